@@ -0,0 +1,56 @@
+// Command jiratest serves Jira fixtures for running the connector offline.
+// With -record it proxies to a real Jira instance and saves its responses to the fixtures directory.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/sssidkn/jira-connector/pkg/jiratest"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+func main() {
+	addr := flag.String("addr", ":8089", "listen address, used as BASE_URL of the connector")
+	fixtures := flag.String("fixtures", "", "fixtures directory, the built-in sample if empty")
+	record := flag.String("record", "", "base URL of the Jira instance to record into -fixtures")
+	flag.Parse()
+
+	var log logger.Logger = logger.NewLogrusLogger()
+
+	var handler http.Handler
+	switch {
+	case *record != "":
+		if *fixtures == "" {
+			panic("-record requires -fixtures")
+		}
+		recorder, err := jiratest.NewRecorder(*record, *fixtures)
+		if err != nil {
+			panic(err)
+		}
+		handler = recorder
+		log.Info("Recording jira responses",
+			logger.Field{Key: "jira", Value: *record},
+			logger.Field{Key: "fixtures", Value: *fixtures})
+	case *fixtures != "":
+		server, err := jiratest.New(os.DirFS(*fixtures))
+		if err != nil {
+			panic(err)
+		}
+		handler = server
+		log.Info("Serving jira fixtures", logger.Field{Key: "fixtures", Value: *fixtures})
+	default:
+		server, err := jiratest.New(jiratest.Sample())
+		if err != nil {
+			panic(err)
+		}
+		handler = server
+		log.Info("Serving sample jira fixtures")
+	}
+
+	log.Info("Fake jira started", logger.Field{Key: "addr", Value: *addr})
+	if err := http.ListenAndServe(*addr, handler); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/config"
+	"github.com/sssidkn/jira-connector/internal/export"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/internal/repository"
+	connector "github.com/sssidkn/jira-connector/internal/service"
+	"github.com/sssidkn/jira-connector/pkg/db/postgres"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// runImport saves Jira exports to the database without starting the servers:
+//
+//	service import [-format json|xml] [-timezone Europe/Moscow] [-source cloud] export.xml...
+func runImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	formatName := flags.String("format", "", "export format: json or xml, detected from the content by default")
+	timezone := flags.String("timezone", "", "timezone of the dates in an XML backup, UTC by default")
+	source := flags.String("source", models.DefaultSource, "id of the Jira source the projects are saved to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: service import [-format json|xml] [-timezone name] [-source id] file...")
+	}
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	var opts []export.Option
+	if *timezone != "" {
+		loc, err := time.LoadLocation(*timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w", err)
+		}
+		opts = append(opts, export.WithLocation(loc))
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(cfg.JiraSources(), func(s config.Source) bool { return s.ID == *source }) {
+		return fmt.Errorf("unknown source: %s", *source)
+	}
+	var log logger.Logger = logger.NewLogrusLogger()
+	log.SetLevel(cfg.LogLevel)
+
+	dbPool, err := postgres.New(cfg.Postgres)
+	if err != nil {
+		return fmt.Errorf("failed to connect to db: %w", err)
+	}
+	defer dbPool.Close()
+	repo := repository.NewProjectRepository(dbPool).ForSource(*source)
+	repo.SetLogger(log)
+
+	jc, err := connector.NewJiraConnector(
+		connector.WithRepository(repo),
+		connector.WithLogger(log),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range flags.Args() {
+		if err = importFile(ctx, jc, name, format, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func importFile(ctx context.Context, jc *connector.JiraConnector, name string,
+	format export.Format, opts []export.Option) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open export: %w", err)
+	}
+	defer file.Close()
+
+	projects, err := jc.ImportProjects(ctx, file, format, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", name, err)
+	}
+	for _, project := range projects {
+		fmt.Printf("%s\t%s\t%d issues\n", project.Key, project.Name, project.TotalIssueCount)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -9,18 +10,28 @@ import (
 
 	"github.com/sssidkn/jira-connector/internal/config"
 	"github.com/sssidkn/jira-connector/internal/jira"
+	"github.com/sssidkn/jira-connector/internal/models"
 	"github.com/sssidkn/jira-connector/internal/repository"
 	connector "github.com/sssidkn/jira-connector/internal/service"
 	grpcSrv "github.com/sssidkn/jira-connector/internal/transport/grpc/server"
 	httpSrv "github.com/sssidkn/jira-connector/internal/transport/http/server"
 	"github.com/sssidkn/jira-connector/pkg/db/postgres"
 	"github.com/sssidkn/jira-connector/pkg/logger"
+	"github.com/sssidkn/jira-connector/pkg/ratelimiter"
 )
 
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(ctx, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg, err := config.New()
 	if err != nil {
 		panic(err)
@@ -29,15 +40,6 @@ func main() {
 	var log logger.Logger = logger.NewLogrusLogger()
 	log.SetLevel(cfg.LogLevel)
 
-	log.Info("Initializing jira client...")
-	jiraClient := jira.NewClient(
-		jira.WithConfig(cfg.Jira),
-		jira.WithLogger(log),
-		jira.WithMaxDelay(cfg.Jira.MaxDelay),
-		jira.WithStartDelay(cfg.Jira.StartDelay),
-	)
-	log.Info("Jira client initialized")
-
 	log.Info("Initializing db connection...")
 	dbPool, err := postgres.New(cfg.Postgres)
 	if err != nil {
@@ -48,20 +50,36 @@ func main() {
 	repo.SetLogger(log)
 	log.Info("DB connection initialized")
 
-	jc, err := connector.NewJiraConnector(
-		connector.WithAPIClient(jiraClient),
-		connector.WithRepository(repo),
-		connector.WithLogger(log),
-	)
+	// clients of sources on the same Jira instance share its request budget
+	buckets := ratelimiter.NewBuckets()
+	connectors := make(map[string]*connector.JiraConnector)
+	for _, source := range cfg.JiraSources() {
+		jc, err := newConnector(ctx, source, cfg.Scheduler, repo.ForSource(source.ID), buckets,
+			log.With(logger.Field{Key: "source", Value: source.ID}))
+		if err != nil {
+			panic(err)
+		}
+		defer jc.Shutdown()
+		connectors[source.ID] = jc
+	}
 
-	if err != nil {
-		panic(err)
+	grpcOpts := []grpcSrv.Option{grpcSrv.WithLogger(log)}
+	httpOpts := []httpSrv.Option{
+		httpSrv.WithLogger(log),
+		httpSrv.WithGRPCAddress(fmt.Sprintf("%s:%d", cfg.Host, cfg.PortGRPC)),
+		httpSrv.WithWebhookSecret(cfg.WebhookSecret),
+	}
+	for id, jc := range connectors {
+		if id == models.DefaultSource {
+			grpcOpts = append(grpcOpts, grpcSrv.WithService(jc))
+			httpOpts = append(httpOpts, httpSrv.WithService(jc))
+			continue
+		}
+		grpcOpts = append(grpcOpts, grpcSrv.WithSource(id, jc))
+		httpOpts = append(httpOpts, httpSrv.WithSource(id, jc))
 	}
 
-	grpcServer := grpcSrv.NewGRPCServer(
-		grpcSrv.WithService(jc),
-		grpcSrv.WithLogger(log),
-	)
+	grpcServer := grpcSrv.NewGRPCServer(grpcOpts...)
 
 	err = grpcServer.Start(fmt.Sprintf("%s:%d", cfg.Host, cfg.PortGRPC))
 	if err != nil {
@@ -69,11 +87,7 @@ func main() {
 	}
 	defer grpcServer.Stop()
 
-	httpServer := httpSrv.NewHTTPServer(
-		httpSrv.WithService(jc),
-		httpSrv.WithLogger(log),
-		httpSrv.WithGRPCAddress(fmt.Sprintf("%s:%d", cfg.Host, cfg.PortGRPC)),
-	)
+	httpServer := httpSrv.NewHTTPServer(httpOpts...)
 	err = httpServer.Start(fmt.Sprintf("%s:%d", cfg.Host, cfg.PortHTTP))
 	if err != nil {
 		panic(err)
@@ -90,3 +104,50 @@ func main() {
 		log.Info("Context cancelled, shutting down...")
 	}
 }
+
+// newConnector makes the Jira clients and the connector of the source and starts its scheduler
+func newConnector(ctx context.Context, source config.Source, schedule config.SchedulerConfig,
+	repo *repository.ProjectRepository, buckets *ratelimiter.Buckets, log logger.Logger) (*connector.JiraConnector, error) {
+
+	log.Info("Initializing jira client...")
+	jiraAuth, err := jira.NewAuthenticator(source.Jira.Auth)
+	if err != nil {
+		return nil, err
+	}
+	jiraClient := jira.NewClient(
+		jira.WithConfig(source.Jira),
+		jira.WithAuthenticator(jiraAuth),
+		jira.WithLogger(log),
+		jira.WithMaxDelay(source.Jira.MaxDelay),
+		jira.WithStartDelay(source.Jira.StartDelay),
+		jira.WithBuckets(buckets),
+	)
+	agileClient := jira.NewAgileClient(jiraClient)
+	if err = jiraClient.ValidateFields(ctx); err != nil {
+		if errors.Is(err, jira.ErrUnknownField) {
+			return nil, err
+		}
+		log.Warn("Failed to validate the field mapping", logger.Field{Key: "error", Value: err.Error()})
+	}
+	log.Info("Jira client initialized")
+
+	jc, err := connector.NewJiraConnector(
+		connector.WithAPIClient(jiraClient),
+		connector.WithAgileClient(agileClient),
+		connector.WithRepository(repo),
+		connector.WithLogger(log),
+		connector.WithSchedule(schedule),
+		connector.WithDescriptionFormat(source.Jira.DescriptionFormat),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = jc.FailInterruptedSyncJobs(ctx); err != nil {
+		return nil, err
+	}
+	if err = jc.StartScheduler(ctx); err != nil {
+		jc.Shutdown()
+		return nil, err
+	}
+	return jc, nil
+}
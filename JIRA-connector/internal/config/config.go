@@ -3,9 +3,12 @@ package config
 import (
 	"fmt"
 	"github.com/sssidkn/jira-connector/internal/jira"
+	"github.com/sssidkn/jira-connector/internal/models"
 	"github.com/sssidkn/jira-connector/pkg/db/postgres"
 	"github.com/sssidkn/jira-connector/pkg/logger"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -17,12 +20,37 @@ const (
 )
 
 type Config struct {
-	Jira     jira.Config     `yaml:"Jira"`
-	Postgres postgres.Config `yaml:"Postgres"`
-	PortHTTP uint            `yaml:"PortHTTP" env:"PORT_HTTP"`
-	PortGRPC uint            `yaml:"PortGRPC" env:"PORT_GRPC"`
-	Host     string          `yaml:"Host" env:"HOST" envDefault:"0.0.0.0"`
-	LogLevel logger.Level
+	// Jira is the default source, used by requests that do not name one
+	Jira jira.Config `yaml:"Jira"`
+	// Sources are the other Jira instances synced into the same database
+	Sources []Source `yaml:"Sources"`
+	// SourcesFile is a YAML file with the Sources list, the env form of Sources
+	SourcesFile string          `yaml:"SourcesFile" env:"SOURCES_FILE"`
+	Postgres    postgres.Config `yaml:"Postgres"`
+	// Scheduler is the periodic sync schedule used until another one is saved through the API
+	Scheduler SchedulerConfig `yaml:"Scheduler"`
+	// WebhookSecret signs Jira webhooks, the webhook endpoint is disabled if it is empty
+	WebhookSecret string `yaml:"WebhookSecret" env:"WEBHOOK_SECRET"`
+	PortHTTP      uint   `yaml:"PortHTTP" env:"PORT_HTTP"`
+	PortGRPC      uint   `yaml:"PortGRPC" env:"PORT_GRPC"`
+	Host          string `yaml:"Host" env:"HOST" envDefault:"0.0.0.0"`
+	LogLevel      logger.Level
+}
+
+// Source is a named Jira instance with its own base URL, auth, rate limits and API version
+type Source struct {
+	// ID selects the source in API requests and is stored with its projects
+	ID   string      `yaml:"ID"`
+	Jira jira.Config `yaml:"Jira"`
+}
+
+// SchedulerConfig is the schedule used until another one is saved through the API
+type SchedulerConfig struct {
+	Enabled bool   `yaml:"Enabled" env:"SCHEDULE_ENABLED"`
+	Cron    string `yaml:"Cron" env:"SCHEDULE_CRON" env-default:"0 * * * *"`
+	// Jitter should be shorter than the interval between runs
+	Jitter        time.Duration `yaml:"Jitter" env:"SCHEDULE_JITTER"`
+	MaxConcurrent int           `yaml:"MaxConcurrent" env:"SCHEDULE_MAX_CONCURRENT" env-default:"1"`
 }
 
 func New() (*Config, error) {
@@ -35,15 +63,74 @@ func New() (*Config, error) {
 			return nil, fmt.Errorf("failed to read config: %v", err)
 		}
 		cfg.LogLevel = logger.LevelDebug
-		return cfg, nil
 	case production:
 		err := cleanenv.ReadEnv(cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read config: %v", err)
 		}
 		cfg.LogLevel = logger.LevelInfo
-		return cfg, nil
 	default:
 		return nil, fmt.Errorf("unknown env: %s", env)
 	}
+
+	if cfg.SourcesFile != "" {
+		var sources struct {
+			Sources []Source `yaml:"Sources"`
+		}
+		if err := cleanenv.ReadConfig(cfg.SourcesFile, &sources); err != nil {
+			return nil, fmt.Errorf("failed to read sources: %v", err)
+		}
+		cfg.Sources = append(cfg.Sources, sources.Sources...)
+	}
+	for i := range cfg.Sources {
+		if err := applyDefaults(&cfg.Sources[i].Jira); err != nil {
+			return nil, fmt.Errorf("failed to read sources: %v", err)
+		}
+	}
+	if err := validateSources(cfg.Sources); err != nil {
+		return nil, err
+	}
+	if err := validateRateLimits(cfg.JiraSources()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// JiraSources returns the default source followed by the other ones
+func (c *Config) JiraSources() []Source {
+	return append([]Source{{ID: models.DefaultSource, Jira: c.Jira}}, c.Sources...)
+}
+
+func validateSources(sources []Source) error {
+	ids := map[string]bool{models.DefaultSource: true}
+	for _, s := range sources {
+		if s.ID == "" {
+			return fmt.Errorf("source id is required")
+		}
+		if ids[s.ID] {
+			return fmt.Errorf("duplicate source id: %s", s.ID)
+		}
+		if s.Jira.BaseURL == "" {
+			return fmt.Errorf("source %s has no base URL", s.ID)
+		}
+		ids[s.ID] = true
+	}
+	return nil
+}
+
+// validateRateLimits rejects sources of one Jira instance with different rate limits,
+// as they share the request budget of the instance
+func validateRateLimits(sources []Source) error {
+	rateLimits := make(map[string]Source, len(sources))
+	for _, s := range sources {
+		baseURL := strings.TrimRight(s.Jira.BaseURL, "/")
+		if baseURL == "" {
+			continue
+		}
+		if other, ok := rateLimits[baseURL]; ok && other.Jira.RateLimit != s.Jira.RateLimit {
+			return fmt.Errorf("sources %s and %s of %s have different rate limits", other.ID, s.ID, baseURL)
+		}
+		rateLimits[baseURL] = s
+	}
+	return nil
 }
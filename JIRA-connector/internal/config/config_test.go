@@ -6,7 +6,9 @@ import (
 	"github.com/sssidkn/jira-connector/pkg/db/postgres"
 	"github.com/sssidkn/jira-connector/pkg/logger"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/stretchr/testify/assert"
@@ -158,6 +160,85 @@ PortGRPC: 9091
 		assert.Equal(t, "production-host", cfg.Host)
 	})
 
+	t.Run("Sources", func(t *testing.T) {
+		os.Setenv("ENV", "DEBUG")
+
+		writeConfig := func(t *testing.T, content string) {
+			require.NoError(t, os.MkdirAll("config", 0755))
+			require.NoError(t, os.WriteFile("config/config.yaml", []byte(content), 0644))
+			t.Cleanup(func() {
+				os.Remove("config/config.yaml")
+				os.Remove("config")
+			})
+		}
+
+		writeConfig(t, `
+Jira:
+  BaseURL: "https://jira.example.com"
+Sources:
+  - ID: cloud
+    Jira:
+      BaseURL: "https://example.atlassian.net"
+      VersionAPI: /rest/api/3
+      RateLimit:
+        Rate: 5
+`)
+		cfg, err := New()
+		require.NoError(t, err)
+
+		// Источник по умолчанию идет первым
+		sources := cfg.JiraSources()
+		require.Len(t, sources, 2)
+		assert.Equal(t, "default", sources[0].ID)
+		assert.Equal(t, "https://jira.example.com", sources[0].Jira.BaseURL)
+		assert.Equal(t, "cloud", sources[1].ID)
+		assert.Equal(t, "/rest/api/3", sources[1].Jira.VersionAPI)
+		assert.Equal(t, 5.0, sources[1].Jira.RateLimit.Rate)
+
+		// Источники из списка получают значения по умолчанию, как и основной
+		for _, source := range sources {
+			assert.Equal(t, jira.BreakerConfig{
+				Window: 20, MinRequests: 10, FailureRate: 0.5, OpenTimeout: 30 * time.Second,
+			}, source.Jira.Breaker, source.ID)
+			assert.Equal(t, 50, source.Jira.RateLimit.RecoverAfter, source.ID)
+		}
+
+		t.Run("SourcesFile", func(t *testing.T) {
+			sourcesFile := filepath.Join(t.TempDir(), "sources.yaml")
+			require.NoError(t, os.WriteFile(sourcesFile, []byte(`
+Sources:
+  - ID: server
+    Jira:
+      BaseURL: "https://jira.other.example.com"
+      Breaker:
+        Window: 5
+`), 0644))
+			writeConfig(t, "SourcesFile: "+sourcesFile+"\n")
+
+			cfg, err := New()
+			require.NoError(t, err)
+			require.Len(t, cfg.Sources, 1)
+			// Заданные значения не заменяются значениями по умолчанию
+			assert.Equal(t, jira.BreakerConfig{
+				Window: 5, MinRequests: 10, FailureRate: 0.5, OpenTimeout: 30 * time.Second,
+			}, cfg.Sources[0].Jira.Breaker)
+		})
+
+		for name, sourcesYAML := range map[string]string{
+			"MissingID":   "  - Jira:\n      BaseURL: https://a.example.com\n",
+			"DuplicateID": "  - ID: default\n    Jira:\n      BaseURL: https://a.example.com\n",
+			"NoBaseURL":   "  - ID: cloud\n",
+			"RateLimitConflict": "  - ID: a\n    Jira:\n      BaseURL: https://a.example.com\n" +
+				"  - ID: b\n    Jira:\n      BaseURL: https://a.example.com/\n      RateLimit:\n        Rate: 5\n",
+		} {
+			t.Run(name, func(t *testing.T) {
+				writeConfig(t, "Sources:\n"+sourcesYAML)
+				_, err := New()
+				assert.Error(t, err)
+			})
+		}
+	})
+
 	t.Run("UnknownEnvironment", func(t *testing.T) {
 		// Устанавливаем неизвестное окружение
 		os.Setenv("ENV", "UNKNOWN_ENV")
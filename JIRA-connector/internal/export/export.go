@@ -0,0 +1,186 @@
+// Package export parses the issues exported from an air-gapped Jira instance:
+// JSON exports of the issue navigator (REST /search results with changelog)
+// and entities.xml of an XML backup.
+package export
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/adf"
+)
+
+type Format string
+
+const (
+	// FormatAuto detects the format by the first character of the export
+	FormatAuto Format = ""
+	FormatJSON Format = "json"
+	FormatXML  Format = "xml"
+)
+
+// ErrUnknownFormat is returned for an export which is neither JSON nor XML
+var ErrUnknownFormat = errors.New("unknown export format")
+
+type options struct {
+	location          *time.Location
+	descriptionFormat adf.Format
+}
+
+type Option func(*options)
+
+// WithLocation sets the timezone of the XML backup dates, which are saved without one. UTC by default.
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+// WithDescriptionFormat sets how ADF descriptions of JSON exports are rendered, "text" by default
+func WithDescriptionFormat(format adf.Format) Option {
+	return func(o *options) {
+		o.descriptionFormat = format
+	}
+}
+
+// ParseFormat accepts "json", "xml" and "" for FormatAuto
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(value)); format {
+	case FormatAuto, FormatJSON, FormatXML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, value)
+	}
+}
+
+// Parse returns the projects of the export with their issues ordered by key
+func Parse(r io.Reader, format Format, opts ...Option) ([]models.JiraProject, error) {
+	o := options{location: time.UTC, descriptionFormat: adf.FormatText}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	br := bufio.NewReader(r)
+	if format == FormatAuto {
+		var err error
+		if format, err = detect(br); err != nil {
+			return nil, err
+		}
+	}
+
+	var projects []models.JiraProject
+	var err error
+	switch format {
+	case FormatJSON:
+		projects, err = parseJSON(br, o)
+	case FormatXML:
+		projects, err = parseXML(br, o)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		sortIssues(projects[i].Issues)
+		projects[i].TotalIssueCount = len(projects[i].Issues)
+	}
+	sort.Slice(projects, func(a, b int) bool {
+		return projects[a].Key < projects[b].Key
+	})
+	return projects, nil
+}
+
+func detect(br *bufio.Reader) (Format, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("%w: empty export", ErrUnknownFormat)
+			}
+			return "", err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+		case 0xEF:
+			// UTF-8 BOM
+			br.Discard(3)
+		case '{', '[':
+			return FormatJSON, nil
+		case '<':
+			return FormatXML, nil
+		default:
+			return "", ErrUnknownFormat
+		}
+	}
+}
+
+// sortIssues orders the issues by the project key and the issue number
+func sortIssues(issues []models.JiraIssue) {
+	sort.SliceStable(issues, func(a, b int) bool {
+		pa, na := splitKey(issues[a].Key)
+		pb, nb := splitKey(issues[b].Key)
+		if pa != pb {
+			return pa < pb
+		}
+		return na < nb
+	})
+}
+
+func splitKey(key string) (string, int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, 0
+	}
+	n := 0
+	fmt.Sscanf(key[i+1:], "%d", &n)
+	return key[:i], n
+}
+
+// projectSet collects the issues by project in the order the projects are met
+type projectSet struct {
+	projects []*models.JiraProject
+	byKey    map[string]*models.JiraProject
+}
+
+func newProjectSet() *projectSet {
+	return &projectSet{byKey: make(map[string]*models.JiraProject)}
+}
+
+func (s *projectSet) get(id, key, name string) *models.JiraProject {
+	p, ok := s.byKey[key]
+	if !ok {
+		p = &models.JiraProject{Key: key}
+		s.byKey[key] = p
+		s.projects = append(s.projects, p)
+	}
+	if p.ID == "" {
+		p.ID = id
+	}
+	if p.Name == "" {
+		p.Name = name
+	}
+	return p
+}
+
+func (s *projectSet) list() ([]models.JiraProject, error) {
+	projects := make([]models.JiraProject, 0, len(s.projects))
+	for _, p := range s.projects {
+		if p.ID == "" {
+			return nil, fmt.Errorf("project %s has no id in the export", p.Key)
+		}
+		if p.Name == "" {
+			p.Name = p.Key
+		}
+		projects = append(projects, *p)
+	}
+	return projects, nil
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+// jsonIssue reads the project of the issue, which models.Fields keeps only the key of
+type jsonIssue struct {
+	models.JiraIssue
+	project struct {
+		ID   string `json:"id"`
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	}
+}
+
+func (i *jsonIssue) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &i.JiraIssue); err != nil {
+		return err
+	}
+	var fields struct {
+		Fields struct {
+			Project *struct {
+				ID   string `json:"id"`
+				Key  string `json:"key"`
+				Name string `json:"name"`
+			} `json:"project"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields.Fields.Project != nil {
+		i.project = *fields.Fields.Project
+	}
+	return nil
+}
+
+// parseJSON reads an array of issues, a /search result or several /search results one after another
+func parseJSON(r io.Reader, o options) ([]models.JiraProject, error) {
+	set := newProjectSet()
+	dec := json.NewDecoder(r)
+	for {
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse json export: %w", err)
+		}
+
+		var issues []jsonIssue
+		if len(value) > 0 && value[0] == '[' {
+			if err := json.Unmarshal(value, &issues); err != nil {
+				return nil, fmt.Errorf("failed to parse json export: %w", err)
+			}
+		} else {
+			var page struct {
+				Issues []jsonIssue `json:"issues"`
+			}
+			if err := json.Unmarshal(value, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse json export: %w", err)
+			}
+			issues = page.Issues
+		}
+
+		for _, issue := range issues {
+			if issue.Key == "" {
+				return nil, errors.New("failed to parse json export: issue without key")
+			}
+			key := issue.project.Key
+			if key == "" {
+				key, _ = splitKey(issue.Key)
+			}
+			issue.Fields.Project.Key = key
+			issue.Render(o.descriptionFormat)
+			p := set.get(issue.project.ID, key, issue.project.Name)
+			p.Issues = append(p.Issues, issue.JiraIssue)
+		}
+	}
+	return set.list()
+}
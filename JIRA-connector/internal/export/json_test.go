@@ -0,0 +1,93 @@
+package export
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sssidkn/jira-connector/pkg/adf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_JSON(t *testing.T) {
+	f, err := os.Open("testdata/search.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	projects, err := Parse(f, FormatAuto, WithDescriptionFormat(adf.FormatMarkdown))
+	require.NoError(t, err)
+
+	require.Len(t, projects, 1)
+	project := projects[0]
+	assert.Equal(t, "10000", project.ID)
+	assert.Equal(t, "TEST", project.Key)
+	assert.Equal(t, "Test Project", project.Name)
+	assert.Equal(t, 3, project.TotalIssueCount)
+
+	// Задачи обеих страниц упорядочены по номеру
+	require.Len(t, project.Issues, 3)
+	assert.Equal(t, "TEST-2", project.Issues[0].Key)
+	assert.Equal(t, "TEST-3", project.Issues[1].Key)
+	assert.Equal(t, "TEST-10", project.Issues[2].Key)
+
+	issue := project.Issues[2]
+	assert.Equal(t, "TEST", issue.Fields.Project.Key)
+	require.Len(t, issue.Changelogs.Histories, 2)
+	assert.Equal(t, "Closed", issue.Changelogs.Histories[1].Items[0].FromString)
+	assert.Equal(t, "Reopened", issue.Changelogs.Histories[1].Items[0].ToString)
+	assert.Equal(t, "Bob Jones", issue.Changelogs.Histories[1].Author.DisplayName)
+	assert.Equal(t, 2025, issue.Fields.Updated.Year())
+
+	assert.Equal(t, "Use **system** colors", project.Issues[0].Fields.Description.Text)
+	// Проект задачи без fields.project определяется по ключу
+	assert.Equal(t, "TEST", project.Issues[1].Fields.Project.Key)
+}
+
+func TestParse_JSONArray(t *testing.T) {
+	export := `[
+		{"id": "1", "key": "A-1", "fields": {"project": {"id": "1", "key": "A", "name": "Alpha"}}},
+		{"id": "2", "key": "B-1", "fields": {"project": {"id": "2", "key": "B"}}}
+	]`
+
+	projects, err := Parse(strings.NewReader(export), FormatJSON)
+	require.NoError(t, err)
+
+	require.Len(t, projects, 2)
+	assert.Equal(t, "Alpha", projects[0].Name)
+	// Без имени проекта используется ключ
+	assert.Equal(t, "B", projects[1].Name)
+}
+
+func TestParse_JSONErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{name: "Invalid", export: `{"issues": [`},
+		{name: "WithoutKey", export: `[{"id": "1", "fields": {"project": {"id": "1", "key": "A"}}}]`},
+		{name: "WithoutProjectID", export: `[{"id": "1", "key": "A-1", "fields": {}}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.export), FormatJSON)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("XML")
+	require.NoError(t, err)
+	assert.Equal(t, FormatXML, format)
+
+	_, err = ParseFormat("csv")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = Parse(strings.NewReader("  \n key,summary"), FormatAuto)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, err = Parse(strings.NewReader(""), FormatAuto)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,523 @@
+package export
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+// backupTimeLayout is the layout of the dates in entities.xml, the fraction is optional
+const backupTimeLayout = "2006-01-02 15:04:05.999999999"
+
+// entity is an element of entities.xml. Short values are attributes, long texts
+// such as descriptions are child elements.
+type entity struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []struct {
+		XMLName xml.Name
+		Value   string `xml:",chardata"`
+	} `xml:",any"`
+}
+
+func (e *entity) get(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	for _, c := range e.Children {
+		if c.XMLName.Local == name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// backupEntities are the entities the issues are built from, the others are skipped
+var backupEntities = map[string]bool{
+	"Project": true, "ProjectKey": true, "Issue": true,
+	"IssueType": true, "Priority": true, "Status": true,
+	"ChangeGroup": true, "ChangeItem": true, "Worklog": true, "Action": true,
+	"IssueLinkType": true, "IssueLink": true,
+	"Component": true, "Version": true, "NodeAssociation": true, "Label": true,
+	"User": true, "ApplicationUser": true,
+}
+
+type backup struct {
+	location    *time.Location
+	projects    map[string]*entity
+	projectKeys map[string]string
+	issues      []*entity
+	names       map[string]map[string]string
+	groups      map[string]*entity
+	groupItems  map[string][]models.Item
+	worklogs    map[string][]*entity
+	comments    map[string][]*entity
+	linkTypes   map[string]*entity
+	links       []*entity
+	components  map[string]*entity
+	versions    map[string]*entity
+	// associations link issues to their components and versions
+	associations map[string][]*entity
+	labels       map[string][]string
+	users        map[string]*entity
+	appUsers     map[string]string
+}
+
+// parseXML reads entities.xml of a Jira XML backup
+func parseXML(r io.Reader, o options) ([]models.JiraProject, error) {
+	b := &backup{
+		location:     o.location,
+		projects:     make(map[string]*entity),
+		projectKeys:  make(map[string]string),
+		names:        map[string]map[string]string{"IssueType": {}, "Priority": {}, "Status": {}},
+		groups:       make(map[string]*entity),
+		groupItems:   make(map[string][]models.Item),
+		worklogs:     make(map[string][]*entity),
+		comments:     make(map[string][]*entity),
+		linkTypes:    make(map[string]*entity),
+		components:   make(map[string]*entity),
+		versions:     make(map[string]*entity),
+		associations: make(map[string][]*entity),
+		labels:       make(map[string][]string),
+		users:        make(map[string]*entity),
+		appUsers:     make(map[string]string),
+	}
+
+	dec := xml.NewDecoder(r)
+	root := true
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse xml export: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if start.Name.Local != "entity-engine-xml" {
+				return nil, fmt.Errorf("failed to parse xml export: unexpected root element %s, entities.xml of a backup is expected",
+					start.Name.Local)
+			}
+			root = false
+			continue
+		}
+		if !backupEntities[start.Name.Local] {
+			if err = dec.Skip(); err != nil {
+				return nil, fmt.Errorf("failed to parse xml export: %w", err)
+			}
+			continue
+		}
+		e := &entity{}
+		if err = dec.DecodeElement(e, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse xml export: %w", err)
+		}
+		b.add(e)
+	}
+	if root {
+		return nil, errors.New("failed to parse xml export: empty export")
+	}
+	return b.build()
+}
+
+func (b *backup) add(e *entity) {
+	switch name := e.XMLName.Local; name {
+	case "Project":
+		b.projects[e.get("id")] = e
+	case "ProjectKey":
+		// the current key of a project, older backups keep it in Project
+		b.projectKeys[e.get("projectId")] = e.get("projectKey")
+	case "Issue":
+		b.issues = append(b.issues, e)
+	case "IssueType", "Priority", "Status":
+		b.names[name][e.get("id")] = e.get("name")
+	case "ChangeGroup":
+		b.groups[e.get("id")] = e
+	case "ChangeItem":
+		group := e.get("group")
+		b.groupItems[group] = append(b.groupItems[group], models.Item{
+			Field:      e.get("field"),
+			From:       e.get("oldvalue"),
+			FromString: e.get("oldstring"),
+			To:         e.get("newvalue"),
+			ToString:   e.get("newstring"),
+		})
+	case "Worklog":
+		b.worklogs[e.get("issue")] = append(b.worklogs[e.get("issue")], e)
+	case "Action":
+		// actions are the comments of issues
+		if e.get("type") == "comment" {
+			b.comments[e.get("issue")] = append(b.comments[e.get("issue")], e)
+		}
+	case "IssueLinkType":
+		b.linkTypes[e.get("id")] = e
+	case "IssueLink":
+		b.links = append(b.links, e)
+	case "Component":
+		b.components[e.get("id")] = e
+	case "Version":
+		b.versions[e.get("id")] = e
+	case "NodeAssociation":
+		if e.get("sourceNodeEntity") == "Issue" {
+			b.associations[e.get("sourceNodeId")] = append(b.associations[e.get("sourceNodeId")], e)
+		}
+	case "Label":
+		// labels of custom fields have fieldid set
+		if e.get("fieldid") == "" {
+			b.labels[e.get("issue")] = append(b.labels[e.get("issue")], e.get("label"))
+		}
+	case "User":
+		b.users[strings.ToLower(e.get("userName"))] = e
+	case "ApplicationUser":
+		b.appUsers[e.get("userKey")] = e.get("lowerUserName")
+	}
+}
+
+func (b *backup) build() ([]models.JiraProject, error) {
+	set := newProjectSet()
+	histories := b.histories()
+	keys := make(map[string]string, len(b.issues))
+	for _, e := range b.issues {
+		keys[e.get("id")] = b.issueKey(e)
+	}
+	relations := b.relations(keys)
+	for _, e := range b.issues {
+		projectID := e.get("project")
+		project, ok := b.projects[projectID]
+		if !ok {
+			return nil, fmt.Errorf("failed to parse xml export: issue %s of unknown project %s", e.get("id"), projectID)
+		}
+		projectKey := b.projectKey(projectID)
+
+		issue := models.JiraIssue{ID: e.get("id"), Key: keys[e.get("id")]}
+		var err error
+		fields := &issue.Fields
+		fields.Project.Key = projectKey
+		fields.Summary = e.get("summary")
+		fields.Description.Text = e.get("description")
+		fields.IssueType.Name = b.name("IssueType", e.get("type"))
+		fields.Priority.Name = b.name("Priority", e.get("priority"))
+		fields.Status.Name = b.name("Status", e.get("status"))
+		fields.Creator = b.user(e.get("creator"))
+		if fields.Creator.Key == "" {
+			fields.Creator = b.user(e.get("reporter"))
+		}
+		fields.Assignee = b.user(e.get("assignee"))
+		if fields.Created, err = b.time(e.get("created")); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+		if fields.Updated, err = b.time(e.get("updated")); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+		if fields.Closed, err = b.time(e.get("resolutiondate")); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+		if spent := e.get("timespent"); spent != "" {
+			seconds, err := strconv.Atoi(spent)
+			if err != nil {
+				return nil, fmt.Errorf("issue %s: invalid timespent %q", issue.Key, spent)
+			}
+			fields.Timetracking.TimeSpentSeconds = &seconds
+		}
+		issue.Changelogs.Histories = histories[issue.ID]
+		if fields.Worklog, err = b.issueWorklogs(issue.ID); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+		if fields.Comment, err = b.issueComments(issue.ID); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+		r := relations[issue.ID]
+		fields.IssueLinks = r.links
+		fields.Parent = r.parent
+		fields.Subtasks = r.subtasks
+		fields.EpicLink = r.epic
+		if err = b.issueMetadata(issue.ID, fields); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+
+		p := set.get(projectID, projectKey, project.get("name"))
+		if p.Versions == nil {
+			if p.Versions, err = b.projectVersions(projectID); err != nil {
+				return nil, fmt.Errorf("project %s: %w", projectKey, err)
+			}
+		}
+		p.Issues = append(p.Issues, issue)
+	}
+	return set.list()
+}
+
+// issueMetadata sets the components, labels, fix and affected versions of the issue
+func (b *backup) issueMetadata(issueID string, fields *models.Fields) error {
+	fields.Components = []models.Component{}
+	fields.FixVersions = []models.Version{}
+	fields.Versions = []models.Version{}
+	for _, e := range b.associations[issueID] {
+		sinkID := e.get("sinkNodeId")
+		switch e.get("associationType") {
+		case "IssueComponent":
+			if c, ok := b.components[sinkID]; ok {
+				fields.Components = append(fields.Components, models.Component{ID: sinkID, Name: c.get("name")})
+			}
+		case "IssueFixVersion", "IssueVersion":
+			version, ok := b.versions[sinkID]
+			if !ok {
+				continue
+			}
+			v, err := b.version(version)
+			if err != nil {
+				return err
+			}
+			if e.get("associationType") == "IssueFixVersion" {
+				fields.FixVersions = append(fields.FixVersions, v)
+			} else {
+				fields.Versions = append(fields.Versions, v)
+			}
+		}
+	}
+	sort.Slice(fields.Components, func(i, j int) bool { return fields.Components[i].Name < fields.Components[j].Name })
+
+	fields.Labels = append([]string{}, b.labels[issueID]...)
+	sort.Strings(fields.Labels)
+	return nil
+}
+
+// projectVersions returns the versions of the project in the order set in Jira
+func (b *backup) projectVersions(projectID string) ([]models.Version, error) {
+	var entities []*entity
+	for _, e := range b.versions {
+		if e.get("project") == projectID {
+			entities = append(entities, e)
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		si, _ := strconv.Atoi(entities[i].get("sequence"))
+		sj, _ := strconv.Atoi(entities[j].get("sequence"))
+		return si < sj
+	})
+
+	versions := make([]models.Version, 0, len(entities))
+	for _, e := range entities {
+		v, err := b.version(e)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func (b *backup) version(e *entity) (models.Version, error) {
+	v := models.Version{
+		ID:          e.get("id"),
+		Name:        e.get("name"),
+		Description: e.get("description"),
+		Archived:    flag(e.get("archived")),
+		Released:    flag(e.get("released")),
+	}
+	var err error
+	if v.StartDate, err = b.time(e.get("startdate")); err != nil {
+		return v, fmt.Errorf("version %s: %w", v.Name, err)
+	}
+	if v.ReleaseDate, err = b.time(e.get("releasedate")); err != nil {
+		return v, fmt.Errorf("version %s: %w", v.Name, err)
+	}
+	return v, nil
+}
+
+// flag reads a boolean, which older backups store as 1
+func flag(value string) bool {
+	return value == "true" || value == "1"
+}
+
+// issueWorklogs returns every worklog of the issue ordered by start time
+func (b *backup) issueWorklogs(issueID string) (*models.Worklogs, error) {
+	entities := b.worklogs[issueID]
+	worklogs := make([]models.Worklog, 0, len(entities))
+	for _, e := range entities {
+		w := models.Worklog{ID: e.get("id"), Author: b.user(e.get("author"))}
+		w.Comment.Text = e.get("body")
+		var err error
+		if w.Started, err = b.time(e.get("startdate")); err != nil {
+			return nil, err
+		}
+		if w.TimeSpentSeconds, err = strconv.Atoi(e.get("timeworked")); err != nil {
+			return nil, fmt.Errorf("invalid timeworked of worklog %s", w.ID)
+		}
+		worklogs = append(worklogs, w)
+	}
+	sort.SliceStable(worklogs, func(a, c int) bool {
+		return worklogs[a].Started.Before(worklogs[c].Started.Time)
+	})
+	return &models.Worklogs{MaxResults: len(worklogs), Total: len(worklogs), Worklogs: worklogs}, nil
+}
+
+// issueComments returns every comment of the issue ordered by creation time
+func (b *backup) issueComments(issueID string) (*models.Comments, error) {
+	entities := b.comments[issueID]
+	comments := make([]models.Comment, 0, len(entities))
+	for _, e := range entities {
+		c := models.Comment{ID: e.get("id"), Author: b.user(e.get("author"))}
+		c.Body.Text = e.get("body")
+		var err error
+		if c.Created, err = b.time(e.get("created")); err != nil {
+			return nil, err
+		}
+		if c.Updated, err = b.time(e.get("updated")); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	sort.SliceStable(comments, func(a, c int) bool {
+		return comments[a].Created.Before(comments[c].Created.Time)
+	})
+	return &models.Comments{MaxResults: len(comments), Total: len(comments), Comments: comments}, nil
+}
+
+// histories returns the change groups of every issue ordered by time
+func (b *backup) histories() map[string][]models.History {
+	ids := make([]string, 0, len(b.groups))
+	for id := range b.groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	histories := make(map[string][]models.History)
+	for _, id := range ids {
+		group := b.groups[id]
+		created, _ := b.time(group.get("created"))
+		issueID := group.get("issue")
+		histories[issueID] = append(histories[issueID], models.History{
+			ID:      id,
+			Created: created,
+			Author:  b.user(group.get("author")),
+			Items:   b.groupItems[id],
+		})
+	}
+	for _, h := range histories {
+		sort.SliceStable(h, func(a, c int) bool {
+			return h[a].Created.Before(h[c].Created.Time)
+		})
+	}
+	return histories
+}
+
+func (b *backup) issueKey(e *entity) string {
+	if key := e.get("key"); key != "" {
+		return key
+	}
+	return b.projectKey(e.get("project")) + "-" + e.get("number")
+}
+
+// issueRelations are the links of an issue stored as IssueLink entities
+type issueRelations struct {
+	links    []models.IssueLink
+	parent   *models.IssueRef
+	subtasks []models.IssueRef
+	epic     string
+}
+
+// relations splits the issue links of the backup into sub-task and epic links and the links shown
+// on both issues. Links to issues missing from the backup are skipped.
+func (b *backup) relations(keys map[string]string) map[string]*issueRelations {
+	relations := make(map[string]*issueRelations, len(keys))
+	for id := range keys {
+		relations[id] = &issueRelations{links: []models.IssueLink{}}
+	}
+	for _, e := range b.links {
+		source, destination := e.get("source"), e.get("destination")
+		sourceKey, ok := keys[source]
+		if !ok {
+			continue
+		}
+		destinationKey, ok := keys[destination]
+		if !ok {
+			continue
+		}
+		linkType := b.linkTypes[e.get("linktype")]
+		if linkType == nil {
+			continue
+		}
+		switch {
+		case linkType.get("style") == "jira_subtask":
+			relations[destination].parent = &models.IssueRef{ID: source, Key: sourceKey}
+			relations[source].subtasks = append(relations[source].subtasks,
+				models.IssueRef{ID: destination, Key: destinationKey})
+		case linkType.get("linkname") == "Epic-Story Link":
+			relations[destination].epic = sourceKey
+		default:
+			link := models.IssueLink{ID: e.get("id")}
+			link.Type.Name = linkType.get("linkname")
+			link.Type.Inward = linkType.get("inward")
+			link.Type.Outward = linkType.get("outward")
+			outward, inward := link, link
+			outward.OutwardIssue = &models.IssueRef{ID: destination, Key: destinationKey}
+			inward.InwardIssue = &models.IssueRef{ID: source, Key: sourceKey}
+			relations[source].links = append(relations[source].links, outward)
+			relations[destination].links = append(relations[destination].links, inward)
+		}
+	}
+	return relations
+}
+
+func (b *backup) projectKey(projectID string) string {
+	if key, ok := b.projectKeys[projectID]; ok {
+		return key
+	}
+	if project := b.projects[projectID]; project != nil {
+		if key := project.get("key"); key != "" {
+			return key
+		}
+		return project.get("originalkey")
+	}
+	return ""
+}
+
+// name returns the name of a constant entity such as Status, or the id if it is not in the backup
+func (b *backup) name(entity, id string) string {
+	if name, ok := b.names[entity][id]; ok {
+		return name
+	}
+	return id
+}
+
+// user resolves a user key, which is a JIRAUSER id in newer versions, to the user name and display name
+func (b *backup) user(key string) models.JiraUser {
+	if key == "" {
+		return models.JiraUser{}
+	}
+	user := models.JiraUser{Key: key, Name: key, DisplayName: key}
+	lowerName, ok := b.appUsers[key]
+	if !ok {
+		lowerName = strings.ToLower(key)
+	}
+	if e, ok := b.users[lowerName]; ok {
+		user.Name = e.get("userName")
+		if displayName := e.get("displayName"); displayName != "" {
+			user.DisplayName = displayName
+		}
+	}
+	return user
+}
+
+func (b *backup) time(value string) (models.JiraTime, error) {
+	if value == "" {
+		return models.JiraTime{}, nil
+	}
+	t, err := time.ParseInLocation(backupTimeLayout, value, b.location)
+	if err != nil {
+		return models.JiraTime{}, fmt.Errorf("invalid date %q", value)
+	}
+	return models.JiraTime{Time: t}, nil
+}
@@ -0,0 +1,160 @@
+package export
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_XMLBackup(t *testing.T) {
+	f, err := os.Open("testdata/entities.xml")
+	require.NoError(t, err)
+	defer f.Close()
+
+	msk := time.FixedZone("MSK", 3*60*60)
+	projects, err := Parse(f, FormatAuto, WithLocation(msk))
+	require.NoError(t, err)
+
+	require.Len(t, projects, 2)
+	assert.Equal(t, "OLD", projects[0].Key)
+	assert.Equal(t, "Old Project", projects[0].Name)
+	assert.Equal(t, 1, projects[0].TotalIssueCount)
+
+	project := projects[1]
+	assert.Equal(t, "10000", project.ID)
+	assert.Equal(t, "TEST", project.Key)
+	assert.Equal(t, "Test Project", project.Name)
+	require.Len(t, project.Issues, 2)
+	assert.Empty(t, projects[0].Versions)
+
+	// Версии упорядочены как в Jira, даты читаются в зоне сервера
+	require.Len(t, project.Versions, 2)
+	version := project.Versions[0]
+	assert.Equal(t, "10110", version.ID)
+	assert.Equal(t, "1.0", version.Name)
+	assert.Equal(t, "First release", version.Description)
+	assert.True(t, version.Released)
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, msk), version.StartDate.Time)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, msk), version.ReleaseDate.Time)
+	assert.False(t, project.Versions[1].Released)
+	assert.True(t, project.Versions[1].StartDate.IsZero())
+
+	issue := project.Issues[0]
+	assert.Equal(t, "10101", issue.ID)
+	assert.Equal(t, "TEST-1", issue.Key)
+	assert.Equal(t, "Login fails on Safari", issue.Fields.Summary)
+	assert.Equal(t, "Steps:\n1. Open the login page in Safari", issue.Fields.Description.Text)
+	assert.Equal(t, "Bug", issue.Fields.IssueType.Name)
+	assert.Equal(t, "Critical", issue.Fields.Priority.Name)
+	assert.Equal(t, "Closed", issue.Fields.Status.Name)
+	assert.Equal(t, "Alice Smith", issue.Fields.Creator.DisplayName)
+	assert.Equal(t, "alice", issue.Fields.Creator.Name)
+	assert.Equal(t, "Bob Jones", issue.Fields.Assignee.DisplayName)
+	assert.Equal(t, time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC), issue.Fields.Created.UTC())
+	assert.Equal(t, time.Date(2025, 3, 5, 13, 30, 0, 0, time.UTC), issue.Fields.Closed.UTC())
+	require.NotNil(t, issue.Fields.Timetracking.TimeSpentSeconds)
+	assert.Equal(t, 7200, *issue.Fields.Timetracking.TimeSpentSeconds)
+
+	// История упорядочена по времени, а не по порядку в файле
+	require.Len(t, issue.Changelogs.Histories, 2)
+	first, second := issue.Changelogs.Histories[0], issue.Changelogs.Histories[1]
+	assert.Equal(t, "Alice Smith", first.Author.DisplayName)
+	assert.Equal(t, models.Item{Field: "status", From: "1", FromString: "Open", To: "3", ToString: "In Progress"},
+		first.Items[0])
+	assert.Equal(t, "Bob Jones", second.Author.DisplayName)
+	require.Len(t, second.Items, 2)
+	assert.Equal(t, "status", second.Items[0].Field)
+	assert.Equal(t, "Closed", second.Items[0].ToString)
+
+	require.NotNil(t, issue.Fields.Worklog)
+	require.Len(t, issue.Fields.Worklog.Worklogs, 2)
+	worklog := issue.Fields.Worklog.Worklogs[0]
+	assert.Equal(t, "10500", worklog.ID)
+	assert.Equal(t, "Alice Smith", worklog.Author.DisplayName)
+	assert.Equal(t, "Investigated the login form", worklog.Comment.Text)
+	assert.Equal(t, time.Date(2025, 3, 3, 6, 0, 0, 0, time.UTC), worklog.Started.UTC())
+	assert.Equal(t, 3600, worklog.TimeSpentSeconds)
+	assert.True(t, issue.Fields.Worklog.Complete())
+
+	// Действия других типов не являются комментариями
+	require.NotNil(t, issue.Fields.Comment)
+	require.Len(t, issue.Fields.Comment.Comments, 2)
+	comment := issue.Fields.Comment.Comments[0]
+	assert.Equal(t, "10600", comment.ID)
+	assert.Equal(t, "Alice Smith", comment.Author.DisplayName)
+	assert.Equal(t, "Reproduced on Safari 17", comment.Body.Text)
+	assert.Equal(t, time.Date(2025, 3, 2, 8, 0, 0, 0, time.UTC), comment.Created.UTC())
+	comment = issue.Fields.Comment.Comments[1]
+	assert.Equal(t, "Fixed in the next build", comment.Body.Text)
+	assert.Equal(t, time.Date(2025, 3, 5, 13, 10, 0, 0, time.UTC), comment.Updated.UTC())
+	assert.True(t, issue.Fields.Comment.Complete())
+
+	// Связь с задачей не из выгрузки пропускается
+	require.Len(t, issue.Fields.IssueLinks, 1)
+	link := issue.Fields.IssueLinks[0]
+	assert.Equal(t, "10700", link.ID)
+	assert.Equal(t, "Blocks", link.Type.Name)
+	source, target := link.Ends(issue.Key)
+	assert.Equal(t, "TEST-3", source)
+	assert.Equal(t, "TEST-1", target)
+	assert.Nil(t, issue.Fields.Parent)
+	assert.Equal(t, []models.IssueRef{{ID: "10103", Key: "TEST-3"}}, issue.Fields.Subtasks)
+
+	// Метки пользовательских полей не считаются метками задачи
+	assert.Equal(t, []models.Component{{ID: "10201", Name: "Browsers"}, {ID: "10200", Name: "Login"}},
+		issue.Fields.Components)
+	assert.Equal(t, []string{"login", "safari"}, issue.Fields.Labels)
+	require.Len(t, issue.Fields.FixVersions, 1)
+	assert.Equal(t, "1.0", issue.Fields.FixVersions[0].Name)
+	assert.Empty(t, issue.Fields.Versions)
+
+	issue = project.Issues[1]
+	assert.Equal(t, "TEST-3", issue.Key)
+	assert.Equal(t, "Bob Jones", issue.Fields.Creator.DisplayName)
+	assert.Equal(t, "Alice Smith", issue.Fields.Assignee.DisplayName)
+	assert.True(t, issue.Fields.Closed.IsZero())
+	assert.Nil(t, issue.Fields.Timetracking.TimeSpentSeconds)
+	assert.Empty(t, issue.Changelogs.Histories)
+	require.Len(t, issue.Fields.IssueLinks, 1)
+	assert.Equal(t, "is blocked by", issue.Fields.IssueLinks[0].Type.Inward)
+	require.NotNil(t, issue.Fields.IssueLinks[0].OutwardIssue)
+	assert.Equal(t, "TEST-1", issue.Fields.IssueLinks[0].OutwardIssue.Key)
+	require.NotNil(t, issue.Fields.Parent)
+	assert.Equal(t, "TEST-1", issue.Fields.Parent.Key)
+	assert.Equal(t, "OLD-1", issue.Fields.EpicLink)
+	assert.Empty(t, issue.Fields.Components)
+	assert.NotNil(t, issue.Fields.Labels)
+	assert.Empty(t, issue.Fields.Labels)
+	require.Len(t, issue.Fields.FixVersions, 1)
+	assert.Equal(t, "10111", issue.Fields.FixVersions[0].ID)
+	require.Len(t, issue.Fields.Versions, 1)
+	assert.Equal(t, "10110", issue.Fields.Versions[0].ID)
+}
+
+func TestParse_XMLErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{name: "NotBackup", export: `<rss version="0.92"><channel></channel></rss>`},
+		{name: "Invalid", export: `<entity-engine-xml><Issue id="1"`},
+		{name: "UnknownProject", export: `<entity-engine-xml><Issue id="1" project="5" number="1"/></entity-engine-xml>`},
+		{
+			name: "InvalidDate",
+			export: `<entity-engine-xml><Project id="1" key="A"/>` +
+				`<Issue id="1" project="1" number="1" created="yesterday"/></entity-engine-xml>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.export), FormatXML)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// VersionAgile is the Jira Software REST API of boards and sprints
+const VersionAgile = "/rest/agile/1.0"
+
+// agilePageSize is the largest page the agile API returns
+const agilePageSize = 50
+
+// AgileClient reads boards and sprints from the Jira Software API. It shares the HTTP client,
+// authentication, rate limit and circuit breaker of the REST API client.
+type AgileClient struct {
+	client *Client
+	logger logger.Logger
+}
+
+func NewAgileClient(client *Client) *AgileClient {
+	return &AgileClient{
+		client: client,
+		logger: client.logger.With(logger.Field{Key: "api", Value: "agile"}),
+	}
+}
+
+// GetProjectSprints returns the boards of the project and the sprints of its scrum boards
+// with the issues in every sprint
+func (a *AgileClient) GetProjectSprints(ctx context.Context, projectKey string) (*models.ProjectSprints, error) {
+	boards, err := a.GetBoards(ctx, projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get boards: %w", err)
+	}
+	result := &models.ProjectSprints{ProjectKey: projectKey, Boards: boards}
+
+	// a sprint is listed by every board whose filter matches its issues
+	seen := make(map[int]bool)
+	for _, board := range boards {
+		if board.Type == "kanban" {
+			continue
+		}
+		sprints, err := a.GetSprints(ctx, board.ID)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			// the board does not support sprints
+			a.logger.Debug("Skipping board without sprints", logger.Field{Key: "board", Value: board.ID})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sprints of board %d: %w", board.ID, err)
+		}
+		for _, sprint := range sprints {
+			if seen[sprint.ID] {
+				continue
+			}
+			seen[sprint.ID] = true
+			if sprint.BoardID == 0 {
+				sprint.BoardID = board.ID
+			}
+			if sprint.IssueKeys, err = a.GetSprintIssueKeys(ctx, sprint.ID); err != nil {
+				return nil, fmt.Errorf("failed to get issues of sprint %d: %w", sprint.ID, err)
+			}
+			result.Sprints = append(result.Sprints, sprint)
+		}
+	}
+	a.logger.Info("Fetched sprints", logger.Field{Key: "project_key", Value: projectKey},
+		logger.Field{Key: "boards", Value: len(result.Boards)},
+		logger.Field{Key: "sprints", Value: len(result.Sprints)})
+	return result, nil
+}
+
+// GetBoards returns the boards whose filter includes the project
+func (a *AgileClient) GetBoards(ctx context.Context, projectKey string) ([]models.Board, error) {
+	return getValues[models.Board](ctx, a, "/board", url.Values{"projectKeyOrId": []string{projectKey}})
+}
+
+// GetSprints returns every sprint of the board, Jira returns 400 for boards without sprints
+func (a *AgileClient) GetSprints(ctx context.Context, boardID int) ([]models.Sprint, error) {
+	return getValues[models.Sprint](ctx, a, fmt.Sprintf("/board/%d/sprint", boardID), url.Values{})
+}
+
+// GetSprintIssueKeys returns the keys of the issues in the sprint
+func (a *AgileClient) GetSprintIssueKeys(ctx context.Context, sprintID int) ([]string, error) {
+	var keys []string
+	startAt := 0
+	for {
+		params := url.Values{
+			"startAt":    []string{strconv.Itoa(startAt)},
+			"maxResults": []string{strconv.Itoa(agilePageSize)},
+			"fields":     []string{"key"},
+		}
+		var page struct {
+			Total  int `json:"total"`
+			Issues []struct {
+				Key string `json:"key"`
+			} `json:"issues"`
+		}
+		if err := a.get(ctx, fmt.Sprintf("/sprint/%d/issue", sprintID), params, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			keys = append(keys, issue.Key)
+		}
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return keys, nil
+		}
+	}
+}
+
+// getValues pages through an agile list endpoint, which answers {"isLast": false, "values": [...]}
+func getValues[T any](ctx context.Context, a *AgileClient, endpoint string, params url.Values) ([]T, error) {
+	var values []T
+	startAt := 0
+	for {
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+		var page struct {
+			IsLast bool `json:"isLast"`
+			Values []T  `json:"values"`
+		}
+		if err := a.get(ctx, endpoint, params, &page); err != nil {
+			return nil, err
+		}
+		values = append(values, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return values, nil
+		}
+	}
+}
+
+func (a *AgileClient) get(ctx context.Context, endpoint string, params url.Values, result interface{}) error {
+	link := fmt.Sprintf("%s%s%s?%s", a.client.config.BaseURL, VersionAgile, endpoint, params.Encode())
+	return a.client.withRetry(ctx, func() error {
+		return a.client.doRequest(ctx, link, result)
+	})
+}
@@ -0,0 +1,126 @@
+package jira_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/jira"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// agileServer отдает доски и спринты Jira Software по одному элементу на странице
+func agileServer(t *testing.T) *httptest.Server {
+	boards := []map[string]any{
+		{"id": 1, "name": "TEST scrum", "type": "scrum"},
+		{"id": 2, "name": "TEST kanban", "type": "kanban"},
+		{"id": 3, "name": "TEST team", "type": "simple"},
+		{"id": 4, "name": "TEST and OTHER", "type": "scrum"},
+	}
+	sprints := map[string][]map[string]any{
+		"1": {
+			{"id": 10, "originBoardId": 1, "name": "Sprint 1", "state": "closed",
+				"startDate": "2025-03-03T09:00:00.000Z", "endDate": "2025-03-17T09:00:00.000Z",
+				"completeDate": "2025-03-17T10:30:00.000Z", "goal": "Login"},
+			{"id": 11, "originBoardId": 1, "name": "Sprint 2", "state": "future"},
+		},
+		// спринт другой доски виден на общей доске
+		"4": {{"id": 11, "originBoardId": 1, "name": "Sprint 2", "state": "future"}},
+	}
+	issues := map[string][]string{"10": {"TEST-1", "TEST-2", "OTHER-1"}, "11": {"TEST-3"}}
+
+	values := func(w http.ResponseWriter, r *http.Request, items []map[string]any) {
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		page := items[min(startAt, len(items)):min(startAt+1, len(items))]
+		json.NewEncoder(w).Encode(map[string]any{
+			"startAt": startAt, "maxResults": 1, "isLast": startAt+1 >= len(items), "values": page,
+		})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/board", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TEST", r.URL.Query().Get("projectKeyOrId"))
+		values(w, r, boards)
+	})
+	mux.HandleFunc("/rest/agile/1.0/board/{id}/sprint", func(w http.ResponseWriter, r *http.Request) {
+		switch id := r.PathValue("id"); id {
+		case "2":
+			t.Error("sprints of a kanban board requested")
+		case "3":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages":["The board does not support sprints"]}`))
+		default:
+			values(w, r, sprints[id])
+		}
+	})
+	mux.HandleFunc("/rest/agile/1.0/sprint/{id}/issue", func(w http.ResponseWriter, r *http.Request) {
+		keys := issues[r.PathValue("id")]
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		page := make([]map[string]string, 0)
+		for _, key := range keys[min(startAt, len(keys)):min(startAt+2, len(keys))] {
+			page = append(page, map[string]string{"key": key})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"startAt": startAt, "total": len(keys), "issues": page})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAgileClient_GetProjectSprints(t *testing.T) {
+	server := agileServer(t)
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{BaseURL: server.URL, VersionAPI: jira.VersionAPI2}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+	agile := jira.NewAgileClient(client)
+
+	result, err := agile.GetProjectSprints(context.Background(), "TEST")
+	require.NoError(t, err)
+
+	assert.Equal(t, "TEST", result.ProjectKey)
+	require.Len(t, result.Boards, 4)
+	assert.Equal(t, "kanban", result.Boards[1].Type)
+
+	// Спринт 11 есть на двух досках, но сохраняется один раз
+	require.Len(t, result.Sprints, 2)
+	sprint := result.Sprints[0]
+	assert.Equal(t, 10, sprint.ID)
+	assert.Equal(t, 1, sprint.BoardID)
+	assert.Equal(t, "closed", sprint.State)
+	assert.Equal(t, "Login", sprint.Goal)
+	assert.Equal(t, time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), sprint.StartDate.UTC())
+	assert.Equal(t, time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC), sprint.EndDate.UTC())
+	assert.Equal(t, time.Date(2025, 3, 17, 10, 30, 0, 0, time.UTC), sprint.CompleteDate.UTC())
+	assert.Equal(t, []string{"TEST-1", "TEST-2", "OTHER-1"}, sprint.IssueKeys)
+
+	sprint = result.Sprints[1]
+	assert.Equal(t, 11, sprint.ID)
+	assert.True(t, sprint.StartDate.IsZero())
+	assert.True(t, sprint.CompleteDate.IsZero())
+	assert.Equal(t, []string{"TEST-3"}, sprint.IssueKeys)
+}
+
+func TestAgileClient_Errors(t *testing.T) {
+	// Jira без Jira Software не знает агильного API
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{BaseURL: server.URL, VersionAPI: jira.VersionAPI2}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+
+	_, err := jira.NewAgileClient(client).GetProjectSprints(context.Background(), "TEST")
+	var apiErr *jira.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
@@ -0,0 +1,374 @@
+package jira
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type AuthType string
+
+const (
+	AuthNone   AuthType = ""
+	AuthBasic  AuthType = "basic"
+	AuthBearer AuthType = "bearer"
+	AuthOAuth1 AuthType = "oauth1"
+	AuthOAuth2 AuthType = "oauth2"
+)
+
+// AuthConfig describes how the client authenticates against Jira.
+// Every secret can be given inline, through the environment or as a path to a file.
+type AuthConfig struct {
+	Type             AuthType `yaml:"Type" env:"JIRA_AUTH_TYPE"`
+	Username         string   `yaml:"Username" env:"JIRA_USERNAME"`
+	Token            string   `yaml:"Token" env:"JIRA_TOKEN"`
+	TokenFile        string   `yaml:"TokenFile" env:"JIRA_TOKEN_FILE"`
+	ConsumerKey      string   `yaml:"ConsumerKey" env:"JIRA_CONSUMER_KEY"`
+	PrivateKeyFile   string   `yaml:"PrivateKeyFile" env:"JIRA_PRIVATE_KEY_FILE"`
+	ClientID         string   `yaml:"ClientID" env:"JIRA_CLIENT_ID"`
+	ClientSecret     string   `yaml:"ClientSecret" env:"JIRA_CLIENT_SECRET"`
+	ClientSecretFile string   `yaml:"ClientSecretFile" env:"JIRA_CLIENT_SECRET_FILE"`
+	TokenURL         string   `yaml:"TokenURL" env:"JIRA_TOKEN_URL"`
+	Scopes           []string `yaml:"Scopes" env:"JIRA_SCOPES"`
+}
+
+// AuthError is returned when Jira rejects the credentials (401) or the permissions (403).
+type AuthError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("Jira authentication error: %d - %s", e.StatusCode, e.Message)
+}
+
+// Authenticator signs outgoing requests to Jira.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// NewAuthenticator builds an Authenticator for the configured auth mode.
+func NewAuthenticator(cfg AuthConfig) (Authenticator, error) {
+	switch cfg.Type {
+	case AuthNone:
+		return noAuth{}, nil
+	case AuthBasic:
+		token, err := readSecret(cfg.Token, cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Username == "" || token == "" {
+			return nil, errors.New("basic auth requires username and token")
+		}
+		return &basicAuth{username: cfg.Username, token: token}, nil
+	case AuthBearer:
+		token, err := readSecret(cfg.Token, cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			return nil, errors.New("bearer auth requires token")
+		}
+		return &bearerAuth{token: token}, nil
+	case AuthOAuth1:
+		return newOAuth1(cfg)
+	case AuthOAuth2:
+		return newOAuth2(cfg)
+	default:
+		return nil, fmt.Errorf("unknown auth type: %s", cfg.Type)
+	}
+}
+
+func readSecret(value, file string) (string, error) {
+	if value != "" || file == "" {
+		return value, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+type noAuth struct{}
+
+func (noAuth) Authenticate(*http.Request) error {
+	return nil
+}
+
+// failedAuth keeps a configuration error until the first request is made.
+type failedAuth struct {
+	err error
+}
+
+func (a failedAuth) Authenticate(*http.Request) error {
+	return a.err
+}
+
+type basicAuth struct {
+	username string
+	token    string
+}
+
+func (a *basicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.username, a.token)
+	return nil
+}
+
+type bearerAuth struct {
+	token string
+}
+
+func (a *bearerAuth) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// oauth1 implements the RSA-SHA1 flavour of OAuth 1.0a used by Jira application links.
+type oauth1 struct {
+	consumerKey string
+	token       string
+	key         *rsa.PrivateKey
+	now         func() time.Time
+}
+
+func newOAuth1(cfg AuthConfig) (*oauth1, error) {
+	token, err := readSecret(cfg.Token, cfg.TokenFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ConsumerKey == "" || token == "" || cfg.PrivateKeyFile == "" {
+		return nil, errors.New("oauth1 auth requires consumer key, token and private key file")
+	}
+	data, err := os.ReadFile(cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth1{consumerKey: cfg.ConsumerKey, token: token, key: key, now: time.Now}, nil
+}
+
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode private key PEM")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
+	return rsaKey, nil
+}
+
+func (a *oauth1) Authenticate(req *http.Request) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     a.consumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(a.now().Unix(), 10),
+		"oauth_token":            a.token,
+		"oauth_version":          "1.0",
+	}
+
+	signature, err := a.sign(req, oauthParams)
+	if err != nil {
+		return err
+	}
+	oauthParams["oauth_signature"] = signature
+
+	keys := make([]string, 0, len(oauthParams))
+	for k := range oauthParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, k, oauthEscape(oauthParams[k])))
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(parts, ", "))
+	return nil
+}
+
+func (a *oauth1) sign(req *http.Request, oauthParams map[string]string) (string, error) {
+	type pair struct{ k, v string }
+	pairs := make([]pair, 0, len(oauthParams))
+	for k, v := range oauthParams {
+		pairs = append(pairs, pair{oauthEscape(k), oauthEscape(v)})
+	}
+	for k, values := range req.URL.Query() {
+		for _, v := range values {
+			pairs = append(pairs, pair{oauthEscape(k), oauthEscape(v)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].k == pairs[j].k {
+			return pairs[i].v < pairs[j].v
+		}
+		return pairs[i].k < pairs[j].k
+	})
+	encoded := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		encoded = append(encoded, p.k+"="+p.v)
+	}
+
+	baseURL := *req.URL
+	baseURL.RawQuery = ""
+	baseURL.Fragment = ""
+	baseString := strings.Join([]string{
+		strings.ToUpper(req.Method),
+		oauthEscape(baseURL.String()),
+		oauthEscape(strings.Join(encoded, "&")),
+	}, "&")
+
+	hash := sha1.Sum([]byte(baseString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA1, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign request: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// oauthEscape percent-encodes a value as required by RFC 5849.
+func oauthEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+const (
+	// defaultTokenLifetime is assumed when the token endpoint does not report expires_in
+	defaultTokenLifetime = time.Hour
+	// tokenRefreshMargin is how much earlier than the server expires the token it is refreshed
+	tokenRefreshMargin = 30 * time.Second
+)
+
+// oauth2 implements the OAuth 2.0 client credentials grant and caches the access token.
+type oauth2 struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	scopes       []string
+	httpClient   *http.Client
+	now          func() time.Time
+
+	m       sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newOAuth2(cfg AuthConfig) (*oauth2, error) {
+	secret, err := readSecret(cfg.ClientSecret, cfg.ClientSecretFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ClientID == "" || secret == "" || cfg.TokenURL == "" {
+		return nil, errors.New("oauth2 auth requires client id, client secret and token url")
+	}
+	return &oauth2{
+		clientID:     cfg.ClientID,
+		clientSecret: secret,
+		tokenURL:     cfg.TokenURL,
+		scopes:       cfg.Scopes,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		now:          time.Now,
+	}, nil
+}
+
+func (a *oauth2) Authenticate(req *http.Request) error {
+	token, err := a.accessToken(req)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *oauth2) accessToken(req *http.Request) (string, error) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if a.token != "" && a.now().Before(a.expires) {
+		return a.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{a.clientID},
+		"client_secret": []string{a.clientSecret},
+	}
+	if len(a.scopes) > 0 {
+		form.Set("scope", strings.Join(a.scopes, " "))
+	}
+	tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, a.tokenURL,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// the token endpoint is the authorization server, not the Jira instance: it has no share
+	// in the request budget of the instance, and an open circuit of the instance stops
+	// requests before they are authenticated
+	resp, err := a.httpClient.Do(tokenReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &AuthError{StatusCode: resp.StatusCode, Message: "failed to obtain OAuth 2.0 access token"}
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode access token: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", errors.New("token endpoint returned empty access token")
+	}
+
+	a.token = result.AccessToken
+	a.expires = a.now().Add(tokenLifetime(result.ExpiresIn))
+	return a.token, nil
+}
+
+// tokenLifetime returns how long the token is used: a little shorter than the server keeps it,
+// and half of the lifetime of tokens which expire within a minute
+func tokenLifetime(expiresIn int) time.Duration {
+	lifetime := time.Duration(expiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = defaultTokenLifetime
+	}
+	return lifetime - min(tokenRefreshMargin, lifetime/2)
+}
@@ -0,0 +1,190 @@
+package jira_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sssidkn/jira-connector/internal/jira"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authServer отдает список проектов, только если заголовок Authorization совпал с ожидаемым
+func authServer(t *testing.T, check func(header string) bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !check(r.Header.Get("Authorization")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errorMessages":["You are not authenticated"]}`))
+			return
+		}
+		json.NewEncoder(w).Encode([]models.ProjectInfo{{ID: "10000", Key: "TEST", Name: "Test Project"}})
+	}))
+}
+
+func newAuthClient(baseURL string, auth jira.AuthConfig) *jira.Client {
+	return jira.NewClient(
+		jira.WithConfig(jira.Config{BaseURL: baseURL, VersionAPI: "/rest/api/2", Auth: auth}),
+		jira.WithLogger(&logger.TestLogger{}),
+	)
+}
+
+func TestAuth_Basic(t *testing.T) {
+	server := authServer(t, func(header string) bool {
+		return header == "Basic dXNlckBleGFtcGxlLmNvbTpzZWNyZXQ="
+	})
+	defer server.Close()
+
+	client := newAuthClient(server.URL, jira.AuthConfig{
+		Type:     jira.AuthBasic,
+		Username: "user@example.com",
+		Token:    "secret",
+	})
+
+	projects, err := client.GetProjects(context.Background(), 10, 1, "")
+	require.NoError(t, err)
+	assert.Len(t, projects, 1)
+}
+
+func TestAuth_BearerFromFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("pat-token\n"), 0600))
+
+	server := authServer(t, func(header string) bool {
+		return header == "Bearer pat-token"
+	})
+	defer server.Close()
+
+	client := newAuthClient(server.URL, jira.AuthConfig{
+		Type:      jira.AuthBearer,
+		TokenFile: tokenFile,
+	})
+
+	_, err := client.GetProjects(context.Background(), 10, 1, "")
+	require.NoError(t, err)
+}
+
+func TestAuth_OAuth2ClientCredentials(t *testing.T) {
+	// Без expires_in и с коротким сроком токен тоже кешируется
+	for _, expiresIn := range []int{3600, 0, 10} {
+		t.Run(strconv.Itoa(expiresIn), func(t *testing.T) {
+			var tokenRequests int32
+			tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&tokenRequests, 1)
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+				assert.Equal(t, "client", r.PostForm.Get("client_id"))
+				assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+				assert.Equal(t, "read:jira-work", r.PostForm.Get("scope"))
+				token := map[string]interface{}{"access_token": "access"}
+				if expiresIn > 0 {
+					token["expires_in"] = expiresIn
+				}
+				json.NewEncoder(w).Encode(token)
+			}))
+			defer tokenServer.Close()
+
+			server := authServer(t, func(header string) bool {
+				return header == "Bearer access"
+			})
+			defer server.Close()
+
+			client := newAuthClient(server.URL, jira.AuthConfig{
+				Type:         jira.AuthOAuth2,
+				ClientID:     "client",
+				ClientSecret: "client-secret",
+				TokenURL:     tokenServer.URL,
+				Scopes:       []string{"read:jira-work"},
+			})
+
+			for i := 0; i < 3; i++ {
+				_, err := client.GetProjects(context.Background(), 10, 1, "")
+				require.NoError(t, err)
+			}
+			// Токен кешируется до истечения срока действия
+			assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+		})
+	}
+}
+
+func TestAuth_OAuth1(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "jira.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	server := authServer(t, func(header string) bool {
+		return strings.HasPrefix(header, "OAuth ") &&
+			strings.Contains(header, `oauth_consumer_key="analyzer"`) &&
+			strings.Contains(header, `oauth_token="access-token"`) &&
+			strings.Contains(header, `oauth_signature_method="RSA-SHA1"`) &&
+			strings.Contains(header, `oauth_signature="`)
+	})
+	defer server.Close()
+
+	client := newAuthClient(server.URL, jira.AuthConfig{
+		Type:           jira.AuthOAuth1,
+		ConsumerKey:    "analyzer",
+		Token:          "access-token",
+		PrivateKeyFile: keyFile,
+	})
+
+	_, err = client.GetProjects(context.Background(), 10, 1, "")
+	require.NoError(t, err)
+}
+
+func TestAuth_Errors(t *testing.T) {
+	t.Run("Unauthorized", func(t *testing.T) {
+		server := authServer(t, func(string) bool { return false })
+		defer server.Close()
+
+		client := newAuthClient(server.URL, jira.AuthConfig{})
+
+		_, err := client.GetProjects(context.Background(), 10, 1, "")
+		var authErr *jira.AuthError
+		require.True(t, errors.As(err, &authErr))
+		assert.Equal(t, http.StatusUnauthorized, authErr.StatusCode)
+	})
+
+	t.Run("ForbiddenProject", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := newAuthClient(server.URL, jira.AuthConfig{})
+
+		_, err := client.GetProject(context.Background(), "TEST")
+		var authErr *jira.AuthError
+		require.True(t, errors.As(err, &authErr))
+		assert.Equal(t, http.StatusForbidden, authErr.StatusCode)
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := jira.NewAuthenticator(jira.AuthConfig{Type: jira.AuthBasic})
+		assert.Error(t, err)
+
+		_, err = jira.NewAuthenticator(jira.AuthConfig{Type: "kerberos"})
+		assert.Error(t, err)
+
+		client := newAuthClient("http://127.0.0.1:0", jira.AuthConfig{Type: jira.AuthBearer})
+		_, err = client.GetProjects(context.Background(), 10, 1, "")
+		assert.ErrorContains(t, err, "invalid auth config")
+	})
+}
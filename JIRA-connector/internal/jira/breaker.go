@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"sync"
+	"time"
+)
+
+// ErrJiraUnavailable is returned without sending the request while the circuit is open
+var ErrJiraUnavailable = errors.New("jira is unavailable")
+
+type BreakerConfig struct {
+	// Window is the number of recent requests the error rate is counted over, 0 disables the breaker
+	Window int `yaml:"Window" env:"BREAKER_WINDOW" env-default:"20"`
+	// MinRequests is how many requests the window must hold before the circuit may open
+	MinRequests int `yaml:"MinRequests" env:"BREAKER_MIN_REQUESTS" env-default:"10"`
+	// FailureRate opens the circuit, e.g. 0.5 for half of the window failed
+	FailureRate float64 `yaml:"FailureRate" env:"BREAKER_FAILURE_RATE" env-default:"0.5"`
+	// OpenTimeout is how long the circuit stays open before a probe request is let through
+	OpenTimeout time.Duration `yaml:"OpenTimeout" env:"BREAKER_OPEN_TIMEOUT" env-default:"30s"`
+}
+
+// breaker is a circuit breaker over the outcomes of the recent requests.
+// Closed: requests are sent and counted. Open: requests fail with ErrJiraUnavailable.
+// Half-open: after OpenTimeout one probe is sent, it closes the circuit on success
+// and opens it again on failure.
+type breaker struct {
+	mu       sync.Mutex
+	config   BreakerConfig
+	now      func() time.Time
+	state    models.BreakerState
+	results  []bool
+	next     int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(config BreakerConfig) *breaker {
+	return &breaker{
+		config:  config,
+		now:     time.Now,
+		state:   models.BreakerClosed,
+		results: make([]bool, 0, max(config.Window, 0)),
+	}
+}
+
+func (b *breaker) enabled() bool {
+	return b.config.Window > 0
+}
+
+// allow reserves the request. Every allowed request must be followed by success, failure or release.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.enabled() {
+		return nil
+	}
+	switch b.state {
+	case models.BreakerOpen:
+		retryAt := b.openedAt.Add(b.config.OpenTimeout)
+		if b.now().Before(retryAt) {
+			return fmt.Errorf("%w: circuit is open until %s", ErrJiraUnavailable, retryAt.Format(time.RFC3339))
+		}
+		b.state = models.BreakerHalfOpen
+		b.probing = true
+		return nil
+	case models.BreakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w: waiting for the probe request", ErrJiraUnavailable)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.enabled() {
+		return
+	}
+	switch b.state {
+	case models.BreakerHalfOpen:
+		b.close()
+	case models.BreakerClosed:
+		b.record(false)
+	}
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.enabled() {
+		return
+	}
+	switch b.state {
+	case models.BreakerHalfOpen:
+		b.open()
+	case models.BreakerClosed:
+		b.record(true)
+		if len(b.results) >= b.config.MinRequests &&
+			float64(b.failures) >= b.config.FailureRate*float64(len(b.results)) {
+			b.open()
+		}
+	}
+}
+
+// release frees the probe of a request which ended without an answer from Jira, e.g. canceled
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) record(failed bool) {
+	if len(b.results) < b.config.Window {
+		b.results = append(b.results, failed)
+	} else {
+		if b.results[b.next] {
+			b.failures--
+		}
+		b.results[b.next] = failed
+		b.next = (b.next + 1) % b.config.Window
+	}
+	if failed {
+		b.failures++
+	}
+}
+
+func (b *breaker) open() {
+	b.state = models.BreakerOpen
+	b.openedAt = b.now()
+	b.probing = false
+}
+
+func (b *breaker) close() {
+	b.state = models.BreakerClosed
+	b.openedAt = time.Time{}
+	b.probing = false
+	b.results = b.results[:0]
+	b.next = 0
+	b.failures = 0
+}
+
+func (b *breaker) health() models.JiraHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	health := models.JiraHealth{
+		State:    b.state,
+		Requests: len(b.results),
+		Failures: b.failures,
+	}
+	if b.state != models.BreakerClosed {
+		health.OpenedAt = b.openedAt
+		health.RetryAt = b.openedAt.Add(b.config.OpenTimeout)
+	}
+	return health
+}
@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBreaker(now *time.Time) *breaker {
+	b := newBreaker(BreakerConfig{Window: 4, MinRequests: 4, FailureRate: 0.5, OpenTimeout: 30 * time.Second})
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestBreaker(t *testing.T) {
+	start := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("OpensOnFailureRate", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(&now)
+
+		// Недостаточно запросов в окне
+		for i := 0; i < 3; i++ {
+			require.NoError(t, b.allow())
+			b.failure()
+		}
+		assert.Equal(t, models.BreakerClosed, b.health().State)
+
+		require.NoError(t, b.allow())
+		b.failure()
+		assert.Equal(t, models.BreakerOpen, b.health().State)
+
+		err := b.allow()
+		assert.True(t, errors.Is(err, ErrJiraUnavailable))
+		assert.Equal(t, start.Add(30*time.Second), b.health().RetryAt)
+	})
+
+	t.Run("StaysClosedBelowFailureRate", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(&now)
+
+		// Окно сдвигается, старые ошибки забываются
+		for _, failed := range []bool{true, false, false, false, true, false, false, false} {
+			require.NoError(t, b.allow())
+			if failed {
+				b.failure()
+			} else {
+				b.success()
+			}
+		}
+		health := b.health()
+		assert.Equal(t, models.BreakerClosed, health.State)
+		assert.Equal(t, 4, health.Requests)
+		assert.Equal(t, 1, health.Failures)
+	})
+
+	t.Run("HalfOpenProbe", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(&now)
+		for i := 0; i < 4; i++ {
+			b.failure()
+		}
+
+		now = now.Add(30 * time.Second)
+		require.NoError(t, b.allow())
+		assert.Equal(t, models.BreakerHalfOpen, b.health().State)
+		// Пока идет пробный запрос, остальные отклоняются
+		assert.ErrorIs(t, b.allow(), ErrJiraUnavailable)
+
+		b.success()
+		health := b.health()
+		assert.Equal(t, models.BreakerClosed, health.State)
+		assert.Equal(t, 0, health.Requests)
+		assert.True(t, health.RetryAt.IsZero())
+		assert.NoError(t, b.allow())
+	})
+
+	t.Run("FailedProbeReopens", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(&now)
+		for i := 0; i < 4; i++ {
+			b.failure()
+		}
+
+		now = now.Add(time.Minute)
+		require.NoError(t, b.allow())
+		b.failure()
+
+		health := b.health()
+		assert.Equal(t, models.BreakerOpen, health.State)
+		assert.Equal(t, now, health.OpenedAt)
+		assert.ErrorIs(t, b.allow(), ErrJiraUnavailable)
+	})
+
+	t.Run("ReleasedProbe", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(&now)
+		for i := 0; i < 4; i++ {
+			b.failure()
+		}
+
+		now = now.Add(time.Minute)
+		require.NoError(t, b.allow())
+		b.release()
+		require.NoError(t, b.allow())
+		assert.Equal(t, models.BreakerHalfOpen, b.health().State)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		b := newBreaker(BreakerConfig{})
+		for i := 0; i < 100; i++ {
+			require.NoError(t, b.allow())
+			b.failure()
+		}
+		assert.Equal(t, models.BreakerClosed, b.health().State)
+	})
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// changelogPageSize is the largest page Jira returns from /issue/{key}/changelog
+const changelogPageSize = 100
+
+// completeChangelogs fetches the histories cut from the changelogs embedded in search results,
+// which hold at most 100 histories per issue
+func (c *Client) completeChangelogs(ctx context.Context, issues []models.JiraIssue) error {
+	for i := range issues {
+		changelog := &issues[i].Changelogs
+		if changelog.Total <= len(changelog.Histories) {
+			continue
+		}
+		c.logger.Debug("Fetching full changelog",
+			logger.Field{Key: "issue", Value: issues[i].Key},
+			logger.Field{Key: "embedded", Value: len(changelog.Histories)},
+			logger.Field{Key: "total", Value: changelog.Total})
+
+		histories, err := c.getChangelog(ctx, issues[i].Key)
+		if err != nil {
+			return fmt.Errorf("failed to get changelog of %s: %w", issues[i].Key, err)
+		}
+		total := changelog.Total
+		changelog.Histories = mergeHistories(changelog.Histories, histories)
+		if len(changelog.Histories) < total {
+			c.logger.Warn("Changelog is incomplete",
+				logger.Field{Key: "issue", Value: issues[i].Key},
+				logger.Field{Key: "fetched", Value: len(changelog.Histories)},
+				logger.Field{Key: "total", Value: total})
+		}
+		changelog.StartAt = 0
+		changelog.MaxResults = len(changelog.Histories)
+		changelog.Total = len(changelog.Histories)
+	}
+	return nil
+}
+
+// getChangelog returns the full changelog of the issue. Jira Cloud pages it through
+// /issue/{key}/changelog of both API versions. Jira Server answers that with 404 but returns
+// the whole changelog of a single issue expanded, and is not asked for the pages again.
+func (c *Client) getChangelog(ctx context.Context, issueKey string) ([]models.History, error) {
+	if !c.noChangelogPages.Load() {
+		histories, err := c.getChangelogPages(ctx, issueKey)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return histories, err
+		}
+		// an issue deleted in the meantime is missing from both endpoints
+		histories, err = c.getExpandedChangelog(ctx, issueKey)
+		if err == nil {
+			c.logger.Debug("Changelog pages are not supported, expanding issues",
+				logger.Field{Key: "issue", Value: issueKey})
+			c.noChangelogPages.Store(true)
+		}
+		return histories, err
+	}
+	return c.getExpandedChangelog(ctx, issueKey)
+}
+
+// getExpandedChangelog requests /issue/{key}?expand=changelog
+func (c *Client) getExpandedChangelog(ctx context.Context, issueKey string) ([]models.History, error) {
+	params := url.Values{
+		"expand": []string{"changelog"},
+		"fields": []string{"key"},
+	}
+	link := c.buildURL(fmt.Sprintf("/issue/%s", url.PathEscape(issueKey)), params)
+	var issue models.JiraIssue
+	err := c.withRetry(ctx, func() error {
+		return c.doRequest(ctx, link, &issue)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issue.Changelogs.Histories, nil
+}
+
+// getChangelogPages pages through /issue/{key}/changelog
+func (c *Client) getChangelogPages(ctx context.Context, issueKey string) ([]models.History, error) {
+	var histories []models.History
+	startAt := 0
+	for {
+		params := url.Values{
+			"startAt":    []string{strconv.Itoa(startAt)},
+			"maxResults": []string{strconv.Itoa(changelogPageSize)},
+		}
+		var page struct {
+			Total  int              `json:"total"`
+			IsLast bool             `json:"isLast"`
+			Values []models.History `json:"values"`
+		}
+		link := c.buildURL(fmt.Sprintf("/issue/%s/changelog", url.PathEscape(issueKey)), params)
+		err := c.withRetry(ctx, func() error {
+			return c.doRequest(ctx, link, &page)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		histories = append(histories, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || startAt >= page.Total {
+			return histories, nil
+		}
+	}
+}
+
+// mergeHistories joins the embedded and the paged histories without duplicates, ordered by time
+func mergeHistories(embedded, paged []models.History) []models.History {
+	seen := make(map[string]bool, len(embedded)+len(paged))
+	merged := make([]models.History, 0, len(embedded)+len(paged))
+	for _, h := range append(append([]models.History(nil), embedded...), paged...) {
+		key := historyKey(h)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, h)
+	}
+	sort.SliceStable(merged, func(a, b int) bool {
+		return merged[a].Created.Before(merged[b].Created.Time)
+	})
+	return merged
+}
+
+// historyKey identifies a history by its id, or by its content if Jira did not send the id
+func historyKey(h models.History) string {
+	if h.ID != "" {
+		return h.ID
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d|%s", h.Created.UnixNano(), h.Author.DisplayName)
+	for _, item := range h.Items {
+		fmt.Fprintf(&b, "|%s:%s>%s", item.Field, item.From+item.FromString, item.To+item.ToString)
+	}
+	return b.String()
+}
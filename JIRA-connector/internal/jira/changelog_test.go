@@ -0,0 +1,39 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeHistories(t *testing.T) {
+	at := func(hour int) models.JiraTime {
+		return models.JiraTime{Time: time.Date(2025, 3, 1, hour, 0, 0, 0, time.UTC)}
+	}
+	status := []models.Item{{Field: "status", FromString: "Open", ToString: "In Progress"}}
+
+	embedded := []models.History{
+		{ID: "3", Created: at(12)},
+		{ID: "4", Created: at(13)},
+	}
+	paged := []models.History{
+		{ID: "1", Created: at(10)},
+		{ID: "2", Created: at(11)},
+		{ID: "3", Created: at(12)},
+		// Без id записи сравниваются по содержимому
+		{Created: at(14), Author: models.JiraUser{DisplayName: "Alice"}, Items: status},
+		{Created: at(14), Author: models.JiraUser{DisplayName: "Alice"}, Items: status},
+		{Created: at(14), Author: models.JiraUser{DisplayName: "Bob"}, Items: status},
+	}
+
+	merged := mergeHistories(embedded, paged)
+
+	ids := make([]string, 0, len(merged))
+	for _, h := range merged {
+		ids = append(ids, h.ID+h.Author.DisplayName)
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4", "Alice", "Bob"}, ids)
+}
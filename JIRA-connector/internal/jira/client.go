@@ -1,19 +1,30 @@
 package jira
 
 import (
+	"fmt"
+	"github.com/sssidkn/jira-connector/internal/models"
 	"github.com/sssidkn/jira-connector/pkg/logger"
 	"github.com/sssidkn/jira-connector/pkg/ratelimiter"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 type Client struct {
 	httpClient *http.Client
 	config     Config
+	auth       Authenticator
 	logger     logger.Logger
 	rl         *ratelimiter.RateLimiter
+	buckets    *ratelimiter.Buckets
+	// bucket throttles every request before it is sent
+	bucket     *ratelimiter.TokenBucket
+	breaker    *breaker
 	maxDelay   time.Duration
 	startDelay time.Duration
+	// noChangelogPages is set once Jira answered /issue/{key}/changelog with 404
+	noChangelogPages atomic.Bool
 }
 
 func NewClient(options ...Option) *Client {
@@ -23,6 +34,25 @@ func NewClient(options ...Option) *Client {
 	for _, opt := range options {
 		opt(client)
 	}
+	if client.auth == nil {
+		auth, err := NewAuthenticator(client.config.Auth)
+		if err != nil {
+			auth = failedAuth{err: fmt.Errorf("invalid auth config: %w", err)}
+		}
+		client.auth = auth
+	}
 	client.rl = ratelimiter.NewRateLimiter(client.startDelay, client.maxDelay)
+	if client.buckets == nil {
+		client.buckets = ratelimiter.NewBuckets()
+	}
+	client.bucket = client.buckets.Get(strings.TrimRight(client.config.BaseURL, "/"), client.config.RateLimit)
+	client.breaker = newBreaker(client.config.Breaker)
 	return client
 }
+
+// Health returns the state of the circuit breaker around the Jira API
+func (c *Client) Health() models.JiraHealth {
+	health := c.breaker.health()
+	health.BaseURL = c.config.BaseURL
+	return health
+}
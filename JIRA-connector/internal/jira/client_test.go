@@ -3,14 +3,19 @@ package jira_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sssidkn/jira-connector/internal/jira"
 	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/jiratest"
 	"github.com/sssidkn/jira-connector/pkg/logger"
 )
 
@@ -203,3 +208,401 @@ func BenchmarkGetProjects(b *testing.B) {
 		}
 	}
 }
+
+func TestClient_JiraFixtures(t *testing.T) {
+	newClient := func(server *jiratest.Server, pagination jira.Pagination) *jira.Client {
+		return jira.NewClient(
+			jira.WithConfig(jira.Config{
+				BaseURL:      server.URL,
+				VersionAPI:   "/rest/api/2",
+				MaxResults:   2,
+				MaxProcesses: 3,
+				Pagination:   pagination,
+			}),
+			jira.WithLogger(&logger.TestLogger{}),
+			jira.WithMaxDelay(10),
+		)
+	}
+	stream := func(t *testing.T, client *jira.Client, jql string, from time.Time) []string {
+		pages := make(chan models.IssuePage, 10)
+		var err error
+		go func() {
+			err = client.StreamIssues(context.Background(), jql, from, pages)
+			close(pages)
+		}()
+		var keys []string
+		for page := range pages {
+			for _, issue := range page.Issues {
+				keys = append(keys, issue.Key)
+			}
+		}
+		if err != nil {
+			t.Fatalf("StreamIssues failed: %v", err)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	for _, pagination := range []jira.Pagination{jira.PaginationOffset, jira.PaginationToken} {
+		t.Run(string(pagination), func(t *testing.T) {
+			server := jiratest.Start(t, jiratest.Sample())
+			client := newClient(server, pagination)
+
+			project, err := client.GetProject(context.Background(), "TEST")
+			if err != nil {
+				t.Fatalf("GetProject failed: %v", err)
+			}
+			if project.Name != "Test Project" || project.TotalIssueCount != 5 {
+				t.Errorf("Expected Test Project with 5 issues, got %s with %d", project.Name, project.TotalIssueCount)
+			}
+			for _, issue := range project.Issues {
+				if issue.Key == "TEST-10" && len(issue.Changelogs.Histories) != 2 {
+					t.Errorf("Expected TEST-10 with 2 status changes, got %d", len(issue.Changelogs.Histories))
+				}
+			}
+
+			// Синхронизация с момента последнего обновления
+			jql := client.IssuesJQL("TEST", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+			keys := stream(t, client, jql, time.Date(2025, 3, 12, 9, 45, 0, 0, time.UTC))
+			if fmt.Sprint(keys) != "[TEST-10 TEST-3]" {
+				t.Errorf("Expected [TEST-10 TEST-3], got %v", keys)
+			}
+		})
+	}
+
+	t.Run("RetriesInjectedErrors", func(t *testing.T) {
+		server := jiratest.Start(t, jiratest.Sample())
+		server.FailNext(1, jiratest.Fault{Status: http.StatusTooManyRequests, Path: "/search"})
+		server.FailEvery(2, jiratest.Fault{Status: http.StatusBadGateway, Path: "/search"})
+		client := newClient(server, jira.PaginationOffset)
+
+		keys := stream(t, client, client.IssuesJQL("TEST", time.Time{}), time.Time{})
+		if len(keys) != 5 {
+			t.Errorf("Expected 5 issues, got %v", keys)
+		}
+		if requests := server.Requests("/search"); requests <= 4 {
+			t.Errorf("Expected failed requests to be repeated, got %d requests", requests)
+		}
+	})
+}
+
+func TestClient_FullChangelog(t *testing.T) {
+	// Jira Cloud отдает историю по страницам в обеих версиях API
+	for _, version := range []string{jira.VersionAPI2, jira.VersionAPI3} {
+		t.Run(version, func(t *testing.T) {
+			// Jira Cloud встраивает в результаты поиска только одну запись истории
+			server := jiratest.Start(t, jiratest.Sample(), jiratest.WithChangelogLimit(1))
+			client := jira.NewClient(
+				jira.WithConfig(jira.Config{
+					BaseURL:      server.URL,
+					VersionAPI:   version,
+					MaxResults:   2,
+					MaxProcesses: 2,
+				}),
+				jira.WithLogger(&logger.TestLogger{}),
+				jira.WithMaxDelay(10),
+			)
+			server.FailNext(1, jiratest.Fault{Status: http.StatusTooManyRequests, Path: "/changelog"})
+
+			project, err := client.GetProject(context.Background(), "TEST")
+			if err != nil {
+				t.Fatalf("GetProject failed: %v", err)
+			}
+			for _, issue := range project.Issues {
+				histories := issue.Changelogs.Histories
+				if issue.Changelogs.Total != len(histories) {
+					t.Errorf("Expected %d histories of %s, got %d", issue.Changelogs.Total, issue.Key, len(histories))
+				}
+				if issue.Key == "TEST-1" && (len(histories) != 2 || histories[0].ID != "101010" || histories[1].ID != "101011") {
+					t.Errorf("Expected ordered histories 101010, 101011 of TEST-1, got %+v", histories)
+				}
+			}
+			// TEST-1, TEST-3 и TEST-10 с повтором после 429
+			if requests := server.Requests("/changelog"); requests != 4 {
+				t.Errorf("Expected 4 changelog requests, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestClient_FullChangelogServer(t *testing.T) {
+	// В Jira Server нет /issue/{key}/changelog, вся история отдается в самой задаче
+	server := jiratest.Start(t, jiratest.Sample(), jiratest.WithChangelogLimit(1))
+	server.FailEvery(1, jiratest.Fault{Status: http.StatusNotFound, Path: "/changelog"})
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   jira.VersionAPI2,
+			MaxResults:   2,
+			MaxProcesses: 2,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+
+	project, err := client.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	for _, issue := range project.Issues {
+		histories := issue.Changelogs.Histories
+		if issue.Changelogs.Total != len(histories) {
+			t.Errorf("Expected %d histories of %s, got %d", issue.Changelogs.Total, issue.Key, len(histories))
+		}
+		if issue.Key == "TEST-1" && (len(histories) != 2 || histories[0].ID != "101010" || histories[1].ID != "101011") {
+			t.Errorf("Expected ordered histories 101010, 101011 of TEST-1, got %+v", histories)
+		}
+	}
+	// после 404 страницы истории больше не запрашиваются, кроме уже начатых параллельно запросов
+	changelogRequests := server.Requests("/changelog")
+	if changelogRequests < 1 || changelogRequests > 2 {
+		t.Errorf("Expected 1 or 2 changelog requests, got %d", changelogRequests)
+	}
+	// TEST-1, TEST-3 и TEST-10
+	if requests := server.Requests("/issue/") - changelogRequests; requests != 3 {
+		t.Errorf("Expected 3 issue requests, got %d", requests)
+	}
+}
+
+func TestClient_Worklogs(t *testing.T) {
+	// Jira встраивает в результаты поиска только одну запись о работе
+	server := jiratest.Start(t, jiratest.Sample(), jiratest.WithWorklogLimit(1))
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   "/rest/api/2",
+			MaxResults:   2,
+			MaxProcesses: 2,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+
+	project, err := client.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	for _, issue := range project.Issues {
+		worklog := issue.Fields.Worklog
+		if worklog == nil || !worklog.Complete() {
+			t.Fatalf("Expected complete worklogs of %s, got %+v", issue.Key, worklog)
+		}
+		if issue.Key != "TEST-1" {
+			continue
+		}
+		if len(worklog.Worklogs) != 3 {
+			t.Fatalf("Expected 3 worklogs of TEST-1, got %d", len(worklog.Worklogs))
+		}
+		w := worklog.Worklogs[1]
+		if w.ID != "10011" || w.Author.DisplayName != "Bob Jones" || w.TimeSpentSeconds != 7200 ||
+			w.Comment.Text != "Fixed the login form" || !w.Started.Equal(time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected worklog %+v", w)
+		}
+	}
+	if requests := server.Requests("/worklog"); requests != 1 {
+		t.Errorf("Expected 1 worklog request, got %d", requests)
+	}
+}
+
+func TestClient_Comments(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample(), jiratest.WithCommentLimit(1))
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   "/rest/api/2",
+			MaxResults:   2,
+			MaxProcesses: 2,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+
+	project, err := client.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	for _, issue := range project.Issues {
+		comment := issue.Fields.Comment
+		if comment == nil || !comment.Complete() {
+			t.Fatalf("Expected complete comments of %s, got %+v", issue.Key, comment)
+		}
+		if issue.Key != "TEST-1" {
+			continue
+		}
+		if len(comment.Comments) != 3 {
+			t.Fatalf("Expected 3 comments of TEST-1, got %d", len(comment.Comments))
+		}
+		c := comment.Comments[2]
+		if c.ID != "10022" || c.Author.DisplayName != "Bob Jones" || c.Body.Text != "Fixed, please verify" ||
+			!c.Created.Equal(time.Date(2025, 3, 5, 16, 0, 0, 0, time.UTC)) ||
+			!c.Updated.Equal(time.Date(2025, 3, 5, 16, 20, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected comment %+v", c)
+		}
+	}
+	if requests := server.Requests("/comment"); requests != 1 {
+		t.Errorf("Expected 1 comment request, got %d", requests)
+	}
+}
+
+func TestClient_IssueLinks(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:       server.URL,
+			VersionAPI:    "/rest/api/2",
+			MaxResults:    50,
+			MaxProcesses:  1,
+			EpicLinkField: "customfield_10008",
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+
+	project, err := client.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	issues := make(map[string]models.JiraIssue)
+	for _, issue := range project.Issues {
+		if issue.Fields.IssueLinks == nil || issue.Fields.Subtasks == nil {
+			t.Errorf("Expected links and subtasks of %s to be requested", issue.Key)
+		}
+		issues[issue.Key] = issue
+	}
+
+	links := issues["TEST-3"].Fields.IssueLinks
+	if len(links) != 2 {
+		t.Fatalf("Expected 2 links of TEST-3, got %d", len(links))
+	}
+	if source, target := links[0].Ends("TEST-3"); source != "TEST-1" || target != "TEST-3" ||
+		links[0].Type.Name != "Blocks" {
+		t.Errorf("Expected TEST-1 to block TEST-3, got %s -> %s (%+v)", source, target, links[0].Type)
+	}
+	if source, target := links[1].Ends("TEST-3"); source != "TEST-3" || target != "TEST-10" {
+		t.Errorf("Expected TEST-3 to relate to TEST-10, got %s -> %s", source, target)
+	}
+	if parent := issues["TEST-2"].Fields.Parent; parent == nil || parent.Key != "TEST-4" {
+		t.Errorf("Expected TEST-4 to be the parent of TEST-2, got %+v", parent)
+	}
+	if subtasks := issues["TEST-4"].Fields.Subtasks; len(subtasks) != 1 || subtasks[0].Key != "TEST-2" {
+		t.Errorf("Expected TEST-2 to be the subtask of TEST-4, got %+v", subtasks)
+	}
+	if epic := issues["TEST-3"].Fields.EpicLink; epic != "TEST-4" {
+		t.Errorf("Expected TEST-4 to be the epic of TEST-3, got %q", epic)
+	}
+	if epic := issues["TEST-2"].Fields.EpicLink; epic != "" {
+		t.Errorf("Expected no epic of TEST-2, got %q", epic)
+	}
+}
+
+func TestClient_VersionsAndComponents(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   "/rest/api/2",
+			MaxResults:   50,
+			MaxProcesses: 1,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+
+	versions, err := client.GetProjectVersions(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProjectVersions failed: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 versions, got %d", len(versions))
+	}
+	if v := versions[0]; v.ID != "10100" || v.Name != "1.0" || !v.Released ||
+		!v.StartDate.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) ||
+		!v.ReleaseDate.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected version %+v", v)
+	}
+	if v := versions[1]; v.Released || !v.StartDate.IsZero() {
+		t.Errorf("Expected unreleased version without start date, got %+v", v)
+	}
+	if _, err = client.GetProjectVersions(context.Background(), "NONE"); err == nil {
+		t.Error("Expected error for unknown project")
+	}
+
+	project, err := client.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	for _, issue := range project.Issues {
+		if issue.Key != "TEST-3" {
+			continue
+		}
+		fields := issue.Fields
+		if len(fields.Components) != 1 || fields.Components[0].Name != "Build" {
+			t.Errorf("Unexpected components %+v", fields.Components)
+		}
+		if len(fields.Labels) != 1 || fields.Labels[0] != "deps" {
+			t.Errorf("Unexpected labels %v", fields.Labels)
+		}
+		if len(fields.FixVersions) != 1 || fields.FixVersions[0].ID != "10101" {
+			t.Errorf("Unexpected fix versions %+v", fields.FixVersions)
+		}
+		if len(fields.Versions) != 1 || fields.Versions[0].Name != "1.0" {
+			t.Errorf("Unexpected affected versions %+v", fields.Versions)
+		}
+	}
+}
+
+func TestClient_CustomFields(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+	newClient := func(fields map[string]string) *jira.Client {
+		return jira.NewClient(
+			jira.WithConfig(jira.Config{
+				BaseURL:       server.URL,
+				VersionAPI:    "/rest/api/2",
+				MaxResults:    50,
+				MaxProcesses:  1,
+				EpicLinkField: "customfield_10008",
+				CustomFields:  fields,
+			}),
+			jira.WithLogger(&logger.TestLogger{}),
+			jira.WithMaxDelay(10),
+		)
+	}
+
+	client := newClient(map[string]string{
+		"storyPoints": "customfield_10016",
+		"severity":    "customfield_10030",
+		"team":        "customfield_10001",
+	})
+	if err := client.ValidateFields(context.Background()); err != nil {
+		t.Fatalf("ValidateFields failed: %v", err)
+	}
+	project, err := client.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	issues := make(map[string]models.JiraIssue)
+	for _, issue := range project.Issues {
+		issues[issue.Key] = issue
+	}
+
+	expected := map[string]any{"storyPoints": 3.0, "severity": "Critical", "team": "Web"}
+	if got := issues["TEST-1"].Fields.CustomFields; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected custom fields %v of TEST-1, got %v", expected, got)
+	}
+	// поля без значения не попадают в map
+	expected = map[string]any{"storyPoints": 5.5}
+	if got := issues["TEST-3"].Fields.CustomFields; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected custom fields %v of TEST-3, got %v", expected, got)
+	}
+	if epic := issues["TEST-3"].Fields.EpicLink; epic != "TEST-4" {
+		t.Errorf("Expected TEST-4 to be the epic of TEST-3, got %q", epic)
+	}
+
+	err = newClient(map[string]string{"storyPoints": "customfield_99999"}).ValidateFields(context.Background())
+	if !errors.Is(err, jira.ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "customfield_99999 (storyPoints)") {
+		t.Errorf("Expected the unknown field in the error, got %v", err)
+	}
+}
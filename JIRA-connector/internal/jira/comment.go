@@ -0,0 +1,68 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// commentPageSize is the page size of /issue/{key}/comment
+const commentPageSize = 1000
+
+// completeComments fetches the comments cut from search results
+func (c *Client) completeComments(ctx context.Context, issues []models.JiraIssue) error {
+	for i := range issues {
+		comment := issues[i].Fields.Comment
+		if comment == nil || comment.Complete() {
+			continue
+		}
+		c.logger.Debug("Fetching all comments",
+			logger.Field{Key: "issue", Value: issues[i].Key},
+			logger.Field{Key: "embedded", Value: len(comment.Comments)},
+			logger.Field{Key: "total", Value: comment.Total})
+
+		comments, err := c.getComments(ctx, issues[i].Key)
+		if err != nil {
+			return fmt.Errorf("failed to get comments of %s: %w", issues[i].Key, err)
+		}
+		for j := range comments {
+			comments[j].Body.Render(c.config.DescriptionFormat)
+		}
+		comment.Comments = comments
+		comment.StartAt = 0
+		comment.MaxResults = len(comments)
+		comment.Total = len(comments)
+	}
+	return nil
+}
+
+// getComments pages through /issue/{key}/comment
+func (c *Client) getComments(ctx context.Context, issueKey string) ([]models.Comment, error) {
+	var comments []models.Comment
+	startAt := 0
+	for {
+		params := url.Values{
+			"startAt":    []string{strconv.Itoa(startAt)},
+			"maxResults": []string{strconv.Itoa(commentPageSize)},
+			"orderBy":    []string{"created"},
+		}
+		var page models.Comments
+		link := c.buildURL(fmt.Sprintf("/issue/%s/comment", url.PathEscape(issueKey)), params)
+		err := c.withRetry(ctx, func() error {
+			return c.doRequest(ctx, link, &page)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page.Comments...)
+		startAt += len(page.Comments)
+		if len(page.Comments) == 0 || startAt >= page.Total {
+			return comments, nil
+		}
+	}
+}
@@ -1,11 +1,19 @@
 package jira
 
 import (
+	"github.com/sssidkn/jira-connector/pkg/adf"
 	"github.com/sssidkn/jira-connector/pkg/logger"
+	"github.com/sssidkn/jira-connector/pkg/ratelimiter"
 	"net/http"
 	"time"
 )
 
+const (
+	VersionAPI2 = "/rest/api/2"
+	// VersionAPI3 is Jira Cloud REST v3, which returns rich text fields as ADF documents
+	VersionAPI3 = "/rest/api/3"
+)
+
 type Config struct {
 	BaseURL        string `yaml:"BaseURL" env:"BASE_URL"`
 	VersionAPI     string `yaml:"VersionAPI" env:"VERSION_API"`
@@ -14,6 +22,21 @@ type Config struct {
 	MaxDelay       int    `yaml:"MaxDelay" env:"MAX_DELAY"`
 	StartDelay     int    `yaml:"StartDelay" env:"START_DELAY"`
 	MaxResults     int    `yaml:"MaxResults" env:"MAX_RESULTS"`
+	// Pagination selects how search results are paged: "offset" (default) or "token" for /search/jql
+	Pagination Pagination `yaml:"Pagination" env:"PAGINATION"`
+	// DescriptionFormat is used to render ADF descriptions: "text" (default) or "markdown"
+	DescriptionFormat adf.Format `yaml:"DescriptionFormat" env:"DESCRIPTION_FORMAT"`
+	// EpicLinkField is the id of the Epic Link custom field of Jira Server, e.g. customfield_10008.
+	// Jira Cloud reports epics as parent.
+	EpicLinkField string `yaml:"EpicLinkField" env:"EPIC_LINK_FIELD"`
+	// CustomFields maps the names custom fields are stored under to their ids in the instance,
+	// e.g. storyPoints: customfield_10016. The env form is storyPoints:customfield_10016,team:customfield_10001.
+	CustomFields map[string]string `yaml:"CustomFields" env:"CUSTOM_FIELDS" env-separator:","`
+	Auth         AuthConfig        `yaml:"Auth"`
+	// RateLimit is the request budget shared by all clients of BaseURL
+	RateLimit ratelimiter.BucketConfig `yaml:"RateLimit"`
+	// Breaker makes requests fail fast while Jira is down
+	Breaker BreakerConfig `yaml:"Breaker"`
 }
 
 type Option func(*Client)
@@ -38,10 +61,31 @@ func WithLogger(log logger.Logger) func(*Client) {
 	}
 }
 
+// WithVersionAPI selects the REST API version, e.g. VersionAPI3 for Jira Cloud
+func WithVersionAPI(version string) func(*Client) {
+	return func(c *Client) {
+		c.config.VersionAPI = version
+	}
+}
+
+// WithAuthenticator overrides the authenticator built from Config.Auth
+func WithAuthenticator(auth Authenticator) func(*Client) {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
 func (c *Client) GetBaseURL() string {
 	return c.config.BaseURL
 }
 
+// WithBuckets shares the request budget with other clients of the same Jira instance
+func WithBuckets(buckets *ratelimiter.Buckets) func(*Client) {
+	return func(c *Client) {
+		c.buckets = buckets
+	}
+}
+
 func WithStartDelay(delay int) func(client *Client) {
 	return func(c *Client) {
 		c.startDelay = time.Duration(delay) * time.Second
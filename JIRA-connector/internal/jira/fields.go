@@ -0,0 +1,136 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// ErrUnknownField is returned by ValidateFields if a configured field does not exist in the instance
+var ErrUnknownField = errors.New("unknown jira field")
+
+// ValidateFields checks the epic link field and the mapped custom fields against /field
+func (c *Client) ValidateFields(ctx context.Context) error {
+	configured := make(map[string]string)
+	if c.config.EpicLinkField != "" {
+		configured[c.config.EpicLinkField] = "EpicLinkField"
+	}
+	for name, id := range c.config.CustomFields {
+		configured[id] = name
+	}
+	if len(configured) == 0 {
+		return nil
+	}
+
+	var fields []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	endpoint := c.buildURL("/field", nil)
+	err := c.withRetry(ctx, func() error {
+		return c.doRequest(ctx, endpoint, &fields)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get fields: %w", err)
+	}
+
+	known := make(map[string]string, len(fields))
+	for _, f := range fields {
+		known[f.ID] = f.Name
+	}
+	var unknown []string
+	for id, name := range configured {
+		jiraName, ok := known[id]
+		if !ok {
+			unknown = append(unknown, fmt.Sprintf("%s (%s)", id, name))
+			continue
+		}
+		c.logger.Debug("Field mapped", logger.Field{Key: "name", Value: name},
+			logger.Field{Key: "field", Value: id}, logger.Field{Key: "jira_name", Value: jiraName})
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", ErrUnknownField, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// customFieldIDs returns the ids of the mapped custom fields in a stable order
+func (c *Client) customFieldIDs() []string {
+	ids := make([]string, 0, len(c.config.CustomFields))
+	for _, id := range c.config.CustomFields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// readCustomFields sets EpicLink and CustomFields of the issues from the raw fields of the search response
+func (c *Client) readCustomFields(body json.RawMessage, issues []models.JiraIssue) error {
+	var result struct {
+		Issues []struct {
+			Fields map[string]json.RawMessage `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	for i := range result.Issues {
+		fields := result.Issues[i].Fields
+		if c.config.EpicLinkField != "" {
+			var epic *string
+			if err := json.Unmarshal(fields[c.config.EpicLinkField], &epic); err == nil && epic != nil {
+				issues[i].Fields.EpicLink = *epic
+			}
+		}
+		if len(c.config.CustomFields) == 0 {
+			continue
+		}
+		values := make(map[string]any, len(c.config.CustomFields))
+		for name, id := range c.config.CustomFields {
+			raw, ok := fields[id]
+			if !ok {
+				continue
+			}
+			var value any
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("failed to unmarshal field %s: %w", id, err)
+			}
+			if value = customFieldValue(value); value != nil {
+				values[name] = value
+			}
+		}
+		issues[i].Fields.CustomFields = values
+	}
+	return nil
+}
+
+// customFieldValue reduces select options, users and other objects to their display value,
+// numbers and strings are kept as they are
+func customFieldValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range []string{"value", "displayName", "name", "key"} {
+			if s, ok := v[key]; ok {
+				return s
+			}
+		}
+		return v
+	case []any:
+		values := make([]any, 0, len(v))
+		for _, item := range v {
+			if item = customFieldValue(item); item != nil {
+				values = append(values, item)
+			}
+		}
+		return values
+	default:
+		return v
+	}
+}
@@ -5,30 +5,146 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// maxErrorBody limits how much of an error response is kept in APIError.Message
+const maxErrorBody = 1 << 10
+
 type APIError struct {
 	StatusCode int
 	Message    string
+	// RetryAfter is the pause requested in the Retry-After header, 0 if it was not sent
+	RetryAfter time.Duration
+	// RateLimitRemaining is the X-RateLimit-Remaining header, -1 if it was not sent
+	RateLimitRemaining int
+	// RateLimitReset is the X-RateLimit-Reset header, zero if it was not sent
+	RateLimitReset time.Time
 }
 
 func (e *APIError) Error() string {
 	return fmt.Sprintf("Jira API error: %d - %s", e.StatusCode, e.Message)
 }
+
+// Delay returns how long Jira asked to wait before the next request, 0 if it did not say
+func (e *APIError) Delay(now time.Time) time.Duration {
+	if e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	if e.RateLimitRemaining == 0 && e.RateLimitReset.After(now) {
+		return e.RateLimitReset.Sub(now)
+	}
+	return 0
+}
+
+func newAPIError(resp *http.Response, now time.Time) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return &APIError{
+		StatusCode:         resp.StatusCode,
+		Message:            strings.TrimSpace(string(body)),
+		RetryAfter:         parseRetryAfter(resp.Header.Get("Retry-After"), now),
+		RateLimitRemaining: parseRemaining(resp.Header.Get("X-RateLimit-Remaining")),
+		RateLimitReset:     parseReset(resp.Header.Get("X-RateLimit-Reset")),
+	}
+}
+
+// parseRetryAfter accepts both forms of Retry-After: delay in seconds and HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+func parseRemaining(value string) int {
+	remaining, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+	return remaining
+}
+
+// parseReset accepts the ISO 8601 timestamp sent by Jira Cloud and Unix seconds
+func parseReset(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0)
+	}
+	return time.Time{}
+}
 func (c *Client) buildURL(endpoint string, params url.Values) string {
+	if len(params) == 0 {
+		return fmt.Sprintf("%s%s%s", c.config.BaseURL, c.config.VersionAPI, endpoint)
+	}
 	return fmt.Sprintf("%s%s%s?%s", c.config.BaseURL, c.config.VersionAPI, endpoint, params.Encode())
 }
 
 func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
-	resp, err := c.httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// an open circuit fails fast, before the authenticator may request a token from Jira
+	if err = c.breaker.allow(); err != nil {
+		return err
+	}
+	if err = c.auth.Authenticate(req); err != nil {
+		c.breaker.release()
+		return fmt.Errorf("failed to authenticate request: %w", err)
+	}
+	if err = c.bucket.Wait(ctx); err != nil {
+		c.breaker.release()
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			c.breaker.release()
+		} else {
+			c.breaker.failure()
+		}
 		return fmt.Errorf("failed to make API request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 500 {
+		c.breaker.failure()
+	} else {
+		c.breaker.success()
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.bucket.Throttled()
+	} else if resp.StatusCode < 400 {
+		c.bucket.Succeeded()
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		body, _ := io.ReadAll(resp.Body)
+		return &AuthError{StatusCode: resp.StatusCode, Message: string(body)}
+	}
+
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return newAPIError(resp, time.Now())
 	}
 
 	data, err := io.ReadAll(resp.Body)
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -281,3 +283,329 @@ func TestRateLimiterIntegration(t *testing.T) {
 		assert.Equal(t, time.Duration(0), duration)
 	})
 }
+
+func TestClient_APIError(t *testing.T) {
+	reset := time.Date(2025, 5, 1, 12, 0, 30, 0, time.UTC)
+	tests := []struct {
+		name              string
+		headers           map[string]string
+		expectedRetry     time.Duration
+		expectedRemaining int
+		expectedReset     time.Time
+	}{
+		{
+			name:              "RetryAfterSeconds",
+			headers:           map[string]string{"Retry-After": "7"},
+			expectedRetry:     7 * time.Second,
+			expectedRemaining: -1,
+		},
+		{
+			name: "RateLimitHeaders",
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "2025-05-01T12:00:30Z",
+			},
+			expectedRemaining: 0,
+			expectedReset:     reset,
+		},
+		{
+			name:              "ResetInUnixSeconds",
+			headers:           map[string]string{"X-RateLimit-Reset": "1746100830"},
+			expectedRemaining: -1,
+			expectedReset:     reset,
+		},
+		{
+			name:              "WithoutHeaders",
+			expectedRemaining: -1,
+		},
+		{
+			name:              "InvalidHeaders",
+			headers:           map[string]string{"Retry-After": "soon", "X-RateLimit-Remaining": "many"},
+			expectedRemaining: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"errorMessages":["Rate limit exceeded"]}`))
+			}))
+			defer server.Close()
+
+			client := jira.NewClient(
+				jira.WithConfig(jira.Config{BaseURL: server.URL, VersionAPI: "/rest/api/2"}),
+				jira.WithLogger(&logger.TestLogger{}),
+			)
+
+			_, err := client.GetProjectInfo(context.Background(), "TEST")
+
+			var apiErr *jira.APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+			assert.Equal(t, `{"errorMessages":["Rate limit exceeded"]}`, apiErr.Message)
+			assert.Equal(t, tt.expectedRetry, apiErr.RetryAfter)
+			assert.Equal(t, tt.expectedRemaining, apiErr.RateLimitRemaining)
+			assert.True(t, tt.expectedReset.Equal(apiErr.RateLimitReset))
+		})
+	}
+
+	t.Run("RetryAfterDate", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := jira.NewClient(
+			jira.WithConfig(jira.Config{BaseURL: server.URL, VersionAPI: "/rest/api/2"}),
+			jira.WithLogger(&logger.TestLogger{}),
+		)
+
+		_, err := client.GetProjectInfo(context.Background(), "TEST")
+
+		var apiErr *jira.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.InDelta(t, time.Minute.Seconds(), apiErr.RetryAfter.Seconds(), 2)
+	})
+}
+
+func TestAPIError_Delay(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		err      jira.APIError
+		expected time.Duration
+	}{
+		{
+			name:     "RetryAfter",
+			err:      jira.APIError{RetryAfter: 5 * time.Second, RateLimitRemaining: 0, RateLimitReset: now.Add(time.Minute)},
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "UntilReset",
+			err:      jira.APIError{RateLimitRemaining: 0, RateLimitReset: now.Add(30 * time.Second)},
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "RequestsRemaining",
+			err:      jira.APIError{RateLimitRemaining: 10, RateLimitReset: now.Add(30 * time.Second)},
+			expected: 0,
+		},
+		{
+			name:     "ResetPassed",
+			err:      jira.APIError{RateLimitRemaining: 0, RateLimitReset: now.Add(-time.Second)},
+			expected: 0,
+		},
+		{
+			name:     "Unknown",
+			err:      jira.APIError{RateLimitRemaining: -1},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.Delay(now))
+		})
+	}
+}
+
+func TestClient_HonoursRetryAfter(t *testing.T) {
+	// rateLimitedSearch отвечает 429 на первый запрос каждого вида с заданными заголовками
+	rateLimitedSearch := func(headers func() map[string]string) *httptest.Server {
+		limited := make(map[string]bool)
+		var mu sync.Mutex
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			kind := "page"
+			if r.URL.Query().Get("maxResults") == "0" {
+				kind = "count"
+			}
+			mu.Lock()
+			first := !limited[kind]
+			limited[kind] = true
+			mu.Unlock()
+			if first {
+				for key, value := range headers() {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issues": []models.JiraIssue{{ID: "1", Key: "TEST-1"}, {ID: "2", Key: "TEST-2"}},
+				"total":  2,
+			})
+		}))
+	}
+
+	tests := []struct {
+		name    string
+		headers func() map[string]string
+	}{
+		{
+			name:    "RetryAfter",
+			headers: func() map[string]string { return map[string]string{"Retry-After": "1"} },
+		},
+		{
+			name: "RateLimitReset",
+			headers: func() map[string]string {
+				return map[string]string{
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     time.Now().Add(time.Second).UTC().Format(time.RFC3339Nano),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := rateLimitedSearch(tt.headers)
+			defer server.Close()
+
+			// Экспоненциальная задержка в 30 секунд не уложилась бы в таймаут теста
+			client := jira.NewClient(
+				jira.WithConfig(jira.Config{BaseURL: server.URL, VersionAPI: "/rest/api/2", MaxResults: 2, MaxProcesses: 1}),
+				jira.WithLogger(&logger.TestLogger{}),
+				jira.WithStartDelay(30),
+				jira.WithMaxDelay(60),
+			)
+
+			var pauses []time.Duration
+			var mu sync.Mutex
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			ctx = models.WithSyncTrace(ctx, &models.SyncTrace{Paused: func(retryAfter time.Duration) {
+				mu.Lock()
+				pauses = append(pauses, retryAfter)
+				mu.Unlock()
+			}})
+
+			pages := make(chan models.IssuePage, 1)
+			start := time.Now()
+			err := client.StreamIssues(ctx, "project=TEST", time.Time{}, pages)
+
+			require.NoError(t, err)
+			assert.Len(t, (<-pages).Issues, 2)
+			assert.Less(t, time.Since(start), 5*time.Second)
+			require.Len(t, pauses, 2)
+			for _, pause := range pauses {
+				assert.LessOrEqual(t, pause, time.Second)
+				assert.Greater(t, pause, 500*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestClient_SharedBucket(t *testing.T) {
+	mock := MockServer()
+	defer mock.Close()
+
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first := false
+		if strings.HasSuffix(r.URL.Path, "/search") {
+			mu.Lock()
+			requests++
+			first = requests == 1
+			mu.Unlock()
+		}
+		if first {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		mock.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	cfg := jira.Config{
+		BaseURL:      server.URL,
+		VersionAPI:   "/rest/api/2",
+		MaxResults:   50,
+		MaxProcesses: 3,
+		MaxDelay:     10,
+		RateLimit:    ratelimiter.BucketConfig{Rate: 100, Burst: 10, RecoverAfter: 1000},
+	}
+	buckets := ratelimiter.NewBuckets()
+	newClient := func() *jira.Client {
+		return jira.NewClient(
+			jira.WithConfig(cfg),
+			jira.WithLogger(&logger.TestLogger{}),
+			jira.WithMaxDelay(cfg.MaxDelay),
+			jira.WithBuckets(buckets),
+		)
+	}
+	first, second := newClient(), newClient()
+
+	// 429 у одного клиента замедляет всех клиентов того же Jira
+	_, err := first.GetProject(context.Background(), "TEST")
+	require.NoError(t, err)
+	bucket := buckets.Get(server.URL, cfg.RateLimit)
+	assert.Equal(t, 50.0, bucket.Rate())
+
+	_, err = second.GetProject(context.Background(), "TEST")
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, bucket.Rate())
+
+	other := buckets.Get("https://other.jira.com", cfg.RateLimit)
+	assert.Equal(t, 100.0, other.Rate())
+}
+
+// countingAuth считает запросы, для которых получались учетные данные
+type countingAuth struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (a *countingAuth) Authenticate(*http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls++
+	return nil
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	auth := &countingAuth{}
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:    server.URL,
+			VersionAPI: "/rest/api/2",
+			Breaker: jira.BreakerConfig{
+				Window:      4,
+				MinRequests: 4,
+				FailureRate: 0.5,
+				OpenTimeout: time.Hour,
+			},
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithAuthenticator(auth),
+	)
+	assert.Equal(t, models.BreakerClosed, client.Health().State)
+
+	// Jira недоступна: запросы отправляются, пока не откроется цепь
+	for i := 0; i < 4; i++ {
+		_, err := client.GetProjectInfo(context.Background(), "TEST")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, jira.ErrJiraUnavailable))
+	}
+
+	_, err := client.GetProjectInfo(context.Background(), "TEST")
+	assert.ErrorIs(t, err, jira.ErrJiraUnavailable)
+	// При открытой цепи токен у Jira не запрашивается
+	assert.Equal(t, 4, auth.calls)
+
+	health := client.Health()
+	assert.Equal(t, server.URL, health.BaseURL)
+	assert.Equal(t, models.BreakerOpen, health.State)
+	assert.Equal(t, 4, health.Failures)
+	assert.Equal(t, health.OpenedAt.Add(time.Hour), health.RetryAt)
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/sssidkn/jira-connector/internal/models"
@@ -13,33 +14,79 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-func (c *Client) getTotalIssuesCount(ctx context.Context, projectKey string) (int, error) {
-	jql := fmt.Sprintf("project=%s", projectKey)
-	c.logger.Info("starting getting total issues count")
-	params := url.Values{
-		"jql":        []string{jql},
-		"startAt":    []string{"0"},
-		"maxResults": []string{"0"},
+const issueFields = `summary,description,issuetype,priority,
+			status,creator,assignee,created,updated,resolutiondate,worklog,timetracking,comment,
+			issuelinks,parent,subtasks,components,labels,fixVersions,versions`
+
+func projectJQL(projectKey string) string {
+	return fmt.Sprintf("project=%s", projectKey)
+}
+
+func updatedAfterJQL(projectKey string, lastUpdate time.Time) string {
+	return fmt.Sprintf("project=%s AND updated > \"%s\"", projectKey,
+		lastUpdate.UTC().Format("2006/01/02"))
+}
+
+// syncJQL orders jql by updated so that a sync interrupted on some page can continue
+// from the latest updated timestamp it committed. Non-zero from skips the issues updated before it.
+// JQL dates are read in the timezone of the Jira user, so from must be a wall clock in that timezone.
+func syncJQL(jql string, from time.Time) string {
+	if !from.IsZero() {
+		jql = fmt.Sprintf("(%s) AND updated >= \"%s\"", jql, from.Format("2006/01/02 15:04"))
 	}
+	return jql + " ORDER BY updated ASC, key ASC"
+}
 
-	var result struct {
-		Total int `json:"total"`
+func (c *Client) searchParams(jql string) url.Values {
+	return url.Values{
+		"jql":        []string{jql},
+		"maxResults": []string{fmt.Sprintf("%d", c.config.MaxResults)},
+		"expand":     []string{"changelog"},
+		"fields":     []string{c.issueFields()},
 	}
+}
 
-	endpoint := c.buildURL("/search", params)
-	if err := c.doRequest(ctx, endpoint, &result); err != nil {
-		return 0, fmt.Errorf("failed to get total issues count: %w", err)
+// issueFields adds the epic link and the mapped custom fields of the instance to the requested fields
+func (c *Client) issueFields() string {
+	fields := issueFields
+	if c.config.EpicLinkField != "" {
+		fields += "," + c.config.EpicLinkField
 	}
-	c.logger.Info(fmt.Sprintf("finished getting total issues count. Count: %d", result.Total))
-	return result.Total, nil
+	for _, id := range c.customFieldIDs() {
+		fields += "," + id
+	}
+	return fields
 }
 
-func (c *Client) getIssuesCountAfter(ctx context.Context, projectKey string, lastUpdate time.Time) (int, error) {
-	jql := fmt.Sprintf("project=%s AND updated > \"%s\"", projectKey,
-		lastUpdate.UTC().Format("2006/01/02"))
+// searchIssues fetches all issues matching jql into memory
+func (c *Client) searchIssues(ctx context.Context, jql string) (*[]models.JiraIssue, error) {
+	return c.collectIssues(ctx, func(ctx context.Context, out chan<- models.IssuePage) error {
+		return c.streamSearch(ctx, jql, out)
+	})
+}
+
+// streamSearch sends pages of issues matching jql to out with the pagination strategy
+// configured for the instance. out is not closed.
+func (c *Client) streamSearch(ctx context.Context, jql string, out chan<- models.IssuePage) error {
+	if c.config.Pagination == PaginationToken {
+		return c.getIssuesByToken(ctx, c.searchParams(jql), out)
+	}
 
+	total, err := c.getIssuesCount(ctx, jql)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		return nil
+	}
+	return c.getIssuesBy(ctx, total, c.searchParams(jql), out)
+}
+
+func (c *Client) getIssuesCount(ctx context.Context, jql string) (int, error) {
+	c.logger.Info("starting getting issues count", logger.Field{Key: "jql", Value: jql})
 	params := url.Values{
 		"jql":        []string{jql},
+		"startAt":    []string{"0"},
 		"maxResults": []string{"0"},
 	}
 
@@ -48,99 +95,145 @@ func (c *Client) getIssuesCountAfter(ctx context.Context, projectKey string, las
 	}
 
 	endpoint := c.buildURL("/search", params)
-	err := c.doRequest(ctx, endpoint, &result)
+	err := c.withRetry(ctx, func() error {
+		return c.doRequest(ctx, endpoint, &result)
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get issues count: %w", err)
 	}
+	c.logger.Info(fmt.Sprintf("finished getting issues count. Count: %d", result.Total))
 	return result.Total, nil
 }
 
-func (c *Client) getIssuesBy(ctx context.Context, total int, params url.Values) (*[]models.JiraIssue, error) {
+// getIssuesBy fans out startAt offsets of the /search endpoint to the workers
+func (c *Client) getIssuesBy(ctx context.Context, total int, params url.Values,
+	out chan<- models.IssuePage) error {
+
 	pageSize := c.config.MaxResults
-	threadsCount := c.config.MaxProcesses
 
 	totalPages := (total + pageSize - 1) / pageSize
 	c.logger.Debug(fmt.Sprintf("Total pages: %d", totalPages))
-
-	pages := make(chan int, threadsCount)
-	results := make(chan []models.JiraIssue, threadsCount)
-
-	errGroup, ctx := errgroup.WithContext(ctx)
-	errGroup.SetLimit(threadsCount + 1)
+	if trace := models.ContextSyncTrace(ctx); trace.TotalDiscovered != nil {
+		trace.TotalDiscovered(total, totalPages)
+	}
 
 	link := c.buildURL("/search", params)
 
-	errGroup.Go(func() error {
-		defer close(pages)
+	return c.streamPages(ctx, out, func(ctx context.Context, pages chan<- pageRequest) error {
 		for page := 0; page < totalPages; page++ {
 			select {
-			case pages <- page:
+			case pages <- pageRequest{
+				number: page,
+				pages:  totalPages,
+				total:  total,
+				link:   link + fmt.Sprintf("&startAt=%d", page*pageSize),
+			}:
 			case <-ctx.Done():
 				return ctx.Err()
 			}
 		}
 		return nil
 	})
+}
+
+// pageRequest is a search page link with the page position in the query.
+// pages and total are 0 when the query size is unknown.
+type pageRequest struct {
+	number int
+	pages  int
+	total  int
+	link   string
+}
+
+// streamPages runs produce, which enqueues search page links, alongside MaxProcesses page workers
+// which send the fetched pages to out. Pages are sent in the order they are fetched.
+func (c *Client) streamPages(ctx context.Context, out chan<- models.IssuePage,
+	produce func(ctx context.Context, pages chan<- pageRequest) error) error {
+
+	threadsCount := c.config.MaxProcesses
+
+	pages := make(chan pageRequest, threadsCount)
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(threadsCount + 1)
+
+	errGroup.Go(func() error {
+		defer close(pages)
+		return produce(ctx, pages)
+	})
 
 	for i := 0; i < threadsCount; i++ {
 		errGroup.Go(func() error {
-			return c.issuePageWorker(ctx, pages, results, link)
+			return c.issuePageWorker(ctx, pages, out)
 		})
 	}
 
+	return errGroup.Wait()
+}
+
+// collectIssues buffers every page sent by stream
+func (c *Client) collectIssues(ctx context.Context,
+	stream func(ctx context.Context, out chan<- models.IssuePage) error) (*[]models.JiraIssue, error) {
+
+	results := make(chan models.IssuePage, c.config.MaxProcesses)
+
+	var err error
 	go func() {
-		errGroup.Wait()
+		err = stream(ctx, results)
 		close(results)
 	}()
 
-	allIssues := make([]models.JiraIssue, 0, total)
+	allIssues := make([]models.JiraIssue, 0)
 	for res := range results {
-		allIssues = append(allIssues, res...)
+		allIssues = append(allIssues, res.Issues...)
 	}
 
-	if err := errGroup.Wait(); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	return &allIssues, nil
 }
 
-func (c *Client) getAllIssues(ctx context.Context, projectKey string, total int) (*[]models.JiraIssue, error) {
-	pageSize := c.config.MaxResults
-
-	allIssues, err := c.getIssuesBy(ctx, total, url.Values{
-		"jql":        []string{fmt.Sprintf("project=%s", projectKey)},
-		"maxResults": []string{fmt.Sprintf("%d", pageSize)},
-		"expand":     []string{"changelog"},
-		"fields": []string{`summary,description,issuetype,priority,
-			status,creator,assignee,created,updated,resolutiondate,worklog,timetracking`},
-	})
+func (c *Client) getIssuesPage(ctx context.Context, link string) ([]models.JiraIssue, error) {
+	var body json.RawMessage
+	err := c.doRequest(ctx, link, &body)
 	if err != nil {
 		return nil, err
 	}
-	return allIssues, nil
-}
-
-func (c *Client) getIssuesPage(ctx context.Context, link string) ([]models.JiraIssue, error) {
 	var result struct {
 		Issues []models.JiraIssue `json:"issues"`
 	}
-
-	err := c.doRequest(ctx, link, &result)
-	if err != nil {
-		return nil, err
+	if err = json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if c.config.EpicLinkField != "" || len(c.config.CustomFields) > 0 {
+		if err = c.readCustomFields(body, result.Issues); err != nil {
+			return nil, err
+		}
+	}
+	for i := range result.Issues {
+		result.Issues[i].Render(c.config.DescriptionFormat)
 	}
 
 	return result.Issues, nil
 }
 
-func (c *Client) issuePageWorker(ctx context.Context, pages chan int,
-	issuePages chan<- []models.JiraIssue, link string) error {
+// completeIssues fetches the changelogs, worklogs and comments cut from search results
+func (c *Client) completeIssues(ctx context.Context, issues []models.JiraIssue) error {
+	if err := c.completeChangelogs(ctx, issues); err != nil {
+		return err
+	}
+	if err := c.completeWorklogs(ctx, issues); err != nil {
+		return err
+	}
+	return c.completeComments(ctx, issues)
+}
 
-	pageSize := c.config.MaxResults
+func (c *Client) issuePageWorker(ctx context.Context, pages <-chan pageRequest,
+	issuePages chan<- models.IssuePage) error {
+
+	trace := models.ContextSyncTrace(ctx)
 	for {
-		if err := c.waitIfPaused(ctx); err != nil {
-			return err
-		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -148,33 +241,28 @@ func (c *Client) issuePageWorker(ctx context.Context, pages chan int,
 			if !ok {
 				return nil
 			}
+			link := page.link
 
-			startAt := page * pageSize
-			c.logger.Debug("Processing page",
-				logger.Field{Key: "page_size", Value: pageSize},
-				logger.Field{Key: "page", Value: page})
-
-			issues, err := c.getIssuesPage(ctx, link+fmt.Sprintf("&startAt=%d", startAt))
-
-			var apiErr *APIError
-			if errors.As(err, &apiErr) {
-				if apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500 {
-					c.logger.Info("API rate limit exceeded", logger.Field{Key: "Error", Value: apiErr.Error()})
-					c.rl.Pause()
-					go func() { pages <- page }()
-					continue
-				} else {
-					return err
-				}
-			}
+			c.logger.Debug("Processing page", logger.Field{Key: "link", Value: link})
 
-			c.rl.Reset()
+			var issues []models.JiraIssue
+			err := c.withRetry(ctx, func() (err error) {
+				issues, err = c.getIssuesPage(ctx, link)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if err = c.completeIssues(ctx, issues); err != nil {
+				return err
+			}
+			if trace.PageFetched != nil {
+				trace.PageFetched(page.number, len(issues))
+			}
 
 			select {
-			case issuePages <- issues:
-				c.logger.Debug("Processed page",
-					logger.Field{Key: "page_size", Value: pageSize},
-					logger.Field{Key: "page", Value: page})
+			case issuePages <- models.IssuePage{Number: page.number, Pages: page.pages, Total: page.total, Issues: issues}:
+				c.logger.Debug("Processed page", logger.Field{Key: "link", Value: link})
 			case <-ctx.Done():
 				return ctx.Err()
 			}
@@ -182,6 +270,36 @@ func (c *Client) issuePageWorker(ctx context.Context, pages chan int,
 	}
 }
 
+// withRetry repeats request while Jira answers with 429 or 5xx, pausing all workers in between
+// for as long as Jira asks in Retry-After or X-RateLimit-Reset
+func (c *Client) withRetry(ctx context.Context, request func() error) error {
+	for {
+		if err := c.waitIfPaused(ctx); err != nil {
+			return err
+		}
+
+		err := request()
+		var apiErr *APIError
+		if errors.As(err, &apiErr) &&
+			(apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500) {
+			c.logger.Info("API rate limit exceeded", logger.Field{Key: "Error", Value: apiErr.Error()})
+			// the backoff is used only if Jira did not say how long to wait
+			if delay := apiErr.Delay(time.Now()); delay > 0 {
+				c.rl.PauseFor(delay)
+			} else {
+				c.rl.Pause()
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		c.rl.Reset()
+		return nil
+	}
+}
+
 func (c *Client) waitIfPaused(ctx context.Context) error {
 	for {
 		paused, duration := c.rl.ShouldPause()
@@ -193,6 +311,9 @@ func (c *Client) waitIfPaused(ctx context.Context) error {
 		}
 		c.logger.Info("Request paused due to rate limiting",
 			logger.Field{Key: "retry_after", Value: duration.String()})
+		if trace := models.ContextSyncTrace(ctx); trace.Paused != nil {
+			trace.Paused(duration)
+		}
 
 		select {
 		case <-time.After(duration):
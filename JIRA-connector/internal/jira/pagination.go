@@ -0,0 +1,140 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+type Pagination string
+
+const (
+	// PaginationOffset counts the issues first and fans out startAt offsets of /search
+	PaginationOffset Pagination = "offset"
+	// PaginationToken walks nextPageToken cursors of /search/jql, which has no total
+	PaginationToken Pagination = "token"
+)
+
+// getIssuesByToken walks the /search/jql cursor sequentially, requesting only issue ids.
+// Every page of ids is then fetched with changelog expansion by the page workers in parallel.
+func (c *Client) getIssuesByToken(ctx context.Context, params url.Values,
+	out chan<- models.IssuePage) error {
+
+	jql := params.Get("jql")
+
+	return c.streamPages(ctx, out, func(ctx context.Context, pages chan<- pageRequest) error {
+		token := ""
+		number := 0
+		for page := 0; ; page++ {
+			refs, next, err := c.getIssueRefsPage(ctx, jql, token)
+			if err != nil {
+				return err
+			}
+			ids := make([]string, 0, len(refs))
+			for _, ref := range refs {
+				ids = append(ids, ref.ID)
+			}
+			c.logger.Debug("Fetched issue ids page",
+				logger.Field{Key: "page", Value: page},
+				logger.Field{Key: "count", Value: len(ids)})
+
+			if len(ids) > 0 {
+				pageParams := url.Values{}
+				for k, v := range params {
+					pageParams[k] = v
+				}
+				pageParams.Set("jql", fmt.Sprintf("id in (%s)", strings.Join(ids, ",")))
+				pageParams.Set("maxResults", fmt.Sprintf("%d", len(ids)))
+
+				select {
+				case pages <- pageRequest{number: number, link: c.buildURL("/search/jql", pageParams)}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				number++
+			}
+
+			if next == "" {
+				return nil
+			}
+			token = next
+		}
+	})
+}
+
+// getIssueRefsPage fetches a page of the /search/jql cursor with the ids and keys of the issues only
+func (c *Client) getIssueRefsPage(ctx context.Context, jql, token string) ([]models.IssueRef, string, error) {
+	params := url.Values{
+		"jql":        []string{jql},
+		"fields":     []string{"id"},
+		"maxResults": []string{fmt.Sprintf("%d", c.config.MaxResults)},
+	}
+	if token != "" {
+		params.Set("nextPageToken", token)
+	}
+
+	var result struct {
+		Issues        []models.IssueRef `json:"issues"`
+		NextPageToken string            `json:"nextPageToken"`
+		IsLast        bool              `json:"isLast"`
+	}
+	err := c.withRetry(ctx, func() error {
+		return c.doRequest(ctx, c.buildURL("/search/jql", params), &result)
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get issue ids page: %w", err)
+	}
+
+	if result.IsLast {
+		return result.Issues, "", nil
+	}
+	return result.Issues, result.NextPageToken, nil
+}
+
+// getIssueRefsByToken walks the /search/jql cursor collecting the ids and keys of the issues
+func (c *Client) getIssueRefsByToken(ctx context.Context, jql string) ([]models.IssueRef, error) {
+	var refs []models.IssueRef
+	token := ""
+	for {
+		page, next, err := c.getIssueRefsPage(ctx, jql, token)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, page...)
+		if next == "" {
+			return refs, nil
+		}
+		token = next
+	}
+}
+
+// getIssueRefsByOffset walks the startAt offsets of /search collecting the ids and keys of the issues
+func (c *Client) getIssueRefsByOffset(ctx context.Context, jql string) ([]models.IssueRef, error) {
+	var refs []models.IssueRef
+	for {
+		params := url.Values{
+			"jql":        []string{jql},
+			"fields":     []string{"id"},
+			"startAt":    []string{fmt.Sprintf("%d", len(refs))},
+			"maxResults": []string{fmt.Sprintf("%d", c.config.MaxResults)},
+		}
+		var result struct {
+			Issues []models.IssueRef `json:"issues"`
+			Total  int               `json:"total"`
+		}
+		err := c.withRetry(ctx, func() error {
+			return c.doRequest(ctx, c.buildURL("/search", params), &result)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue ids page: %w", err)
+		}
+		refs = append(refs, result.Issues...)
+		if len(result.Issues) == 0 || len(refs) >= result.Total {
+			return refs, nil
+		}
+	}
+}
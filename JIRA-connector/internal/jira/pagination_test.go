@@ -0,0 +1,243 @@
+package jira_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/jira"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTokenServer имитирует /search/jql с курсорной пагинацией по nextPageToken
+func MockTokenServer(t *testing.T, total int) (*httptest.Server, *[]string) {
+	var m sync.Mutex
+	tokens := make([]string, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/rest/api/2/project/TEST":
+			json.NewEncoder(w).Encode(models.JiraProject{ID: "10000", Key: "TEST", Name: "Test Project"})
+			return
+		case "/rest/api/2/search/jql":
+		case "/rest/api/2/search":
+			t.Errorf("offset search must not be used with token pagination")
+			w.WriteHeader(http.StatusGone)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		jql := query.Get("jql")
+		if strings.HasPrefix(jql, "id in (") {
+			ids := strings.Split(strings.TrimSuffix(strings.TrimPrefix(jql, "id in ("), ")"), ",")
+			assert.Equal(t, "changelog", query.Get("expand"))
+			issues := make([]models.JiraIssue, 0, len(ids))
+			for _, id := range ids {
+				issues = append(issues, models.JiraIssue{
+					ID:     id,
+					Key:    "TEST-" + id,
+					Fields: models.Fields{Summary: "Issue " + id},
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"issues": issues})
+			return
+		}
+
+		assert.Contains(t, jql, "project=TEST")
+		assert.Equal(t, "id", query.Get("fields"))
+
+		pageSize, _ := strconv.Atoi(query.Get("maxResults"))
+		start := 0
+		if token := query.Get("nextPageToken"); token != "" {
+			m.Lock()
+			tokens = append(tokens, token)
+			m.Unlock()
+			start, _ = strconv.Atoi(strings.TrimPrefix(token, "cursor-"))
+		}
+
+		ids := make([]map[string]string, 0, pageSize)
+		for i := start; i < start+pageSize && i < total; i++ {
+			ids = append(ids, map[string]string{"id": strconv.Itoa(i), "key": "TEST-" + strconv.Itoa(i)})
+		}
+		response := map[string]interface{}{"issues": ids}
+		if start+pageSize < total {
+			response["nextPageToken"] = fmt.Sprintf("cursor-%d", start+pageSize)
+		} else {
+			response["isLast"] = true
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	return server, &tokens
+}
+
+func TestClient_TokenPagination(t *testing.T) {
+	newClient := func(url string) *jira.Client {
+		return jira.NewClient(
+			jira.WithConfig(jira.Config{
+				BaseURL:      url,
+				VersionAPI:   jira.VersionAPI2,
+				MaxResults:   50,
+				MaxProcesses: 3,
+				Pagination:   jira.PaginationToken,
+			}),
+			jira.WithLogger(&logger.TestLogger{}),
+		)
+	}
+
+	t.Run("GetProject", func(t *testing.T) {
+		server, tokens := MockTokenServer(t, 120)
+		defer server.Close()
+
+		project, err := newClient(server.URL).GetProject(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, 120, project.TotalIssueCount)
+		require.Len(t, project.Issues, 120)
+
+		seen := make(map[string]struct{})
+		for _, issue := range project.Issues {
+			seen[issue.ID] = struct{}{}
+		}
+		assert.Len(t, seen, 120)
+		// Курсор обходится последовательно
+		assert.Equal(t, []string{"cursor-50", "cursor-100"}, *tokens)
+	})
+
+	t.Run("UpdateProject", func(t *testing.T) {
+		server, _ := MockTokenServer(t, 30)
+		defer server.Close()
+
+		issues, err := newClient(server.URL).UpdateProject(context.Background(), "TEST",
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Len(t, *issues, 30)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		server, tokens := MockTokenServer(t, 0)
+		defer server.Close()
+
+		issues, err := newClient(server.URL).UpdateProject(context.Background(), "TEST", time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, *issues)
+		assert.Empty(t, *tokens)
+	})
+
+	t.Run("StreamIssues", func(t *testing.T) {
+		server, _ := MockTokenServer(t, 120)
+		defer server.Close()
+
+		pages := make(chan models.IssuePage, 10)
+		err := newClient(server.URL).StreamIssues(context.Background(), "project=TEST", time.Time{}, pages)
+		require.NoError(t, err)
+		close(pages)
+
+		// Страницы пронумерованы по порядку курсора, даже если загружены в другом порядке
+		numbers := make([]int, 0)
+		for page := range pages {
+			numbers = append(numbers, page.Number)
+		}
+		assert.ElementsMatch(t, []int{0, 1, 2}, numbers)
+	})
+
+	t.Run("ListIssueRefs", func(t *testing.T) {
+		server, tokens := MockTokenServer(t, 120)
+		defer server.Close()
+
+		refs, err := newClient(server.URL).ListIssueRefs(context.Background(), "TEST")
+		require.NoError(t, err)
+		require.Len(t, refs, 120)
+		assert.Equal(t, models.IssueRef{ID: "119", Key: "TEST-119"}, refs[119])
+		assert.Equal(t, []string{"cursor-50", "cursor-100"}, *tokens)
+	})
+}
+
+func TestClient_ListIssueRefs(t *testing.T) {
+	const total = 120
+	var m sync.Mutex
+	starts := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/rest/api/2/search", r.URL.Path)
+		assert.Equal(t, "project=TEST ORDER BY key ASC", query.Get("jql"))
+		// Запрашиваются только id задач
+		assert.Equal(t, "id", query.Get("fields"))
+		m.Lock()
+		starts = append(starts, query.Get("startAt"))
+		m.Unlock()
+
+		start, _ := strconv.Atoi(query.Get("startAt"))
+		pageSize, _ := strconv.Atoi(query.Get("maxResults"))
+		issues := make([]models.IssueRef, 0, pageSize)
+		for i := start; i < start+pageSize && i < total; i++ {
+			issues = append(issues, models.IssueRef{ID: strconv.Itoa(10000 + i), Key: "TEST-" + strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"total": total, "issues": issues})
+	}))
+	defer server.Close()
+
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   jira.VersionAPI2,
+			MaxResults:   50,
+			MaxProcesses: 3,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+	)
+
+	refs, err := client.ListIssueRefs(context.Background(), "TEST")
+	require.NoError(t, err)
+	require.Len(t, refs, total)
+	assert.Equal(t, models.IssueRef{ID: "10000", Key: "TEST-0"}, refs[0])
+	assert.Equal(t, []string{"0", "50", "100"}, starts)
+}
+
+func TestClient_StreamIssuesJQL(t *testing.T) {
+	var m sync.Mutex
+	jqls := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		jqls = append(jqls, r.URL.Query().Get("jql"))
+		m.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"total": 0, "issues": []models.JiraIssue{}})
+	}))
+	defer server.Close()
+
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   jira.VersionAPI2,
+			MaxResults:   50,
+			MaxProcesses: 1,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+	)
+	pages := make(chan models.IssuePage, 1)
+
+	jql := client.IssuesJQL("TEST", time.Time{})
+	assert.Equal(t, "project=TEST", jql)
+	require.NoError(t, client.StreamIssues(context.Background(), jql, time.Time{}, pages))
+
+	// Продолжение с high-water отметки в часовом поясе Jira
+	from := time.Date(2025, 5, 1, 10, 15, 42, 0, time.FixedZone("MSK", 3*60*60))
+	require.NoError(t, client.StreamIssues(context.Background(), jql, from, pages))
+
+	assert.Equal(t, []string{
+		"project=TEST ORDER BY updated ASC, key ASC",
+		`(project=TEST) AND updated >= "2025/05/01 10:15" ORDER BY updated ASC, key ASC`,
+	}, jqls)
+}
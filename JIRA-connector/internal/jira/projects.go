@@ -2,115 +2,111 @@ package jira
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/sssidkn/jira-connector/internal/models"
 	"github.com/sssidkn/jira-connector/pkg/logger"
-	"io"
-	"net/http"
-	"net/url"
 	"time"
 )
 
-// GetProject returns project information
+// GetProject returns project information with all its issues
 func (c *Client) GetProject(ctx context.Context, projectKey string) (*models.JiraProject, error) {
-	endpoint := fmt.Sprintf("%s%s/project/%s?expand=insight,description,lead",
-		c.config.BaseURL, c.config.VersionAPI, projectKey)
-	log := c.logger.With(
-		logger.Field{Key: "project_key", Value: projectKey},
-		logger.Field{Key: "project_url", Value: endpoint},
-	)
-
-	req, err := http.NewRequest("GET", endpoint, nil)
+	project, err := c.GetProjectInfo(ctx, projectKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	log := c.logger.With(logger.Field{Key: "project_key", Value: projectKey})
 
-	resp, err := c.httpClient.Do(req)
-	defer resp.Body.Close()
+	log.Info("Fetching issues")
+	issues, err := c.searchIssues(ctx, projectJQL(projectKey))
+	log.Info("Fetching issues ended")
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to get issues: %w", err)
 	}
+	project.Issues = *issues
+	project.TotalIssueCount = len(project.Issues)
+	log.Info("Fetched issues count", logger.Field{Key: "total", Value: project.TotalIssueCount})
+	return project, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
-	}
+// GetProjectInfo returns project information without issues
+func (c *Client) GetProjectInfo(ctx context.Context, projectKey string) (*models.JiraProject, error) {
+	endpoint := fmt.Sprintf("%s%s/project/%s?expand=insight,description,lead",
+		c.config.BaseURL, c.config.VersionAPI, projectKey)
 
 	var project models.JiraProject
-
-	if err = json.NewDecoder(resp.Body).Decode(&project); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doRequest(ctx, endpoint, &project); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 	project.Self = c.config.BaseURL + "/projects/" + project.Key
-	total, err := c.getTotalIssuesCount(ctx, projectKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total issues count: %w", err)
-	}
-	project.TotalIssueCount = total
-	if project.TotalIssueCount == 0 {
-		return &project, nil
-	}
-
-	var issues *[]models.JiraIssue
+	return &project, nil
+}
 
-	log.Info("Fetched issues count", logger.Field{Key: "total", Value: project.TotalIssueCount})
-	log.Info("Fetching issues")
-	issues, err = c.getAllIssues(ctx, projectKey, project.TotalIssueCount)
-	log.Info("Fetching issues ended")
+// GetProjectVersions returns the released and unreleased versions of the project
+func (c *Client) GetProjectVersions(ctx context.Context, projectKey string) ([]models.Version, error) {
+	var versions []models.Version
+	endpoint := c.buildURL(fmt.Sprintf("/project/%s/versions", projectKey), nil)
+	err := c.withRetry(ctx, func() error {
+		return c.doRequest(ctx, endpoint, &versions)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issues: %w", err)
+		return nil, fmt.Errorf("failed to get project versions: %w", err)
 	}
-	project.Issues = *issues
-	return &project, nil
+	return versions, nil
 }
 
-// UpdateProject updates project
+// UpdateProject returns issues of the project updated after lastUpdate
 func (c *Client) UpdateProject(ctx context.Context, projectKey string, lastUpdate time.Time) (*[]models.JiraIssue, error) {
-	total, err := c.getIssuesCountAfter(ctx, projectKey, lastUpdate)
-	if err != nil {
-		return nil, err
+	return c.searchIssues(ctx, updatedAfterJQL(projectKey, lastUpdate))
+}
+
+// IssuesJQL returns the query of a project sync: every issue of the project for zero lastUpdate,
+// otherwise the issues updated after lastUpdate
+func (c *Client) IssuesJQL(projectKey string, lastUpdate time.Time) string {
+	if lastUpdate.IsZero() {
+		return projectJQL(projectKey)
 	}
+	return updatedAfterJQL(projectKey, lastUpdate)
+}
 
-	query := fmt.Sprintf("project=%s AND updated > \"%s\"", projectKey,
-		lastUpdate.UTC().Format("2006/01/02"))
+// StreamIssues sends pages of issues matching jql, ordered by updated, to pages as soon as
+// they are fetched. Non-zero from skips the issues updated before it. pages is not closed.
+func (c *Client) StreamIssues(ctx context.Context, jql string, from time.Time,
+	pages chan<- models.IssuePage) error {
 
-	params := url.Values{
-		"jql":        []string{query},
-		"maxResults": []string{fmt.Sprintf("%d", c.config.MaxResults)},
-		"expand":     []string{"changelog"},
-		"fields": []string{`summary,description,issuetype,priority,
-			status,creator,assignee,created,updated,resolutiondate,worklog,timetracking`},
+	if err := c.streamSearch(ctx, syncJQL(jql, from), pages); err != nil {
+		return fmt.Errorf("failed to get issues: %w", err)
 	}
+	return nil
+}
 
-	issues, err := c.getIssuesBy(ctx, total, params)
+// ListIssueRefs returns the ids and keys of every issue of the project. Unlike StreamIssues
+// it requests no fields, so the whole project is listed in a few requests.
+func (c *Client) ListIssueRefs(ctx context.Context, projectKey string) ([]models.IssueRef, error) {
+	// keys are ordered by number, so issues created while the list is paged through come last
+	jql := projectJQL(projectKey) + " ORDER BY key ASC"
+	var refs []models.IssueRef
+	var err error
+	if c.config.Pagination == PaginationToken {
+		refs, err = c.getIssueRefsByToken(ctx, jql)
+	} else {
+		refs, err = c.getIssueRefsByOffset(ctx, jql)
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to list issues: %w", err)
 	}
-	return issues, nil
+	return refs, nil
 }
 
 // GetProjects returns projects
 func (c *Client) GetProjects(ctx context.Context, limit, page int, search string) ([]models.ProjectInfo, error) {
 	c.logger.Info("starting getting projects")
-	response, err := http.Get(c.config.BaseURL + c.config.VersionAPI + "/project")
-	defer response.Body.Close()
-	if err != nil {
-		return nil, err
-	}
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
+	var jiraProjects []models.ProjectInfo
+	if err := c.doRequest(ctx, c.buildURL("/project", nil), &jiraProjects); err != nil {
 		return nil, err
 	}
-
-	var jiraProjects []models.ProjectInfo
-	err = json.Unmarshal(body, &jiraProjects)
 	for i := 0; i < len(jiraProjects); i++ {
 		jiraProjects[i].Self = c.config.BaseURL + "/projects/" + jiraProjects[i].Key
 	}
-	if err != nil {
-		return nil, err
-	}
 
 	return jiraProjects, nil
 }
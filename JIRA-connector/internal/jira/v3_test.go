@@ -0,0 +1,101 @@
+package jira_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/sssidkn/jira-connector/internal/jira"
+	"github.com/sssidkn/jira-connector/pkg/adf"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockV3Server отдает записанные ответы Jira Cloud REST v3 из testdata/v3
+func MockV3Server() *httptest.Server {
+	fixture := func(w http.ResponseWriter, r *http.Request, name string) {
+		w.Header().Set("Content-Type", "application/json")
+		http.ServeFile(w, r, filepath.Join("testdata", "v3", name))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/project/CLOUD":
+			fixture(w, r, "project.json")
+		case "/rest/api/3/search":
+			if r.URL.Query().Get("maxResults") == "0" {
+				fixture(w, r, "search_count.json")
+				return
+			}
+			fixture(w, r, "search.json")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_V3(t *testing.T) {
+	server := MockV3Server()
+	defer server.Close()
+
+	newClient := func(format adf.Format) *jira.Client {
+		return jira.NewClient(
+			jira.WithConfig(jira.Config{
+				BaseURL:           server.URL,
+				MaxResults:        50,
+				MaxProcesses:      2,
+				DescriptionFormat: format,
+			}),
+			jira.WithVersionAPI(jira.VersionAPI3),
+			jira.WithLogger(&logger.TestLogger{}),
+		)
+	}
+
+	t.Run("PlainTextDescription", func(t *testing.T) {
+		project, err := newClient(adf.FormatText).GetProject(context.Background(), "CLOUD")
+		require.NoError(t, err)
+		require.Len(t, project.Issues, 2)
+
+		issues := project.Issues
+		if issues[0].Key != "CLOUD-1" {
+			issues[0], issues[1] = issues[1], issues[0]
+		}
+		assert.Equal(t, "Steps\n\n1. Open /login\n2. Submit the form\n\nReported by @Mia Krystof, see docs and hurry",
+			issues[0].Fields.Description.Text)
+		assert.Empty(t, issues[1].Fields.Description.Text)
+	})
+
+	t.Run("MarkdownDescription", func(t *testing.T) {
+		project, err := newClient(adf.FormatMarkdown).GetProject(context.Background(), "CLOUD")
+		require.NoError(t, err)
+
+		for _, issue := range project.Issues {
+			if issue.Key != "CLOUD-1" {
+				continue
+			}
+			assert.Equal(t, "## Steps\n\n1. Open `/login`\n2. Submit the form\n\n"+
+				"Reported by @Mia Krystof, see [docs](https://example.com/docs) and **hurry**",
+				issue.Fields.Description.Text)
+		}
+	})
+
+	t.Run("AccountIDUsers", func(t *testing.T) {
+		project, err := newClient(adf.FormatText).GetProject(context.Background(), "CLOUD")
+		require.NoError(t, err)
+
+		for _, issue := range project.Issues {
+			assert.Equal(t, "5b10a2844c20165700ede21g", issue.Fields.Creator.ID())
+			assert.Equal(t, "Mia Krystof", issue.Fields.Creator.DisplayName)
+			if issue.Key == "CLOUD-1" {
+				assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", issue.Fields.Assignee.ID())
+				require.Len(t, issue.Changelogs.Histories, 1)
+				assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", issue.Changelogs.Histories[0].Author.ID())
+			} else {
+				assert.Empty(t, issue.Fields.Assignee.ID())
+			}
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// worklogPageSize is the page size of /issue/{key}/worklog
+const worklogPageSize = 1000
+
+// completeWorklogs fetches the worklogs cut from search results, which hold at most 20 worklogs per issue
+func (c *Client) completeWorklogs(ctx context.Context, issues []models.JiraIssue) error {
+	for i := range issues {
+		worklog := issues[i].Fields.Worklog
+		if worklog == nil || worklog.Complete() {
+			continue
+		}
+		c.logger.Debug("Fetching all worklogs",
+			logger.Field{Key: "issue", Value: issues[i].Key},
+			logger.Field{Key: "embedded", Value: len(worklog.Worklogs)},
+			logger.Field{Key: "total", Value: worklog.Total})
+
+		worklogs, err := c.getWorklogs(ctx, issues[i].Key)
+		if err != nil {
+			return fmt.Errorf("failed to get worklogs of %s: %w", issues[i].Key, err)
+		}
+		for j := range worklogs {
+			worklogs[j].Comment.Render(c.config.DescriptionFormat)
+		}
+		worklog.Worklogs = worklogs
+		worklog.StartAt = 0
+		worklog.MaxResults = len(worklogs)
+		worklog.Total = len(worklogs)
+	}
+	return nil
+}
+
+// getWorklogs pages through /issue/{key}/worklog
+func (c *Client) getWorklogs(ctx context.Context, issueKey string) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	startAt := 0
+	for {
+		params := url.Values{
+			"startAt":    []string{strconv.Itoa(startAt)},
+			"maxResults": []string{strconv.Itoa(worklogPageSize)},
+		}
+		var page models.Worklogs
+		link := c.buildURL(fmt.Sprintf("/issue/%s/worklog", url.PathEscape(issueKey)), params)
+		err := c.withRetry(ctx, func() error {
+			return c.doRequest(ctx, link, &page)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		worklogs = append(worklogs, page.Worklogs...)
+		startAt += len(page.Worklogs)
+		if len(page.Worklogs) == 0 || startAt >= page.Total {
+			return worklogs, nil
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/sssidkn/jira-connector/pkg/adf"
+
 type JiraIssue struct {
 	ID         string    `json:"id"`
 	Key        string    `json:"key"`
@@ -7,9 +9,28 @@ type JiraIssue struct {
 	Changelogs Changelog `json:"changelog"`
 }
 
+// Render converts the ADF documents of the issue into the given format
+func (i *JiraIssue) Render(format adf.Format) {
+	i.Fields.Description.Render(format)
+	if i.Fields.Worklog != nil {
+		for j := range i.Fields.Worklog.Worklogs {
+			i.Fields.Worklog.Worklogs[j].Comment.Render(format)
+		}
+	}
+	if i.Fields.Comment != nil {
+		for j := range i.Fields.Comment.Comments {
+			i.Fields.Comment.Comments[j].Body.Render(format)
+		}
+	}
+}
+
 type Fields struct {
-	Summary     string `json:"summary"`
-	Description string `json:"description"`
+	// Project is only read from webhook payloads, search results are requested per project
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Summary     string   `json:"summary"`
+	Description RichText `json:"description"`
 	IssueType   struct {
 		Name string `json:"name"`
 	} `json:"issuetype"`
@@ -27,13 +48,112 @@ type Fields struct {
 	Timetracking struct {
 		TimeSpentSeconds *int `json:"timeSpentSeconds"`
 	} `json:"timetracking"`
+	// Worklog is nil if the field was not requested
+	Worklog *Worklogs `json:"worklog"`
+	// Comment is nil if the field was not requested
+	Comment *Comments `json:"comment"`
+	// IssueLinks is nil if the field was not requested
+	IssueLinks []IssueLink `json:"issuelinks"`
+	// Parent is the parent of a sub-task, or the epic of a Jira Cloud issue
+	Parent   *IssueRef  `json:"parent"`
+	Subtasks []IssueRef `json:"subtasks"`
+	// EpicLink is the key of the epic from the custom field configured in EpicLinkField
+	EpicLink string `json:"-"`
+	// Components, Labels, FixVersions and Versions are nil if the fields were not requested
+	Components  []Component `json:"components"`
+	Labels      []string    `json:"labels"`
+	FixVersions []Version   `json:"fixVersions"`
+	// Versions are the affected versions
+	Versions []Version `json:"versions"`
+	// CustomFields are the values of the fields configured in CustomFields by their mapped names.
+	// It is nil if no fields are mapped, fields without a value are left out.
+	CustomFields map[string]any `json:"-"`
+}
+
+// IssueRef is the short form of an issue in links, parent and sub-tasks
+type IssueRef struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// IssueLink links the issue to another one, either InwardIssue or OutwardIssue is set
+type IssueLink struct {
+	ID   string `json:"id"`
+	Type struct {
+		// Name is the link type, e.g. Blocks
+		Name string `json:"name"`
+		// Inward and Outward describe the link from both sides, e.g. "is blocked by" and "blocks"
+		Inward  string `json:"inward"`
+		Outward string `json:"outward"`
+	} `json:"type"`
+	InwardIssue  *IssueRef `json:"inwardIssue"`
+	OutwardIssue *IssueRef `json:"outwardIssue"`
+}
+
+// Ends returns the keys of the linked issues in the outward direction of the link type,
+// e.g. the blocking issue first, for the link listed on the issue with issueKey
+func (l IssueLink) Ends(issueKey string) (source, target string) {
+	if l.OutwardIssue != nil {
+		return issueKey, l.OutwardIssue.Key
+	}
+	if l.InwardIssue != nil {
+		return l.InwardIssue.Key, issueKey
+	}
+	return "", ""
+}
+
+// Worklogs embedded in search results hold at most MaxResults of Total worklogs
+type Worklogs struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Worklogs   []Worklog `json:"worklogs"`
+}
+
+// Complete reports whether every worklog of the issue is listed
+func (w *Worklogs) Complete() bool {
+	return w != nil && w.Total <= len(w.Worklogs)
+}
+
+// Comments embedded in search results hold at most MaxResults of Total comments
+type Comments struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Comments   []Comment `json:"comments"`
 }
 
+// Complete reports whether every comment of the issue is listed
+func (c *Comments) Complete() bool {
+	return c != nil && c.Total <= len(c.Comments)
+}
+
+type Comment struct {
+	ID      string   `json:"id"`
+	Author  JiraUser `json:"author"`
+	Body    RichText `json:"body"`
+	Created JiraTime `json:"created"`
+	Updated JiraTime `json:"updated"`
+}
+
+type Worklog struct {
+	ID               string   `json:"id"`
+	Author           JiraUser `json:"author"`
+	Comment          RichText `json:"comment"`
+	Started          JiraTime `json:"started"`
+	TimeSpentSeconds int      `json:"timeSpentSeconds"`
+}
+
+// Changelog embedded in search results holds at most MaxResults of Total histories
 type Changelog struct {
-	Histories []History `json:"histories"`
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Histories  []History `json:"histories"`
 }
 
 type History struct {
+	ID      string   `json:"id"`
 	Created JiraTime `json:"created"`
 	Author  JiraUser `json:"author"`
 	Items   []Item   `json:"items"`
@@ -41,11 +161,28 @@ type History struct {
 
 type Item struct {
 	Field      string `json:"field"`
+	From       string `json:"from"`
 	FromString string `json:"fromString"`
+	To         string `json:"to"`
 	ToString   string `json:"toString"`
 }
 
 type JiraUser struct {
 	AccountID   string `json:"accountId"`
+	Name        string `json:"name"`
+	Key         string `json:"key"`
 	DisplayName string `json:"displayName"`
 }
+
+// ID returns the stable user identifier: accountId in Jira Cloud (REST v3),
+// key in Jira Server (REST v2), which unlike the name survives renaming the user.
+func (u JiraUser) ID() string {
+	switch {
+	case u.AccountID != "":
+		return u.AccountID
+	case u.Key != "":
+		return u.Key
+	default:
+		return u.Name
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+// Board is a Jira Software board
+type Board struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Type is scrum, kanban or simple
+	Type string `json:"type"`
+}
+
+// Sprint is a sprint of a scrum board. Dates are zero until the sprint is started or completed.
+type Sprint struct {
+	ID int `json:"id"`
+	// BoardID is the board the sprint was created on
+	BoardID      int      `json:"originBoardId"`
+	Name         string   `json:"name"`
+	State        string   `json:"state"`
+	Goal         string   `json:"goal"`
+	StartDate    JiraTime `json:"startDate"`
+	EndDate      JiraTime `json:"endDate"`
+	CompleteDate JiraTime `json:"completeDate"`
+	// IssueKeys are the issues in the sprint
+	IssueKeys []string `json:"-"`
+}
+
+// ProjectSprints are the boards of a project and the sprints of these boards
+type ProjectSprints struct {
+	ProjectKey string
+	Boards     []Board
+	Sprints    []Sprint
+}
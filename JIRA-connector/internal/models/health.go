@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type BreakerState string
+
+const (
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen means requests to Jira fail fast until RetryAt
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen means a single probe request is let through
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// JiraHealth is the state of the circuit breaker around the Jira API
+type JiraHealth struct {
+	BaseURL string
+	State   BreakerState
+	// Requests and Failures are counted over the recent requests window
+	Requests int
+	Failures int
+	// OpenedAt and RetryAt are zero unless the circuit is open or half-open
+	OpenedAt time.Time
+	RetryAt  time.Time
+}
@@ -4,6 +4,10 @@ import (
 	"time"
 )
 
+// DefaultSource is the id of the Jira instance configured by the Jira section of the config.
+// Projects synced before sources were introduced belong to it too.
+const DefaultSource = "default"
+
 type JiraProject struct {
 	ID              string      `json:"id"`
 	Key             string      `json:"key"`
@@ -11,13 +15,32 @@ type JiraProject struct {
 	Self            string      `json:"self"`
 	Issues          []JiraIssue `json:"issues"`
 	TotalIssueCount int         `json:"totalIssuesCount"`
+	Versions        []Version   `json:"versions"`
 	LastUpdate      time.Time
 }
 
 type ProjectInfo struct {
 	ID         string `json:"id"`
+	JiraID     string `json:"-"` // Jira id of a saved project, whose ID is the one it got in the database
 	Key        string `json:"key"`
 	Name       string `json:"name"`
 	LastUpdate time.Time
 	Self       string `json:"self"`
 }
+
+// Component is a part of the project issues are filed against
+type Component struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Version is a release of the project. Issues refer to it as a fix or an affected version.
+type Version struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Archived    bool     `json:"archived"`
+	Released    bool     `json:"released"`
+	StartDate   JiraTime `json:"startDate"`
+	ReleaseDate JiraTime `json:"releaseDate"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/sssidkn/jira-connector/pkg/adf"
+)
+
+// RichText is a text field which is a plain string in REST v2
+// and an Atlassian Document Format document in REST v3.
+type RichText struct {
+	Text string
+	Doc  *adf.Node
+}
+
+func (rt *RichText) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &rt.Text)
+	}
+	var doc adf.Node
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	rt.Doc = &doc
+	rt.Text = adf.ToText(&doc)
+	return nil
+}
+
+func (rt RichText) MarshalJSON() ([]byte, error) {
+	if rt.Doc != nil {
+		return json.Marshal(rt.Doc)
+	}
+	return json.Marshal(rt.Text)
+}
+
+// Render converts an ADF document into the given format. Plain strings are kept as is.
+func (rt *RichText) Render(format adf.Format) {
+	if rt.Doc != nil {
+		rt.Text = adf.Convert(rt.Doc, format)
+	}
+}
+
+func (rt RichText) String() string {
+	return rt.Text
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sssidkn/jira-connector/pkg/adf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRichText_UnmarshalJSON(t *testing.T) {
+	t.Run("PlainString", func(t *testing.T) {
+		var rt RichText
+		require.NoError(t, json.Unmarshal([]byte(`"plain *wiki* text"`), &rt))
+		assert.Equal(t, "plain *wiki* text", rt.Text)
+		assert.Nil(t, rt.Doc)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		var rt RichText
+		require.NoError(t, json.Unmarshal([]byte(`null`), &rt))
+		assert.Empty(t, rt.Text)
+	})
+
+	t.Run("ADFDocument", func(t *testing.T) {
+		var rt RichText
+		doc := `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"hi","marks":[{"type":"strong"}]}]}]}`
+		require.NoError(t, json.Unmarshal([]byte(doc), &rt))
+		assert.Equal(t, "hi", rt.Text)
+		require.NotNil(t, rt.Doc)
+
+		rt.Render(adf.FormatMarkdown)
+		assert.Equal(t, "**hi**", rt.Text)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		data, err := json.Marshal(Fields{Description: RichText{Text: "text"}})
+		require.NoError(t, err)
+
+		var fields Fields
+		require.NoError(t, json.Unmarshal(data, &fields))
+		assert.Equal(t, "text", fields.Description.Text)
+	})
+}
+
+func TestJiraUser_ID(t *testing.T) {
+	assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", JiraUser{AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Emma"}.ID())
+	assert.Equal(t, "JIRAUSER1", JiraUser{Name: "emma", Key: "JIRAUSER1"}.ID())
+	assert.Equal(t, "emma", JiraUser{Name: "emma"}.ID())
+	assert.Empty(t, JiraUser{}.ID())
+}
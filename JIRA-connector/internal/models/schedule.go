@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// SyncSchedule is the schedule of the periodic sync of all tracked projects
+type SyncSchedule struct {
+	Enabled bool
+	// Cron is a standard 5-field cron expression
+	Cron string
+	// Jitter is the upper bound of the random delay added to every run
+	Jitter        time.Duration
+	MaxConcurrent int
+	UpdatedAt     time.Time
+	// NextRunAt is planned by the scheduler and is not saved, it is zero if the schedule is disabled
+	NextRunAt time.Time
+}
+
+type ScheduleOutcome string
+
+const (
+	ScheduleSucceeded ScheduleOutcome = "succeeded"
+	ScheduleFailed    ScheduleOutcome = "failed"
+	ScheduleCanceled  ScheduleOutcome = "canceled"
+	// ScheduleSkipped means the project sync was already running
+	ScheduleSkipped ScheduleOutcome = "skipped"
+)
+
+// ScheduleRun is one run of the scheduler over the tracked projects
+type ScheduleRun struct {
+	ID         int64
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Error      string
+	Projects   []ScheduledSync
+}
+
+// ScheduledSync is the outcome of a project sync started by the scheduler
+type ScheduledSync struct {
+	ProjectKey string
+	JobID      int64
+	Outcome    ScheduleOutcome
+	Error      string
+}
+
+// Count returns the number of projects with the outcome
+func (r ScheduleRun) Count(outcome ScheduleOutcome) int {
+	count := 0
+	for _, p := range r.Projects {
+		if p.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// IssuePage is one search page. Number is the position of the page in its query, starting from 0.
+// Pages and Total are the page and issue counts of the whole query, 0 when Jira does not report them.
+type IssuePage struct {
+	Number int
+	Pages  int
+	Total  int
+	Issues []JiraIssue
+}
+
+// HighWater returns the latest updated timestamp of the page issues
+func (p IssuePage) HighWater() time.Time {
+	var hw time.Time
+	for _, issue := range p.Issues {
+		if issue.Fields.Updated.After(hw) {
+			hw = issue.Fields.Updated.Time
+		}
+	}
+	return hw
+}
+
+// SyncCheckpoint is the progress of an unfinished project sync
+type SyncCheckpoint struct {
+	ProjectKey string
+	// JQL is the query of the interrupted run, without ordering
+	JQL string
+	// StartedAt becomes the project lastUpdate once the run completes
+	StartedAt      time.Time
+	PagesCompleted int
+	// HighWater is the latest updated timestamp of the committed pages, as a wall clock
+	// in the timezone Jira reported it in
+	HighWater time.Time
+}
+
+// Reconciliation counts the local issues changed to match the issue list of the project in Jira
+type Reconciliation struct {
+	// Moved issues were saved under another key or project and found by their Jira id
+	Moved int64
+	// Deleted issues are no longer in the project and are marked deleted
+	Deleted int64
+}
+
+type SyncJobState string
+
+const (
+	SyncJobQueued    SyncJobState = "queued"
+	SyncJobRunning   SyncJobState = "running"
+	SyncJobSucceeded SyncJobState = "succeeded"
+	SyncJobFailed    SyncJobState = "failed"
+	SyncJobCanceled  SyncJobState = "canceled"
+)
+
+// Finished reports whether the job has reached a final state
+func (s SyncJobState) Finished() bool {
+	return s == SyncJobSucceeded || s == SyncJobFailed || s == SyncJobCanceled
+}
+
+// SyncJob is a project sync running in the background. Totals are 0 while unknown.
+type SyncJob struct {
+	ID          int64
+	ProjectKey  string
+	State       SyncJobState
+	PagesDone   int
+	PagesTotal  int
+	IssuesDone  int
+	IssuesTotal int
+	Error       string
+	CreatedAt   time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	UpdatedAt   time.Time
+}
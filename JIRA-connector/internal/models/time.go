@@ -18,6 +18,8 @@ func (jt *JiraTime) UnmarshalJSON(data []byte) error {
 		"2006-01-02T15:04:05.000-0700",
 		"2006-01-02T15:04:05.000+0000",
 		time.RFC3339,
+		// start and release dates of versions
+		time.DateOnly,
 	}
 
 	s = strings.Trim(s, `"`)
@@ -36,6 +36,11 @@ func TestJiraTime_UnmarshalJSON(t *testing.T) {
 			jsonInput: `"2023-12-31T15:04:05.123456789Z"`,
 			expected:  time.Date(2023, 12, 31, 15, 4, 5, 123456789, time.UTC),
 		},
+		{
+			name:      "Date of a version",
+			jsonInput: `"2025-03-10"`,
+			expected:  time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
 		{
 			name:        "Null value",
 			jsonInput:   `null`,
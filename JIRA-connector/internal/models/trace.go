@@ -0,0 +1,58 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// SyncTrace is a set of hooks called by the Jira client while it fetches issues.
+// Hooks may be called concurrently and any of them may be nil.
+type SyncTrace struct {
+	// TotalDiscovered is called once the issue count of the query is known
+	TotalDiscovered func(issues, pages int)
+	// PageFetched is called after every fetched search page
+	PageFetched func(page, issues int)
+	// Paused is called when a request waits for the rate limit pause to pass
+	Paused func(retryAfter time.Duration)
+}
+
+type syncTraceKey struct{}
+
+// WithSyncTrace returns a context which makes the Jira client call trace hooks
+func WithSyncTrace(ctx context.Context, trace *SyncTrace) context.Context {
+	return context.WithValue(ctx, syncTraceKey{}, trace)
+}
+
+// ContextSyncTrace returns the trace of the context or an empty one
+func ContextSyncTrace(ctx context.Context) *SyncTrace {
+	if trace, ok := ctx.Value(syncTraceKey{}).(*SyncTrace); ok && trace != nil {
+		return trace
+	}
+	return &SyncTrace{}
+}
+
+type SyncEventType string
+
+const (
+	SyncEventTotalDiscovered SyncEventType = "total_discovered"
+	SyncEventPageFetched     SyncEventType = "page_fetched"
+	SyncEventPaused          SyncEventType = "rate_limit_paused"
+	SyncEventBatchCommitted  SyncEventType = "batch_committed"
+	SyncEventCompleted       SyncEventType = "completed"
+	SyncEventFailed          SyncEventType = "failed"
+)
+
+// SyncEvent is a progress event of a sync job. Only the fields of the event type are set.
+type SyncEvent struct {
+	JobID      int64
+	ProjectKey string
+	Time       time.Time
+	Type       SyncEventType
+	Page       int
+	Pages      int
+	Issues     int
+	IssuesDone int
+	RetryAfter time.Duration
+	Error      string
+	Canceled   bool
+}
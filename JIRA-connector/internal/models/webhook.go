@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type IssueEventType string
+
+const (
+	IssueCreated IssueEventType = "jira:issue_created"
+	IssueUpdated IssueEventType = "jira:issue_updated"
+	IssueDeleted IssueEventType = "jira:issue_deleted"
+)
+
+// IssueEvent is the payload of a Jira issue webhook
+type IssueEvent struct {
+	// Timestamp is in milliseconds since the epoch
+	Timestamp    int64          `json:"timestamp"`
+	WebhookEvent IssueEventType `json:"webhookEvent"`
+	User         JiraUser       `json:"user"`
+	Issue        JiraIssue      `json:"issue"`
+	// Changelog holds the changes made by this event only
+	Changelog struct {
+		ID    string `json:"id"`
+		Items []Item `json:"items"`
+	} `json:"changelog"`
+}
+
+// IssueWithChanges returns the issue with the changes of the event as its history.
+// The changes are dated by the issue updated time, as the same history entry is dated
+// in the changelog, so that a later sync does not duplicate them.
+func (e IssueEvent) IssueWithChanges() JiraIssue {
+	issue := e.Issue
+	if len(e.Changelog.Items) > 0 {
+		created := issue.Fields.Updated
+		if created.IsZero() && e.Timestamp != 0 {
+			created = JiraTime{Time: time.UnixMilli(e.Timestamp)}
+		}
+		issue.Changelogs.Histories = append(issue.Changelogs.Histories, History{
+			Created: created,
+			Author:  e.User,
+			Items:   e.Changelog.Items,
+		})
+	}
+	return issue
+}
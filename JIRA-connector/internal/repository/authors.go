@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+// authorKey identifies the user among the saved authors: by the Jira user id,
+// or by the display name for users Jira reports without one
+func authorKey(user models.JiraUser) string {
+	if id := user.ID(); id != "" {
+		return "id:" + id
+	}
+	return "name:" + user.DisplayName
+}
+
+// saveAuthors upserts the users and returns the author ids by authorKey. Users with a Jira id
+// get their current display name, so renaming a user in Jira does not create another author.
+func (p *ProjectRepository) saveAuthors(ctx context.Context, tx pgx.Tx,
+	users []models.JiraUser) (map[string]int, error) {
+
+	seen := make(map[string]struct{}, len(users))
+	var jiraIDs, jiraNames, names []string
+	for _, user := range users {
+		key := authorKey(user)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if id := user.ID(); id != "" {
+			jiraIDs = append(jiraIDs, id)
+			jiraNames = append(jiraNames, user.DisplayName)
+		} else {
+			names = append(names, user.DisplayName)
+		}
+	}
+
+	// authors saved before their Jira id was stored are matched by name
+	_, err := tx.Exec(ctx, `
+        UPDATE Author a SET jiraId = u.id
+        FROM unnest($2::text[], $3::text[]) AS u(id, name)
+        WHERE a.source = $1 AND a.jiraId IS NULL AND a.name = u.name
+            AND NOT EXISTS (SELECT 1 FROM Author WHERE source = $1 AND jiraId = u.id)
+    `, p.source, jiraIDs, jiraNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match authors by name: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO Author (source, jiraId, name)
+        SELECT $1, u.id, u.name FROM unnest($2::text[], $3::text[]) AS u(id, name)
+        ON CONFLICT (source, jiraId) DO UPDATE SET name = EXCLUDED.name
+    `, p.source, jiraIDs, jiraNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch insert authors: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO Author (source, name)
+        SELECT $1, unnest($2::text[])
+        ON CONFLICT (source, name) WHERE jiraId IS NULL DO NOTHING
+    `, p.source, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch insert authors: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+        SELECT id, jiraId, name FROM Author
+        WHERE source = $1 AND (jiraId = ANY($2) OR (jiraId IS NULL AND name = ANY($3)))
+    `, p.source, jiraIDs, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author IDs: %w", err)
+	}
+	defer rows.Close()
+
+	authorIDs := make(map[string]int, len(seen))
+	for rows.Next() {
+		var id int
+		var jiraID, name *string
+		if err := rows.Scan(&id, &jiraID, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan author ID: %w", err)
+		}
+		switch {
+		case jiraID != nil:
+			authorIDs["id:"+*jiraID] = id
+		case name != nil:
+			authorIDs["name:"+*name] = id
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get author IDs: %w", err)
+	}
+	return authorIDs, nil
+}
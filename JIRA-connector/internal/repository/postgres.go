@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/sssidkn/jira-connector/internal/models"
 	"github.com/sssidkn/jira-connector/pkg/logger"
@@ -13,9 +14,12 @@ import (
 
 type Project = models.JiraProject
 
+// ProjectRepository stores the projects of one Jira source. Repositories of other sources
+// share the pool and are made by ForSource.
 type ProjectRepository struct {
 	db     *pgxpool.Pool
 	logger logger.Logger
+	source string
 }
 
 func (p *ProjectRepository) SetLogger(logger logger.Logger) {
@@ -24,28 +28,48 @@ func (p *ProjectRepository) SetLogger(logger logger.Logger) {
 
 func NewProjectRepository(db *pgxpool.Pool) *ProjectRepository {
 	return &ProjectRepository{
-		db: db,
+		db:     db,
+		source: models.DefaultSource,
 	}
 }
 
+// ForSource returns a repository of the projects of the given source
+func (p *ProjectRepository) ForSource(source string) *ProjectRepository {
+	return &ProjectRepository{
+		db:     p.db,
+		logger: p.logger,
+		source: source,
+	}
+}
+
+// Source returns the id of the source the repository stores
+func (p *ProjectRepository) Source() string {
+	return p.source
+}
+
 func (p *ProjectRepository) GetProjectInfo(ctx context.Context, projectKey string) (*models.ProjectInfo, error) {
 	var exists bool
 	err := p.db.QueryRow(ctx,
 		`SELECT 
-        EXISTS(SELECT 1 FROM Projects WHERE key = $1)`,
-		projectKey,
+        EXISTS(SELECT 1 FROM Projects WHERE source = $1 AND key = $2)`,
+		p.source, projectKey,
 	).Scan(&exists)
 	if !exists {
 		return nil, nil
 	}
 	var pi = &models.ProjectInfo{}
+	var lastUpdate *time.Time
 	err = p.db.QueryRow(ctx,
-		`SELECT id, key, title, lastUpdate FROM Projects WHERE key = $1`,
-		projectKey,
-	).Scan(&pi.ID, &pi.Key, &pi.Name, &pi.LastUpdate)
+		`SELECT id, COALESCE(jiraId, ''), key, title, lastUpdate FROM Projects WHERE source = $1 AND key = $2`,
+		p.source, projectKey,
+	).Scan(&pi.ID, &pi.JiraID, &pi.Key, &pi.Name, &lastUpdate)
 	if err != nil {
 		return nil, err
 	}
+	// lastUpdate stays NULL until the first full sync completes
+	if lastUpdate != nil {
+		pi.LastUpdate = *lastUpdate
+	}
 	return pi, nil
 }
 
@@ -56,76 +80,221 @@ func (p *ProjectRepository) SaveProject(ctx context.Context, project Project) er
 	}
 	defer tx.Rollback(ctx)
 
+	if err = p.moveProject(ctx, tx, project); err != nil {
+		return err
+	}
+
+	var projectID string
+	err = tx.QueryRow(ctx, `
+        INSERT INTO Projects (source, jiraId, title, key, lastUpdate) 
+        VALUES ($1, $2, $3, $4, $5) 
+        ON CONFLICT (source, key) DO UPDATE SET
+            lastUpdate = EXCLUDED.lastUpdate,
+            jiraId = COALESCE(Projects.jiraId, EXCLUDED.jiraId)
+        RETURNING id::text
+    `, p.source, nullString(project.ID), project.Name, project.Key, project.LastUpdate).Scan(&projectID)
+	if err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+
+	if project.Versions != nil {
+		if err = p.saveVersions(ctx, tx, projectID, project.Versions); err != nil {
+			return err
+		}
+	}
+	if err = p.saveIssues(ctx, tx, projectID, project.Issues); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SaveProjectInfo creates or renames the project without touching lastUpdate.
+// The project gets its own id, project.ID is saved as the Jira one.
+func (p *ProjectRepository) SaveProjectInfo(ctx context.Context, project Project) error {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = p.moveProject(ctx, tx, project); err != nil {
+		return err
+	}
 	_, err = tx.Exec(ctx, `
-        INSERT INTO Projects (id, title, key, lastUpdate) 
+        INSERT INTO Projects (source, jiraId, title, key) 
         VALUES ($1, $2, $3, $4) 
-        ON CONFLICT (key) DO UPDATE SET lastUpdate = EXCLUDED.lastUpdate
-    `, project.ID, project.Name, project.Key, project.LastUpdate)
+        ON CONFLICT (source, key) DO UPDATE SET
+            title = EXCLUDED.title,
+            jiraId = COALESCE(Projects.jiraId, EXCLUDED.jiraId)
+    `, p.source, nullString(project.ID), project.Name, project.Key)
 	if err != nil {
 		return fmt.Errorf("failed to save project: %w", err)
 	}
+	return tx.Commit(ctx)
+}
 
-	authorSet := make(map[string]struct{})
-	var statusChanges []StatusChangeData
+// moveProject re-keys the project saved with the same Jira id under another key, e.g. before
+// its key was changed in Jira, so that it keeps its issues. It is left as is if the new key is taken.
+func (p *ProjectRepository) moveProject(ctx context.Context, tx pgx.Tx, project Project) error {
+	if project.ID == "" {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+        UPDATE Projects SET key = $3
+        WHERE source = $1 AND jiraId = $2 AND key <> $3
+            AND NOT EXISTS (SELECT 1 FROM Projects WHERE source = $1 AND key = $3)
+    `, p.source, project.ID, project.Key)
+	if err != nil {
+		return fmt.Errorf("failed to move project: %w", err)
+	}
+	return nil
+}
 
-	for _, issue := range project.Issues {
-		authorSet[issue.Fields.Creator.DisplayName] = struct{}{}
-		if issue.Fields.Assignee.DisplayName != "" {
-			authorSet[issue.Fields.Assignee.DisplayName] = struct{}{}
-		}
+// SetLastUpdate marks the project as synced up to lastUpdate and drops its sync checkpoint
+func (p *ProjectRepository) SetLastUpdate(ctx context.Context, projectKey string, lastUpdate time.Time) error {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-		for _, history := range issue.Changelogs.Histories {
-			authorSet[history.Author.DisplayName] = struct{}{}
-		}
+	_, err = tx.Exec(ctx, `UPDATE Projects SET lastUpdate = $3 WHERE source = $1 AND key = $2`,
+		p.source, projectKey, lastUpdate)
+	if err != nil {
+		return fmt.Errorf("failed to set project last update: %w", err)
 	}
+	_, err = tx.Exec(ctx, `DELETE FROM sync_checkpoints WHERE source = $1 AND projectKey = $2`,
+		p.source, projectKey)
+	if err != nil {
+		return fmt.Errorf("failed to delete sync checkpoint: %w", err)
+	}
+	return tx.Commit(ctx)
+}
 
-	authorNames := make([]string, 0, len(authorSet))
-	for name := range authorSet {
-		authorNames = append(authorNames, name)
+// GetCheckpoint returns the checkpoint of an unfinished project sync or nil if there is none
+func (p *ProjectRepository) GetCheckpoint(ctx context.Context, projectKey string) (*models.SyncCheckpoint, error) {
+	cp := &models.SyncCheckpoint{ProjectKey: projectKey}
+	var highWater *time.Time
+	err := p.db.QueryRow(ctx,
+		`SELECT jql, startedAt, pagesCompleted, highWater FROM sync_checkpoints
+        WHERE source = $1 AND projectKey = $2`,
+		p.source, projectKey,
+	).Scan(&cp.JQL, &cp.StartedAt, &cp.PagesCompleted, &highWater)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync checkpoint: %w", err)
 	}
+	if highWater != nil {
+		cp.HighWater = *highWater
+	}
+	return cp, nil
+}
 
-	_, err = tx.Exec(ctx, `
-        INSERT INTO Author (name) 
-        SELECT unnest($1::text[]) 
-        ON CONFLICT DO NOTHING
-    `, authorNames)
+// SaveCheckpoint records the progress of a project sync
+func (p *ProjectRepository) SaveCheckpoint(ctx context.Context, cp models.SyncCheckpoint) error {
+	_, err := p.db.Exec(ctx, `
+        INSERT INTO sync_checkpoints (source, projectKey, jql, startedAt, pagesCompleted, highWater, updatedAt)
+        VALUES ($1, $2, $3, $4, $5, $6, now())
+        ON CONFLICT (source, projectKey) DO UPDATE SET
+            jql = EXCLUDED.jql,
+            startedAt = EXCLUDED.startedAt,
+            pagesCompleted = EXCLUDED.pagesCompleted,
+            highWater = EXCLUDED.highWater,
+            updatedAt = EXCLUDED.updatedAt
+    `, p.source, cp.ProjectKey, cp.JQL, cp.StartedAt, cp.PagesCompleted, nullTime(cp.HighWater))
 	if err != nil {
-		return fmt.Errorf("failed to batch insert authors: %w", err)
+		return fmt.Errorf("failed to save sync checkpoint: %w", err)
 	}
+	return nil
+}
 
-	rows, err := tx.Query(ctx, `
-        SELECT name, id FROM Author 
-        WHERE name = ANY($1)
-    `, authorNames)
+// SaveIssues saves one page of issues in its own transaction
+func (p *ProjectRepository) SaveIssues(ctx context.Context, projectID string, issues []models.JiraIssue) error {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to get author IDs: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback(ctx)
 
-	authorIDs := make(map[string]int)
-	for rows.Next() {
-		var name string
-		var id int
-		if err := rows.Scan(&name, &id); err != nil {
-			return fmt.Errorf("failed to scan author ID: %w", err)
+	if err = p.saveIssues(ctx, tx, projectID, issues); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// MarkIssueDeleted marks the issue deleted, as the reconciliation does, keeping its changelog,
+// and reports whether it was not deleted yet
+func (p *ProjectRepository) MarkIssueDeleted(ctx context.Context, key string) (bool, error) {
+	tag, err := p.db.Exec(ctx, `
+        UPDATE Issue SET deletedAt = NOW()
+        WHERE source = $1 AND key = $2 AND deletedAt IS NULL
+    `, p.source, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark issue deleted: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (p *ProjectRepository) saveIssues(ctx context.Context, tx pgx.Tx, projectID string,
+	issues []models.JiraIssue) error {
+
+	var users []models.JiraUser
+	var statusChanges []StatusChangeData
+	var fieldChanges []FieldChangeData
+
+	for _, issue := range issues {
+		users = append(users, issue.Fields.Creator)
+		if issue.Fields.Assignee != (models.JiraUser{}) {
+			users = append(users, issue.Fields.Assignee)
 		}
-		authorIDs[name] = id
+
+		for _, history := range issue.Changelogs.Histories {
+			users = append(users, history.Author)
+		}
+		if issue.Fields.Worklog != nil {
+			for _, worklog := range issue.Fields.Worklog.Worklogs {
+				users = append(users, worklog.Author)
+			}
+		}
+		if issue.Fields.Comment != nil {
+			for _, comment := range issue.Fields.Comment.Comments {
+				users = append(users, comment.Author)
+			}
+		}
+	}
+
+	authorIDs, err := p.saveAuthors(ctx, tx, users)
+	if err != nil {
+		return err
+	}
+
+	// an issue moved in Jira keeps its id and history under the new key
+	refs := make([]models.IssueRef, 0, len(issues))
+	for _, issue := range issues {
+		refs = append(refs, models.IssueRef{ID: issue.ID, Key: issue.Key})
+	}
+	if _, err = p.moveIssues(ctx, tx, projectID, refs); err != nil {
+		return err
 	}
 
 	issueBatch := &pgx.Batch{}
-	issueKeys := make([]string, 0, len(project.Issues))
+	issueKeys := make([]string, 0, len(issues))
 	issueKeyToID := make(map[string]int)
 
-	for _, issue := range project.Issues {
+	for _, issue := range issues {
 		issueKeys = append(issueKeys, issue.Key)
 
 		issueBatch.Queue(`
             INSERT INTO Issue (
                 projectId, authorId, assigneeId, key, summary, description, 
-                type, priority, status, createdTime, closedTime, updatedTime, timeSpent
+                type, priority, status, createdTime, closedTime, updatedTime, timeSpent,
+                parentKey, epicKey, custom_fields, source, jiraId
             ) VALUES (
-                $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
-            ) ON CONFLICT (key) DO UPDATE SET
+                $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+            ) ON CONFLICT (source, key) DO UPDATE SET
                 summary = EXCLUDED.summary,
                 description = EXCLUDED.description,
                 type = EXCLUDED.type,
@@ -133,15 +302,20 @@ func (p *ProjectRepository) SaveProject(ctx context.Context, project Project) er
                 status = EXCLUDED.status,
                 updatedTime = EXCLUDED.updatedTime,
                 closedTime = EXCLUDED.closedTime,
-                timeSpent = EXCLUDED.timeSpent
+                timeSpent = EXCLUDED.timeSpent,
+                parentKey = EXCLUDED.parentKey,
+                epicKey = COALESCE(EXCLUDED.epicKey, Issue.epicKey),
+                custom_fields = COALESCE(EXCLUDED.custom_fields, Issue.custom_fields),
+                jiraId = COALESCE(EXCLUDED.jiraId, Issue.jiraId),
+                deletedAt = NULL
             RETURNING id, key
         `,
-			project.ID,
-			authorIDs[issue.Fields.Creator.DisplayName],
-			authorIDs[issue.Fields.Assignee.DisplayName],
+			projectID,
+			authorIDs[authorKey(issue.Fields.Creator)],
+			authorIDs[authorKey(issue.Fields.Assignee)],
 			issue.Key,
 			issue.Fields.Summary,
-			issue.Fields.Description,
+			issue.Fields.Description.Text,
 			issue.Fields.IssueType.Name,
 			issue.Fields.Priority.Name,
 			issue.Fields.Status.Name,
@@ -149,13 +323,24 @@ func (p *ProjectRepository) SaveProject(ctx context.Context, project Project) er
 			issue.Fields.Closed.Time,
 			issue.Fields.Updated.Time,
 			issue.Fields.Timetracking.TimeSpentSeconds,
+			parentKey(issue),
+			nullString(issue.Fields.EpicLink),
+			customFields(issue),
+			p.source,
+			nullString(issue.ID),
 		)
 
 		for _, history := range issue.Changelogs.Histories {
 			for _, item := range history.Items {
+				fieldChanges = append(fieldChanges, FieldChangeData{
+					IssueKey:   issue.Key,
+					AuthorKey:  authorKey(history.Author),
+					ChangeTime: history.Created.Time,
+					Item:       item,
+				})
 				if item.Field == "status" {
 					statusChanges = append(statusChanges, StatusChangeData{
-						AuthorName: history.Author.DisplayName,
+						AuthorKey:  authorKey(history.Author),
 						ChangeTime: history.Created.Time,
 						FromStatus: item.FromString,
 						ToStatus:   item.ToString,
@@ -169,7 +354,7 @@ func (p *ProjectRepository) SaveProject(ctx context.Context, project Project) er
 	br := tx.SendBatch(ctx, issueBatch)
 	defer br.Close()
 
-	for range project.Issues {
+	for range issues {
 		var id int
 		var key string
 		if err := br.QueryRow().Scan(&id, &key); err != nil {
@@ -191,7 +376,7 @@ func (p *ProjectRepository) SaveProject(ctx context.Context, project Project) er
                 ON CONFLICT DO NOTHING
             `,
 				issueKeyToID[sc.IssueKey],
-				authorIDs[sc.AuthorName],
+				authorIDs[sc.AuthorKey],
 				sc.ChangeTime,
 				sc.FromStatus,
 				sc.ToStatus,
@@ -204,17 +389,229 @@ func (p *ProjectRepository) SaveProject(ctx context.Context, project Project) er
 		}
 	}
 
-	return tx.Commit(ctx)
+	if len(fieldChanges) > 0 {
+		fieldChangeBatch := &pgx.Batch{}
+
+		for _, fc := range fieldChanges {
+			fieldChangeBatch.Queue(`
+                INSERT INTO FieldChanges (issueId, authorId, changeTime, field, fromId, fromString, toId, toString)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+                ON CONFLICT DO NOTHING
+            `,
+				issueKeyToID[fc.IssueKey],
+				authorIDs[fc.AuthorKey],
+				fc.ChangeTime,
+				fc.Item.Field,
+				fc.Item.From,
+				fc.Item.FromString,
+				fc.Item.To,
+				fc.Item.ToString,
+			)
+		}
+
+		fr := tx.SendBatch(ctx, fieldChangeBatch)
+		if err := fr.Close(); err != nil {
+			return fmt.Errorf("failed to save field changes: %w", err)
+		}
+	}
+
+	if err := saveWorklogs(ctx, tx, issues, issueKeyToID, authorIDs); err != nil {
+		return err
+	}
+	if err := saveComments(ctx, tx, issues, issueKeyToID, authorIDs); err != nil {
+		return err
+	}
+	if err := p.saveIssueLinks(ctx, tx, issues); err != nil {
+		return err
+	}
+	if err := p.saveIssueMetadata(ctx, tx, projectID, issues, issueKeyToID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// saveWorklogs upserts the worklogs of the issues. Worklogs deleted in Jira are deleted
+// only for the issues whose worklog list is complete.
+func saveWorklogs(ctx context.Context, tx pgx.Tx, issues []models.JiraIssue, issueKeyToID map[string]int,
+	authorIDs map[string]int) error {
+
+	batch := &pgx.Batch{}
+	for _, issue := range issues {
+		worklog := issue.Fields.Worklog
+		if worklog == nil {
+			continue
+		}
+		jiraIDs := make([]string, 0, len(worklog.Worklogs))
+		for _, w := range worklog.Worklogs {
+			jiraIDs = append(jiraIDs, w.ID)
+			batch.Queue(`
+                INSERT INTO Worklog (jiraId, issueId, authorId, started, timeSpentSeconds, comment)
+                VALUES ($1, $2, $3, $4, $5, $6)
+                ON CONFLICT (issueId, jiraId) DO UPDATE SET
+                    authorId = EXCLUDED.authorId,
+                    started = EXCLUDED.started,
+                    timeSpentSeconds = EXCLUDED.timeSpentSeconds,
+                    comment = EXCLUDED.comment
+            `,
+				w.ID,
+				issueKeyToID[issue.Key],
+				authorIDs[authorKey(w.Author)],
+				w.Started.Time,
+				w.TimeSpentSeconds,
+				w.Comment.Text,
+			)
+		}
+		if worklog.Complete() {
+			batch.Queue(`DELETE FROM Worklog WHERE issueId = $1 AND NOT jiraId = ANY($2)`,
+				issueKeyToID[issue.Key], jiraIDs)
+		}
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("failed to save worklogs: %w", err)
+	}
+	return nil
+}
+
+// saveComments upserts the comments of the issues. Comments deleted in Jira are deleted
+// only for the issues whose comment list is complete.
+func saveComments(ctx context.Context, tx pgx.Tx, issues []models.JiraIssue, issueKeyToID map[string]int,
+	authorIDs map[string]int) error {
+
+	batch := &pgx.Batch{}
+	for _, issue := range issues {
+		comment := issue.Fields.Comment
+		if comment == nil {
+			continue
+		}
+		jiraIDs := make([]string, 0, len(comment.Comments))
+		for _, c := range comment.Comments {
+			jiraIDs = append(jiraIDs, c.ID)
+			batch.Queue(`
+                INSERT INTO Comment (jiraId, issueId, authorId, createdTime, updatedTime, body)
+                VALUES ($1, $2, $3, $4, $5, $6)
+                ON CONFLICT (issueId, jiraId) DO UPDATE SET
+                    updatedTime = EXCLUDED.updatedTime,
+                    body = EXCLUDED.body
+            `,
+				c.ID,
+				issueKeyToID[issue.Key],
+				authorIDs[authorKey(c.Author)],
+				c.Created.Time,
+				c.Updated.Time,
+				c.Body.Text,
+			)
+		}
+		if comment.Complete() {
+			batch.Queue(`DELETE FROM Comment WHERE issueId = $1 AND NOT jiraId = ANY($2)`,
+				issueKeyToID[issue.Key], jiraIDs)
+		}
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("failed to save comments: %w", err)
+	}
+	return nil
+}
+
+// saveIssueLinks replaces the links of the issues and sets the parent of their sub-tasks,
+// which may have been saved before the parent
+func (p *ProjectRepository) saveIssueLinks(ctx context.Context, tx pgx.Tx, issues []models.JiraIssue) error {
+	batch := &pgx.Batch{}
+	for _, issue := range issues {
+		if len(issue.Fields.Subtasks) > 0 {
+			subtasks := make([]string, 0, len(issue.Fields.Subtasks))
+			for _, subtask := range issue.Fields.Subtasks {
+				subtasks = append(subtasks, subtask.Key)
+			}
+			batch.Queue(`UPDATE Issue SET parentKey = $2 WHERE source = $1 AND key = ANY($3)`,
+				p.source, issue.Key, subtasks)
+		}
+		if issue.Fields.IssueLinks == nil {
+			continue
+		}
+		linkIDs := make([]string, 0, len(issue.Fields.IssueLinks))
+		for _, link := range issue.Fields.IssueLinks {
+			source, target := link.Ends(issue.Key)
+			if source == "" {
+				continue
+			}
+			linkIDs = append(linkIDs, link.ID)
+			batch.Queue(`
+                INSERT INTO IssueLink (source, jiraId, type, inward, outward, sourceKey, targetKey)
+                VALUES ($1, $2, $3, $4, $5, $6, $7)
+                ON CONFLICT (source, jiraId) DO UPDATE SET
+                    type = EXCLUDED.type,
+                    inward = EXCLUDED.inward,
+                    outward = EXCLUDED.outward,
+                    sourceKey = EXCLUDED.sourceKey,
+                    targetKey = EXCLUDED.targetKey
+            `, p.source, link.ID, link.Type.Name, link.Type.Inward, link.Type.Outward, source, target)
+		}
+		batch.Queue(`
+            DELETE FROM IssueLink
+            WHERE source = $1 AND (sourceKey = $2 OR targetKey = $2) AND NOT jiraId = ANY($3)
+        `, p.source, issue.Key, linkIDs)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("failed to save issue links: %w", err)
+	}
+	return nil
+}
+
+func parentKey(issue models.JiraIssue) *string {
+	if issue.Fields.Parent == nil {
+		return nil
+	}
+	return nullString(issue.Fields.Parent.Key)
+}
+
+// customFields is nil for issues read without the field mapping, e.g. from webhooks,
+// so that their stored values are kept
+func customFields(issue models.JiraIssue) any {
+	if issue.Fields.CustomFields == nil {
+		return nil
+	}
+	return issue.Fields.CustomFields
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
 }
 
 type StatusChangeData struct {
 	IssueKey   string
-	AuthorName string
+	AuthorKey  string
 	ChangeTime time.Time
 	FromStatus string
 	ToStatus   string
 }
 
+// FieldChangeData is one changelog item of any field
+type FieldChangeData struct {
+	IssueKey   string
+	AuthorKey  string
+	ChangeTime time.Time
+	Item       models.Item
+}
+
 func (p *ProjectRepository) Close() error {
 	p.db.Close()
 	return nil
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+// moveIssueQuery re-keys the issue with the Jira id $2 saved under another key, e.g. before it was moved
+// to the project $4, along with the links, sub-tasks and epic issues referring to the old key.
+// The issue is left as is if the new key $3 is taken. It returns the number of moved issues.
+const moveIssueQuery = `
+    WITH moved AS (
+        UPDATE Issue i SET key = $3, projectId = $4, deletedAt = NULL
+        FROM Issue old
+        WHERE old.id = i.id AND i.source = $1 AND i.jiraId = $2 AND i.key <> $3
+            AND NOT EXISTS (SELECT 1 FROM Issue WHERE source = $1 AND key = $3)
+        RETURNING old.key
+    ), links AS (
+        UPDATE IssueLink SET
+            sourceKey = CASE WHEN sourceKey IN (SELECT key FROM moved) THEN $3 ELSE sourceKey END,
+            targetKey = CASE WHEN targetKey IN (SELECT key FROM moved) THEN $3 ELSE targetKey END
+        WHERE source = $1 AND (sourceKey IN (SELECT key FROM moved) OR targetKey IN (SELECT key FROM moved))
+    ), children AS (
+        UPDATE Issue SET
+            parentKey = CASE WHEN parentKey IN (SELECT key FROM moved) THEN $3 ELSE parentKey END,
+            epicKey = CASE WHEN epicKey IN (SELECT key FROM moved) THEN $3 ELSE epicKey END
+        WHERE source = $1 AND (parentKey IN (SELECT key FROM moved) OR epicKey IN (SELECT key FROM moved))
+    )
+    SELECT COUNT(*) FROM moved
+`
+
+// ReconcileIssues compares the issues of the project with the full list of its issues in Jira.
+// Issues saved under another key are moved to the project by their Jira id, issues missing
+// from the list are marked deleted and issues found in the list again are restored.
+func (p *ProjectRepository) ReconcileIssues(ctx context.Context, projectID string,
+	issues []models.IssueRef) (*models.Reconciliation, error) {
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]string, 0, len(issues))
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+		keys = append(keys, issue.Key)
+	}
+
+	// issues saved before their Jira id was stored are matched by key
+	_, err = tx.Exec(ctx, `
+        UPDATE Issue i SET jiraId = r.id
+        FROM unnest($2::text[], $3::text[]) AS r(id, key)
+        WHERE i.source = $1 AND i.key = r.key AND i.jiraId IS NULL
+            AND NOT EXISTS (SELECT 1 FROM Issue WHERE source = $1 AND jiraId = r.id)
+    `, p.source, ids, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match issues by key: %w", err)
+	}
+
+	var result models.Reconciliation
+	result.Moved, err = p.moveIssues(ctx, tx, projectID, issues)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE Issue SET deletedAt = NULL
+        WHERE source = $1 AND projectId = $2 AND jiraId = ANY($3) AND deletedAt IS NOT NULL
+    `, p.source, projectID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore issues: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+        UPDATE Issue SET deletedAt = NOW()
+        WHERE source = $1 AND projectId = $2 AND deletedAt IS NULL
+            AND (jiraId IS NULL OR jiraId <> ALL($3))
+    `, p.source, projectID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark deleted issues: %w", err)
+	}
+	result.Deleted = tag.RowsAffected()
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &result, nil
+}
+
+// moveIssues re-keys the issues saved under another key and returns the number of moved issues
+func (p *ProjectRepository) moveIssues(ctx context.Context, tx pgx.Tx, projectID string,
+	issues []models.IssueRef) (int64, error) {
+
+	batch := &pgx.Batch{}
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		batch.Queue(moveIssueQuery, p.source, issue.ID, issue.Key, projectID)
+	}
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	defer br.Close()
+
+	var moved int64
+	for range batch.Len() {
+		var n int64
+		if err := br.QueryRow().Scan(&n); err != nil {
+			return 0, fmt.Errorf("failed to move issues: %w", err)
+		}
+		moved += n
+	}
+	if err := br.Close(); err != nil {
+		return 0, fmt.Errorf("failed to move issues: %w", err)
+	}
+	return moved, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+// GetSyncSchedule returns the saved schedule of the source or nil if it was never saved
+func (p *ProjectRepository) GetSyncSchedule(ctx context.Context) (*models.SyncSchedule, error) {
+	var s models.SyncSchedule
+	var jitterSeconds int64
+	err := p.db.QueryRow(ctx,
+		`SELECT enabled, cron, jitterSeconds, maxConcurrent, updatedAt FROM sync_schedule WHERE source = $1`,
+		p.source,
+	).Scan(&s.Enabled, &s.Cron, &jitterSeconds, &s.MaxConcurrent, &s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync schedule: %w", err)
+	}
+	s.Jitter = time.Duration(jitterSeconds) * time.Second
+	return &s, nil
+}
+
+func (p *ProjectRepository) SaveSyncSchedule(ctx context.Context, s models.SyncSchedule) error {
+	_, err := p.db.Exec(ctx, `
+        INSERT INTO sync_schedule (source, enabled, cron, jitterSeconds, maxConcurrent, updatedAt)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (source) DO UPDATE SET
+            enabled = EXCLUDED.enabled,
+            cron = EXCLUDED.cron,
+            jitterSeconds = EXCLUDED.jitterSeconds,
+            maxConcurrent = EXCLUDED.maxConcurrent,
+            updatedAt = EXCLUDED.updatedAt
+    `, p.source, s.Enabled, s.Cron, int64(s.Jitter/time.Second), s.MaxConcurrent, s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save sync schedule: %w", err)
+	}
+	return nil
+}
+
+// GetProjectKeys returns the keys of all tracked projects of the source
+func (p *ProjectRepository) GetProjectKeys(ctx context.Context) ([]string, error) {
+	rows, err := p.db.Query(ctx, `SELECT key FROM Projects WHERE source = $1 ORDER BY key`, p.source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	return keys, nil
+}
+
+func (p *ProjectRepository) CreateScheduleRun(ctx context.Context, startedAt time.Time) (int64, error) {
+	var id int64
+	err := p.db.QueryRow(ctx,
+		`INSERT INTO schedule_runs (source, startedAt) VALUES ($1, $2) RETURNING id`, p.source, startedAt,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create schedule run: %w", err)
+	}
+	return id, nil
+}
+
+// FinishScheduleRun saves the end of the run together with the outcomes of its projects
+func (p *ProjectRepository) FinishScheduleRun(ctx context.Context, run models.ScheduleRun) error {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `UPDATE schedule_runs SET finishedAt = $2, error = $3 WHERE id = $1`,
+		run.ID, run.FinishedAt, run.Error)
+	if err != nil {
+		return fmt.Errorf("failed to finish schedule run: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, s := range run.Projects {
+		var jobID *int64
+		if s.JobID != 0 {
+			jobID = &s.JobID
+		}
+		batch.Queue(`
+            INSERT INTO scheduled_syncs (runId, projectKey, jobId, outcome, error)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (runId, projectKey) DO NOTHING
+        `, run.ID, s.ProjectKey, jobID, s.Outcome, s.Error)
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to save scheduled syncs: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListScheduleRuns returns the latest runs, newest first
+func (p *ProjectRepository) ListScheduleRuns(ctx context.Context, limit int) ([]models.ScheduleRun, error) {
+	rows, err := p.db.Query(ctx, `
+        SELECT r.id, r.startedAt, r.finishedAt, r.error, s.projectKey, s.jobId, s.outcome, s.error
+        FROM (SELECT * FROM schedule_runs WHERE source = $1 ORDER BY id DESC LIMIT $2) r
+        LEFT JOIN scheduled_syncs s ON s.runId = r.id
+        ORDER BY r.id DESC, s.projectKey
+    `, p.source, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]models.ScheduleRun, 0, limit)
+	for rows.Next() {
+		var run models.ScheduleRun
+		var finishedAt *time.Time
+		var projectKey, outcome, syncError *string
+		var jobID *int64
+		err = rows.Scan(&run.ID, &run.StartedAt, &finishedAt, &run.Error, &projectKey, &jobID, &outcome, &syncError)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule run: %w", err)
+		}
+		if len(runs) == 0 || runs[len(runs)-1].ID != run.ID {
+			if finishedAt != nil {
+				run.FinishedAt = *finishedAt
+			}
+			runs = append(runs, run)
+		}
+		if projectKey == nil {
+			continue
+		}
+		s := models.ScheduledSync{ProjectKey: *projectKey, Outcome: models.ScheduleOutcome(*outcome), Error: *syncError}
+		if jobID != nil {
+			s.JobID = *jobID
+		}
+		last := &runs[len(runs)-1]
+		last.Projects = append(last.Projects, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list schedule runs: %w", err)
+	}
+	return runs, nil
+}
+
+// FailUnfinishedScheduleRuns closes the runs left unfinished by a stopped connector
+func (p *ProjectRepository) FailUnfinishedScheduleRuns(ctx context.Context, reason string) (int64, error) {
+	tag, err := p.db.Exec(ctx,
+		`UPDATE schedule_runs SET finishedAt = now(), error = $2 WHERE source = $1 AND finishedAt IS NULL`,
+		p.source, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail unfinished schedule runs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+// SaveSprints replaces the boards of the project, upserts their sprints and replaces the issues
+// of every sprint. Sprint issues that are not saved yet are skipped.
+func (p *ProjectRepository) SaveSprints(ctx context.Context, sprints models.ProjectSprints) error {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var projectID int
+	err = tx.QueryRow(ctx, `SELECT id FROM Projects WHERE source = $1 AND key = $2`,
+		p.source, sprints.ProjectKey).Scan(&projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("project %s not found", sprints.ProjectKey)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue(`DELETE FROM ProjectBoard WHERE projectId = $1`, projectID)
+	for _, board := range sprints.Boards {
+		batch.Queue(`
+            INSERT INTO Board (source, id, name, type) VALUES ($1, $2, $3, $4)
+            ON CONFLICT (source, id) DO UPDATE SET name = EXCLUDED.name, type = EXCLUDED.type
+        `, p.source, board.ID, board.Name, board.Type)
+		batch.Queue(`
+            INSERT INTO ProjectBoard (projectId, source, boardId) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
+        `, projectID, p.source, board.ID)
+	}
+	for _, sprint := range sprints.Sprints {
+		batch.Queue(`
+            INSERT INTO Sprint (source, id, boardId, name, state, goal, startDate, endDate, completeDate)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            ON CONFLICT (source, id) DO UPDATE SET
+                boardId = EXCLUDED.boardId,
+                name = EXCLUDED.name,
+                state = EXCLUDED.state,
+                goal = EXCLUDED.goal,
+                startDate = EXCLUDED.startDate,
+                endDate = EXCLUDED.endDate,
+                completeDate = EXCLUDED.completeDate
+        `,
+			p.source,
+			sprint.ID,
+			sprint.BoardID,
+			sprint.Name,
+			sprint.State,
+			sprint.Goal,
+			nullTime(sprint.StartDate.Time),
+			nullTime(sprint.EndDate.Time),
+			nullTime(sprint.CompleteDate.Time),
+		)
+		batch.Queue(`DELETE FROM SprintIssue WHERE source = $1 AND sprintId = $2`, p.source, sprint.ID)
+		batch.Queue(`
+            INSERT INTO SprintIssue (source, sprintId, issueId)
+            SELECT $1, $2, id FROM Issue WHERE source = $1 AND key = ANY($3)
+            ON CONFLICT DO NOTHING
+        `, p.source, sprint.ID, sprint.IssueKeys)
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	if err = br.Close(); err != nil {
+		return fmt.Errorf("failed to save sprints: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// ListSprints returns the sprints of the project boards and the sprints with issues of the project,
+// ordered by start date with the future sprints last. IssueKeys are the project issues in the sprint.
+func (p *ProjectRepository) ListSprints(ctx context.Context, projectKey string) ([]models.Sprint, error) {
+	rows, err := p.db.Query(ctx, `
+        WITH project AS (SELECT id FROM Projects WHERE source = $1 AND key = $2)
+        SELECT s.id, s.boardId, s.name, s.state, s.goal, s.startDate, s.endDate, s.completeDate,
+               COALESCE(array_agg(i.key ORDER BY i.id) FILTER (WHERE i.key IS NOT NULL), '{}')
+        FROM Sprint s
+        LEFT JOIN SprintIssue si ON si.source = s.source AND si.sprintId = s.id
+        LEFT JOIN Issue i ON i.id = si.issueId AND i.projectId = (SELECT id FROM project) AND i.deletedAt IS NULL
+        WHERE s.source = $1
+          AND (s.boardId IN (SELECT boardId FROM ProjectBoard WHERE projectId = (SELECT id FROM project))
+           OR i.id IS NOT NULL)
+        GROUP BY s.source, s.id
+        ORDER BY s.startDate NULLS LAST, s.id`,
+		p.source, projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sprints: %w", err)
+	}
+	defer rows.Close()
+
+	sprints := make([]models.Sprint, 0)
+	for rows.Next() {
+		var sprint models.Sprint
+		var startDate, endDate, completeDate *time.Time
+		err = rows.Scan(&sprint.ID, &sprint.BoardID, &sprint.Name, &sprint.State, &sprint.Goal,
+			&startDate, &endDate, &completeDate, &sprint.IssueKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sprint: %w", err)
+		}
+		if startDate != nil {
+			sprint.StartDate.Time = *startDate
+		}
+		if endDate != nil {
+			sprint.EndDate.Time = *endDate
+		}
+		if completeDate != nil {
+			sprint.CompleteDate.Time = *completeDate
+		}
+		sprints = append(sprints, sprint)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sprints: %w", err)
+	}
+	return sprints, nil
+}
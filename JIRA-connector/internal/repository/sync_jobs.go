@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+const syncJobColumns = `id, projectKey, state, pagesDone, pagesTotal, issuesDone, issuesTotal, error,
+        createdAt, startedAt, finishedAt, updatedAt`
+
+// CreateSyncJob enqueues a sync of the project. If the project already has an active job,
+// that job is returned and created is false.
+func (p *ProjectRepository) CreateSyncJob(ctx context.Context, projectKey string) (*models.SyncJob, bool, error) {
+	job, err := scanSyncJob(p.db.QueryRow(ctx, `
+        INSERT INTO sync_jobs (source, projectKey, state)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (source, projectKey) WHERE state IN ('queued', 'running') DO NOTHING
+        RETURNING `+syncJobColumns,
+		p.source, projectKey, models.SyncJobQueued))
+	if err == nil {
+		return job, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to create sync job: %w", err)
+	}
+
+	job, err = scanSyncJob(p.db.QueryRow(ctx, `
+        SELECT `+syncJobColumns+` FROM sync_jobs
+        WHERE source = $1 AND projectKey = $2 AND state IN ('queued', 'running')`,
+		p.source, projectKey))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get active sync job: %w", err)
+	}
+	return job, false, nil
+}
+
+// GetSyncJob returns the job or nil if the source has no such job
+func (p *ProjectRepository) GetSyncJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	job, err := scanSyncJob(p.db.QueryRow(ctx,
+		`SELECT `+syncJobColumns+` FROM sync_jobs WHERE source = $1 AND id = $2`, p.source, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync job: %w", err)
+	}
+	return job, nil
+}
+
+// UpdateSyncJob saves the job state and progress
+func (p *ProjectRepository) UpdateSyncJob(ctx context.Context, job models.SyncJob) error {
+	_, err := p.db.Exec(ctx, `
+        UPDATE sync_jobs SET
+            state = $2,
+            pagesDone = $3,
+            pagesTotal = $4,
+            issuesDone = $5,
+            issuesTotal = $6,
+            error = $7,
+            startedAt = $8,
+            finishedAt = $9,
+            updatedAt = now()
+        WHERE id = $1
+    `, job.ID, job.State, job.PagesDone, job.PagesTotal, job.IssuesDone, job.IssuesTotal, job.Error,
+		nullTime(job.StartedAt), nullTime(job.FinishedAt))
+	if err != nil {
+		return fmt.Errorf("failed to update sync job: %w", err)
+	}
+	return nil
+}
+
+// TouchSyncJob records that the active job is still running
+func (p *ProjectRepository) TouchSyncJob(ctx context.Context, id int64) error {
+	_, err := p.db.Exec(ctx, `
+        UPDATE sync_jobs SET updatedAt = now()
+        WHERE source = $1 AND id = $2 AND state IN ('queued', 'running')
+    `, p.source, id)
+	if err != nil {
+		return fmt.Errorf("failed to touch sync job: %w", err)
+	}
+	return nil
+}
+
+// CancelStaleSyncJob cancels the active job if it was not updated for staleAfter
+// and reports whether it was canceled
+func (p *ProjectRepository) CancelStaleSyncJob(ctx context.Context, id int64, staleAfter time.Duration) (bool, error) {
+	tag, err := p.db.Exec(ctx, `
+        UPDATE sync_jobs SET state = $3, finishedAt = now(), updatedAt = now()
+        WHERE source = $1 AND id = $2 AND state IN ('queued', 'running')
+            AND updatedAt < now() - make_interval(secs => $4)
+    `, p.source, id, models.SyncJobCanceled, staleAfter.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel stale sync job: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// FailActiveSyncJobs fails the jobs left queued or running by a stopped connector
+func (p *ProjectRepository) FailActiveSyncJobs(ctx context.Context, reason string) (int64, error) {
+	tag, err := p.db.Exec(ctx, `
+        UPDATE sync_jobs SET state = $2, error = $3, finishedAt = now(), updatedAt = now()
+        WHERE source = $1 AND state IN ('queued', 'running')
+    `, p.source, models.SyncJobFailed, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail active sync jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanSyncJob(row pgx.Row) (*models.SyncJob, error) {
+	var job models.SyncJob
+	var startedAt, finishedAt *time.Time
+	err := row.Scan(&job.ID, &job.ProjectKey, &job.State, &job.PagesDone, &job.PagesTotal,
+		&job.IssuesDone, &job.IssuesTotal, &job.Error, &job.CreatedAt, &startedAt, &finishedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startedAt != nil {
+		job.StartedAt = *startedAt
+	}
+	if finishedAt != nil {
+		job.FinishedAt = *finishedAt
+	}
+	return &job, nil
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
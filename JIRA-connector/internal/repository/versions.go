@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+const (
+	versionKindFix      = "fix"
+	versionKindAffected = "affected"
+)
+
+// SaveVersions upserts the versions of the project and deletes the versions removed in Jira
+func (p *ProjectRepository) SaveVersions(ctx context.Context, projectKey string, versions []models.Version) error {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var projectID string
+	err = tx.QueryRow(ctx, `SELECT id::text FROM Projects WHERE source = $1 AND key = $2`,
+		p.source, projectKey).Scan(&projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("project %s not found", projectKey)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if err = p.saveVersions(ctx, tx, projectID, versions); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (p *ProjectRepository) saveVersions(ctx context.Context, tx pgx.Tx, projectID string,
+	versions []models.Version) error {
+
+	batch := &pgx.Batch{}
+	ids := make([]string, 0, len(versions))
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+		batch.Queue(`
+            INSERT INTO Version (source, id, projectId, name, description, archived, released, startDate, releaseDate)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            ON CONFLICT (source, id) DO UPDATE SET
+                projectId = EXCLUDED.projectId,
+                name = EXCLUDED.name,
+                description = EXCLUDED.description,
+                archived = EXCLUDED.archived,
+                released = EXCLUDED.released,
+                startDate = EXCLUDED.startDate,
+                releaseDate = EXCLUDED.releaseDate
+        `,
+			p.source,
+			v.ID,
+			projectID,
+			v.Name,
+			v.Description,
+			v.Archived,
+			v.Released,
+			nullTime(v.StartDate.Time),
+			nullTime(v.ReleaseDate.Time),
+		)
+	}
+	batch.Queue(`DELETE FROM Version WHERE projectId = $1 AND NOT id = ANY($2)`, projectID, ids)
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("failed to save versions: %w", err)
+	}
+	return nil
+}
+
+// saveIssueMetadata replaces the components, labels, fix and affected versions of the issues.
+// Components and versions are upserted from the issues too, as the project versions
+// may be synced after them. Fields that were not requested are left as they are.
+func (p *ProjectRepository) saveIssueMetadata(ctx context.Context, tx pgx.Tx, projectID string,
+	issues []models.JiraIssue, issueKeyToID map[string]int) error {
+
+	batch := &pgx.Batch{}
+	for _, issue := range issues {
+		issueID := issueKeyToID[issue.Key]
+		if issue.Fields.Components != nil {
+			ids := make([]string, 0, len(issue.Fields.Components))
+			for _, c := range issue.Fields.Components {
+				ids = append(ids, c.ID)
+				batch.Queue(`
+                    INSERT INTO Component (source, id, projectId, name) VALUES ($1, $2, $3, $4)
+                    ON CONFLICT (source, id) DO UPDATE SET name = EXCLUDED.name
+                `, p.source, c.ID, projectID, c.Name)
+			}
+			batch.Queue(`DELETE FROM IssueComponent WHERE issueId = $1`, issueID)
+			batch.Queue(`
+                INSERT INTO IssueComponent (issueId, source, componentId)
+                SELECT $1, $2, unnest($3::text[])
+                ON CONFLICT DO NOTHING
+            `, issueID, p.source, ids)
+		}
+		if issue.Fields.Labels != nil {
+			batch.Queue(`DELETE FROM IssueLabel WHERE issueId = $1`, issueID)
+			batch.Queue(`
+                INSERT INTO IssueLabel (issueId, label)
+                SELECT $1, unnest($2::text[])
+                ON CONFLICT DO NOTHING
+            `, issueID, issue.Fields.Labels)
+		}
+		p.queueIssueVersions(batch, projectID, issueID, versionKindFix, issue.Fields.FixVersions)
+		p.queueIssueVersions(batch, projectID, issueID, versionKindAffected, issue.Fields.Versions)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("failed to save components, labels and versions: %w", err)
+	}
+	return nil
+}
+
+// queueIssueVersions replaces the versions of one kind of the issue unless the field was not requested.
+// Issues embed versions without the start date and often without the description, so those are kept.
+func (p *ProjectRepository) queueIssueVersions(batch *pgx.Batch, projectID string, issueID int, kind string,
+	versions []models.Version) {
+
+	if versions == nil {
+		return
+	}
+	ids := make([]string, 0, len(versions))
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+		batch.Queue(`
+            INSERT INTO Version (source, id, projectId, name, archived, released, releaseDate)
+            VALUES ($1, $2, $3, $4, $5, $6, $7)
+            ON CONFLICT (source, id) DO UPDATE SET
+                name = EXCLUDED.name,
+                archived = EXCLUDED.archived,
+                released = EXCLUDED.released,
+                releaseDate = EXCLUDED.releaseDate
+        `, p.source, v.ID, projectID, v.Name, v.Archived, v.Released, nullTime(v.ReleaseDate.Time))
+	}
+	batch.Queue(`DELETE FROM IssueVersion WHERE issueId = $1 AND kind = $2`, issueID, kind)
+	batch.Queue(`
+        INSERT INTO IssueVersion (issueId, source, versionId, kind)
+        SELECT $1, $2, unnest($3::text[]), $4
+        ON CONFLICT DO NOTHING
+    `, issueID, p.source, ids, kind)
+}
@@ -2,22 +2,41 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/adf"
 	connectorApi "github.com/sssidkn/jira-connector/pkg/api/connector"
 	"github.com/sssidkn/jira-connector/pkg/logger"
 	"strings"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
+const defaultPageBuffer = 4
+
+// ErrNoCheckpoint is returned by ResumeSync when the project has no unfinished sync
+var ErrNoCheckpoint = errors.New("no unfinished sync")
+
+// ErrSyncActive is returned by the syncs waiting for their result while another job syncs the project
+var ErrSyncActive = errors.New("project sync is already active")
+
 type JiraConnector struct {
-	repo      Repository
-	apiClient APIClient
-	logger    logger.Logger
+	repo       Repository
+	apiClient  APIClient
+	agile      AgileClient
+	logger     logger.Logger
+	pageBuffer int
+	jobs       *jobRunner
+	schedule   *scheduler
+	// descriptionFormat renders the ADF documents of webhook issues like the API client renders synced ones
+	descriptionFormat adf.Format
 }
 
 func NewJiraConnector(opts ...Option) (*JiraConnector, error) {
-	jc := &JiraConnector{}
+	jc := &JiraConnector{pageBuffer: defaultPageBuffer, jobs: newJobRunner(), schedule: newScheduler(),
+		descriptionFormat: adf.FormatText}
 	var err error
 	for _, opt := range opts {
 		err = opt(jc)
@@ -33,15 +52,42 @@ type Project = models.JiraProject
 type Option = func(*JiraConnector) error
 
 type Repository interface {
-	SaveProject(ctx context.Context, project Project) error
 	GetProjectInfo(ctx context.Context, projectKey string) (*models.ProjectInfo, error)
+	SaveProject(ctx context.Context, project Project) error
+	SaveProjectInfo(ctx context.Context, project Project) error
+	SaveIssues(ctx context.Context, projectID string, issues []models.JiraIssue) error
+	MarkIssueDeleted(ctx context.Context, key string) (bool, error)
+	SetLastUpdate(ctx context.Context, projectKey string, lastUpdate time.Time) error
+	GetCheckpoint(ctx context.Context, projectKey string) (*models.SyncCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, cp models.SyncCheckpoint) error
+	CreateSyncJob(ctx context.Context, projectKey string) (*models.SyncJob, bool, error)
+	GetSyncJob(ctx context.Context, id int64) (*models.SyncJob, error)
+	UpdateSyncJob(ctx context.Context, job models.SyncJob) error
+	TouchSyncJob(ctx context.Context, id int64) error
+	CancelStaleSyncJob(ctx context.Context, id int64, staleAfter time.Duration) (bool, error)
+	FailActiveSyncJobs(ctx context.Context, reason string) (int64, error)
+	GetProjectKeys(ctx context.Context) ([]string, error)
+	GetSyncSchedule(ctx context.Context) (*models.SyncSchedule, error)
+	SaveSyncSchedule(ctx context.Context, schedule models.SyncSchedule) error
+	CreateScheduleRun(ctx context.Context, startedAt time.Time) (int64, error)
+	FinishScheduleRun(ctx context.Context, run models.ScheduleRun) error
+	ListScheduleRuns(ctx context.Context, limit int) ([]models.ScheduleRun, error)
+	FailUnfinishedScheduleRuns(ctx context.Context, reason string) (int64, error)
+	SaveSprints(ctx context.Context, sprints models.ProjectSprints) error
+	ListSprints(ctx context.Context, projectKey string) ([]models.Sprint, error)
+	SaveVersions(ctx context.Context, projectKey string, versions []models.Version) error
+	ReconcileIssues(ctx context.Context, projectID string, issues []models.IssueRef) (*models.Reconciliation, error)
 }
 
 type APIClient interface {
-	UpdateProject(ctx context.Context, projectKey string, lastUpdate time.Time) (*[]models.JiraIssue, error)
-	GetProject(ctx context.Context, projectKey string) (*Project, error)
+	GetProjectInfo(ctx context.Context, projectKey string) (*Project, error)
+	GetProjectVersions(ctx context.Context, projectKey string) ([]models.Version, error)
+	IssuesJQL(projectKey string, lastUpdate time.Time) string
+	StreamIssues(ctx context.Context, jql string, from time.Time, pages chan<- models.IssuePage) error
+	ListIssueRefs(ctx context.Context, projectKey string) ([]models.IssueRef, error)
 	GetProjects(ctx context.Context, limit, page int, search string) ([]models.ProjectInfo, error)
 	GetBaseURL() string
+	Health() models.JiraHealth
 }
 
 func WithLogger(log logger.Logger) Option {
@@ -75,6 +121,26 @@ func WithAPIClient(apiClient APIClient) Option {
 	}
 }
 
+// WithDescriptionFormat sets how ADF descriptions, comments and worklogs of webhook issues are rendered,
+// "text" by default. It should match the DescriptionFormat of the API client.
+func WithDescriptionFormat(format adf.Format) Option {
+	return func(jc *JiraConnector) error {
+		jc.descriptionFormat = format
+		return nil
+	}
+}
+
+// WithPageBuffer limits how many fetched pages may wait for the database writer
+func WithPageBuffer(size int) Option {
+	return func(jc *JiraConnector) error {
+		if size <= 0 {
+			return fmt.Errorf("page buffer must be positive")
+		}
+		jc.pageBuffer = size
+		return nil
+	}
+}
+
 func (jc *JiraConnector) GetProjects(ctx context.Context, limit, page int, search string) (*connectorApi.GetProjectsResponse, error) {
 	projects, err := jc.apiClient.GetProjects(ctx, limit, page, search)
 	if err != nil {
@@ -115,48 +181,215 @@ func (jc *JiraConnector) GetProjects(ctx context.Context, limit, page int, searc
 		}}, nil
 }
 
+// Health returns the state of the circuit breaker around the Jira API
+func (jc *JiraConnector) Health(_ context.Context) models.JiraHealth {
+	return jc.apiClient.Health()
+}
+
+// UpdateProject syncs the project issues updated since the last sync. An unfinished sync of the project
+// is resumed from its checkpoint instead. The sync runs as a job and waits for it, so it fails with
+// ErrSyncActive while another job syncs the project. The job goes on if ctx is canceled.
 func (jc *JiraConnector) UpdateProject(ctx context.Context, projectKey string) (*Project, error) {
+	job, rj, err := jc.startSync(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	if rj == nil {
+		return nil, fmt.Errorf("%w: job %d", ErrSyncActive, job.ID)
+	}
+	select {
+	case <-rj.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return rj.project, rj.err
+}
+
+// progressFunc is called by the writer after every saved page with the number of issues saved so far
+type progressFunc func(page models.IssuePage, saved int) error
+
+func (jc *JiraConnector) syncProject(ctx context.Context, projectKey string, progress progressFunc) (*Project, error) {
 	jc.logger.Debug("Updating project", logger.Field{Key: "project_key", Value: projectKey})
 	projectInfo, err := jc.repo.GetProjectInfo(ctx, projectKey)
 	if err != nil {
 		return nil, err
 	}
-	updateTime := time.Now()
 	var project *Project
+	var lastUpdate time.Time
 	if projectInfo == nil {
 		jc.logger.Info("Project not found in DB", logger.Field{Key: "project_key", Value: projectKey})
 		jc.logger.Info("Fetching project from JIRA", logger.Field{Key: "project_key", Value: projectKey})
-		project, err = jc.apiClient.GetProject(ctx, projectKey)
+		project, err = jc.apiClient.GetProjectInfo(ctx, projectKey)
 		if err != nil {
 			return nil, err
 		}
-		project.LastUpdate = updateTime
-	} else {
-		jc.logger.Info("Project found in DB", logger.Field{Key: "project_key", Value: projectKey})
-		jc.logger.Info("Fetching project from JIRA", logger.Field{Key: "project_key", Value: projectKey})
-		issues, err := jc.apiClient.UpdateProject(ctx, projectKey, projectInfo.LastUpdate)
+		if err = jc.repo.SaveProjectInfo(ctx, *project); err != nil {
+			return nil, err
+		}
+		// the project gets its own id in the database, ids of different sources may collide
+		projectInfo, err = jc.repo.GetProjectInfo(ctx, projectKey)
 		if err != nil {
 			return nil, err
 		}
-		project = &Project{
-			ID:         projectInfo.ID,
-			Key:        projectKey,
-			Name:       projectInfo.Name,
-			Self:       jc.apiClient.GetBaseURL() + "/projects/" + projectInfo.Key,
-			Issues:     *issues,
-			LastUpdate: updateTime,
+		if projectInfo == nil {
+			return nil, fmt.Errorf("project %s not found", projectKey)
+		}
+	} else {
+		jc.logger.Info("Project found in DB", logger.Field{Key: "project_key", Value: projectKey})
+		jc.logger.Info("Fetching project from JIRA", logger.Field{Key: "project_key", Value: projectKey})
+		project = jc.projectFromInfo(projectInfo)
+		lastUpdate = projectInfo.LastUpdate
+	}
+
+	cp, err := jc.repo.GetCheckpoint(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	if cp == nil {
+		cp = &models.SyncCheckpoint{
+			ProjectKey: projectKey,
+			JQL:        jc.apiClient.IssuesJQL(projectKey, lastUpdate),
+			StartedAt:  time.Now(),
 		}
-		if len(*issues) == 0 {
-			jc.logger.Info("No new issues found", logger.Field{Key: "project_key", Value: projectKey})
-			return project, nil
+		if err = jc.repo.SaveCheckpoint(ctx, *cp); err != nil {
+			return nil, err
 		}
+	} else {
+		jc.logResume(cp)
 	}
-	jc.logger.Info("Saving project to DB", logger.Field{Key: "project_key", Value: projectKey})
-	err = jc.repo.SaveProject(ctx, *project)
+	return jc.runSync(ctx, projectInfo.ID, project, cp, progress)
+}
+
+// ResumeSync continues an unfinished project sync from the last page it committed.
+// It returns ErrNoCheckpoint if the project has no unfinished sync.
+func (jc *JiraConnector) ResumeSync(ctx context.Context, projectKey string) (*Project, error) {
+	cp, err := jc.repo.GetCheckpoint(ctx, projectKey)
 	if err != nil {
-		jc.logger.Error("Failed to save project to DB", logger.Field{Key: "project_key", Value: projectKey})
 		return nil, err
 	}
-	jc.logger.Info("Project saved to DB", logger.Field{Key: "project_key", Value: projectKey})
+	if cp == nil {
+		return nil, ErrNoCheckpoint
+	}
+	// a project with a checkpoint is synced from it
+	return jc.UpdateProject(ctx, projectKey)
+}
+
+// projectFromInfo returns the saved project as it is in Jira, with the Jira project id
+func (jc *JiraConnector) projectFromInfo(projectInfo *models.ProjectInfo) *Project {
+	return &Project{
+		ID:   projectInfo.JiraID,
+		Key:  projectInfo.Key,
+		Name: projectInfo.Name,
+		Self: jc.apiClient.GetBaseURL() + "/projects/" + projectInfo.Key,
+	}
+}
+
+func (jc *JiraConnector) logResume(cp *models.SyncCheckpoint) {
+	jc.logger.Info("Resuming unfinished sync", logger.Field{Key: "project_key", Value: cp.ProjectKey},
+		logger.Field{Key: "pages_completed", Value: cp.PagesCompleted},
+		logger.Field{Key: "high_water", Value: cp.HighWater})
+}
+
+// runSync streams the checkpoint query and marks the project synced up to the checkpoint start
+// once every page is saved, then reconciles the project issues and syncs its versions and sprints.
+// projectID is the database id of the project.
+func (jc *JiraConnector) runSync(ctx context.Context, projectID string, project *Project,
+	cp *models.SyncCheckpoint, progress progressFunc) (*Project, error) {
+
+	saved, err := jc.syncIssues(ctx, projectID, cp, progress)
+	if err != nil {
+		jc.logger.Error("Failed to sync project issues", logger.Field{Key: "project_key", Value: project.Key},
+			logger.Field{Key: "saved", Value: saved},
+			logger.Field{Key: "pages_completed", Value: cp.PagesCompleted})
+		return nil, err
+	}
+	project.TotalIssueCount = saved
+	if saved == 0 {
+		jc.logger.Info("No new issues found", logger.Field{Key: "project_key", Value: project.Key})
+	}
+
+	if err = jc.repo.SetLastUpdate(ctx, project.Key, cp.StartedAt); err != nil {
+		return nil, err
+	}
+	project.LastUpdate = cp.StartedAt
+	jc.logger.Info("Project saved to DB", logger.Field{Key: "project_key", Value: project.Key},
+		logger.Field{Key: "saved", Value: saved})
+
+	jc.reconcileIssues(ctx, projectID, project.Key)
+	jc.syncVersions(ctx, project.Key)
+	// sprint membership refers to the saved issues
+	jc.syncSprints(ctx, project.Key)
 	return project, nil
 }
+
+// syncIssues streams pages fetched from Jira through a bounded channel to the writer, which commits
+// them one by one and advances cp. It returns the number of saved issues.
+func (jc *JiraConnector) syncIssues(ctx context.Context, projectID string, cp *models.SyncCheckpoint,
+	progress progressFunc) (int, error) {
+
+	pages := make(chan models.IssuePage, jc.pageBuffer)
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		defer close(pages)
+		return jc.apiClient.StreamIssues(gCtx, cp.JQL, cp.HighWater, pages)
+	})
+
+	var saved int
+	g.Go(func() error {
+		var err error
+		saved, err = jc.writeIssues(gCtx, projectID, cp, pages, progress)
+		return err
+	})
+
+	err := g.Wait()
+	return saved, err
+}
+
+// writeIssues saves pages until the channel is closed. Pages arrive in the order they were fetched,
+// so the checkpoint only moves past a page once every page before it is saved too.
+func (jc *JiraConnector) writeIssues(ctx context.Context, projectID string, cp *models.SyncCheckpoint,
+	pages <-chan models.IssuePage, progress progressFunc) (int, error) {
+
+	next := 0
+	done := make(map[int]time.Time)
+	saved := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return saved, ctx.Err()
+		case page, ok := <-pages:
+			if !ok {
+				return saved, nil
+			}
+			if len(page.Issues) > 0 {
+				if err := jc.repo.SaveIssues(ctx, projectID, page.Issues); err != nil {
+					return saved, err
+				}
+				saved += len(page.Issues)
+			}
+
+			done[page.Number] = page.HighWater()
+			advanced := false
+			for hw, ok := done[next]; ok; hw, ok = done[next] {
+				delete(done, next)
+				if hw.After(cp.HighWater) {
+					cp.HighWater = hw
+				}
+				cp.PagesCompleted++
+				next++
+				advanced = true
+			}
+			if advanced {
+				if err := jc.repo.SaveCheckpoint(ctx, *cp); err != nil {
+					return saved, err
+				}
+			}
+			if progress != nil {
+				if err := progress(page, saved); err != nil {
+					return saved, err
+				}
+			}
+		}
+	}
+}
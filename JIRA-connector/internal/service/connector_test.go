@@ -3,8 +3,14 @@ package connector
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/sssidkn/jira-connector/internal/jira"
 	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/jiratest"
 	"github.com/sssidkn/jira-connector/pkg/logger"
+	"net/http"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,17 +24,157 @@ type MockRepository struct {
 	mock.Mock
 }
 
+func (m *MockRepository) GetProjectInfo(ctx context.Context, projectKey string) (*models.ProjectInfo, error) {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProjectInfo), args.Error(1)
+}
+
 func (m *MockRepository) SaveProject(ctx context.Context, project models.JiraProject) error {
 	args := m.Called(ctx, project)
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetProjectInfo(ctx context.Context, projectKey string) (*models.ProjectInfo, error) {
+func (m *MockRepository) SaveProjectInfo(ctx context.Context, project models.JiraProject) error {
+	args := m.Called(ctx, project)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveIssues(ctx context.Context, projectID string, issues []models.JiraIssue) error {
+	args := m.Called(ctx, projectID, issues)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetCheckpoint(ctx context.Context, projectKey string) (*models.SyncCheckpoint, error) {
 	args := m.Called(ctx, projectKey)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*models.ProjectInfo), args.Error(1)
+	return args.Get(0).(*models.SyncCheckpoint), args.Error(1)
+}
+
+func (m *MockRepository) SaveCheckpoint(ctx context.Context, cp models.SyncCheckpoint) error {
+	args := m.Called(ctx, cp)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetLastUpdate(ctx context.Context, projectKey string, lastUpdate time.Time) error {
+	args := m.Called(ctx, projectKey, lastUpdate)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateSyncJob(ctx context.Context, projectKey string) (*models.SyncJob, bool, error) {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*models.SyncJob), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRepository) GetSyncJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncJob), args.Error(1)
+}
+
+func (m *MockRepository) UpdateSyncJob(ctx context.Context, job models.SyncJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockRepository) MarkIssueDeleted(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) TouchSyncJob(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CancelStaleSyncJob(ctx context.Context, id int64, staleAfter time.Duration) (bool, error) {
+	args := m.Called(ctx, id, staleAfter)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) FailActiveSyncJobs(ctx context.Context, reason string) (int64, error) {
+	args := m.Called(ctx, reason)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetProjectKeys(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRepository) GetSyncSchedule(ctx context.Context) (*models.SyncSchedule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncSchedule), args.Error(1)
+}
+
+func (m *MockRepository) SaveSyncSchedule(ctx context.Context, schedule models.SyncSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateScheduleRun(ctx context.Context, startedAt time.Time) (int64, error) {
+	args := m.Called(ctx, startedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) FinishScheduleRun(ctx context.Context, run models.ScheduleRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListScheduleRuns(ctx context.Context, limit int) ([]models.ScheduleRun, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ScheduleRun), args.Error(1)
+}
+
+func (m *MockRepository) FailUnfinishedScheduleRuns(ctx context.Context, reason string) (int64, error) {
+	args := m.Called(ctx, reason)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) SaveSprints(ctx context.Context, sprints models.ProjectSprints) error {
+	args := m.Called(ctx, sprints)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveVersions(ctx context.Context, projectKey string, versions []models.Version) error {
+	args := m.Called(ctx, projectKey, versions)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ReconcileIssues(ctx context.Context, projectID string,
+	issues []models.IssueRef) (*models.Reconciliation, error) {
+	args := m.Called(ctx, projectID, issues)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reconciliation), args.Error(1)
+}
+
+func (m *MockRepository) ListSprints(ctx context.Context, projectKey string) ([]models.Sprint, error) {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Sprint), args.Error(1)
 }
 
 // MockAPIClient мок для APIClient
@@ -36,20 +182,49 @@ type MockAPIClient struct {
 	mock.Mock
 }
 
-func (m *MockAPIClient) UpdateProject(ctx context.Context, projectKey string, lastUpdate time.Time) (*[]models.JiraIssue, error) {
-	args := m.Called(ctx, projectKey, lastUpdate)
+func (m *MockAPIClient) GetProjectInfo(ctx context.Context, projectKey string) (*models.JiraProject, error) {
+	args := m.Called(ctx, projectKey)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*[]models.JiraIssue), args.Error(1)
+	return args.Get(0).(*models.JiraProject), args.Error(1)
 }
 
-func (m *MockAPIClient) GetProject(ctx context.Context, projectKey string) (*models.JiraProject, error) {
+func (m *MockAPIClient) GetProjectVersions(ctx context.Context, projectKey string) ([]models.Version, error) {
 	args := m.Called(ctx, projectKey)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*models.JiraProject), args.Error(1)
+	return args.Get(0).([]models.Version), args.Error(1)
+}
+
+func (m *MockAPIClient) IssuesJQL(projectKey string, lastUpdate time.Time) string {
+	args := m.Called(projectKey, lastUpdate)
+	return args.String(0)
+}
+
+// StreamIssues отправляет в канал заданные страницы и затем возвращает заданную ошибку
+func (m *MockAPIClient) StreamIssues(ctx context.Context, jql string, from time.Time,
+	pages chan<- models.IssuePage) error {
+	args := m.Called(ctx, jql, from)
+	if args.Get(0) != nil {
+		for _, page := range args.Get(0).([]models.IssuePage) {
+			select {
+			case pages <- page:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockAPIClient) ListIssueRefs(ctx context.Context, projectKey string) ([]models.IssueRef, error) {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.IssueRef), args.Error(1)
 }
 
 func (m *MockAPIClient) GetProjects(ctx context.Context, limit, page int, search string) ([]models.ProjectInfo, error) {
@@ -62,9 +237,15 @@ func (m *MockAPIClient) GetBaseURL() string {
 	return args.String(0)
 }
 
+func (m *MockAPIClient) Health() models.JiraHealth {
+	args := m.Called()
+	return args.Get(0).(models.JiraHealth)
+}
+
 func createTestProjectInfo() *models.ProjectInfo {
 	return &models.ProjectInfo{
-		ID:         "10000",
+		ID:         "1",
+		JiraID:     "10000",
 		Key:        "TEST",
 		Name:       "Test Project",
 		LastUpdate: time.Now().Add(-24 * time.Hour),
@@ -94,20 +275,35 @@ func createTestJiraProject() *models.JiraProject {
 	}
 }
 
-func createTestIssues() *[]models.JiraIssue {
-	issues := []models.JiraIssue{
-		{
-			ID:  "10001",
-			Key: "TEST-1",
-			Fields: models.Fields{
-				Summary: "New Test Issue",
-				Creator: models.JiraUser{
-					DisplayName: "Test User",
+const testJQL = "project=TEST"
+
+func createTestIssues() []models.IssuePage {
+	return createTestPages(1, 1)
+}
+
+// createTestPages создает count страниц по size задач, updated задач возрастает на минуту
+func createTestPages(count, size int) []models.IssuePage {
+	start := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	pages := make([]models.IssuePage, 0, count)
+	for n := 0; n < count; n++ {
+		page := models.IssuePage{Number: n}
+		for i := 0; i < size; i++ {
+			id := n*size + i + 1
+			page.Issues = append(page.Issues, models.JiraIssue{
+				ID:  fmt.Sprintf("%d", 10000+id),
+				Key: fmt.Sprintf("TEST-%d", id),
+				Fields: models.Fields{
+					Summary: "New Test Issue",
+					Creator: models.JiraUser{
+						DisplayName: "Test User",
+					},
+					Updated: models.JiraTime{Time: start.Add(time.Duration(id) * time.Minute)},
 				},
-			},
-		},
+			})
+		}
+		pages = append(pages, page)
 	}
-	return &issues
+	return pages
 }
 
 func TestNewJiraConnector(t *testing.T) {
@@ -278,36 +474,92 @@ func TestJiraConnector_GetProjects(t *testing.T) {
 	})
 }
 
+func TestJiraConnector_Health(t *testing.T) {
+	mockAPIClient := &MockAPIClient{}
+	connector, err := NewJiraConnector(
+		WithRepository(&MockRepository{}),
+		WithAPIClient(mockAPIClient),
+		WithLogger(&logger.TestLogger{}),
+	)
+	require.NoError(t, err)
+
+	expected := models.JiraHealth{BaseURL: "https://jira.test.com", State: models.BreakerOpen}
+	mockAPIClient.On("Health").Return(expected)
+
+	assert.Equal(t, expected, connector.Health(context.Background()))
+	mockAPIClient.AssertExpectations(t)
+}
+
 func TestJiraConnector_UpdateProject(t *testing.T) {
-	t.Run("ExistingProjectWithNewIssues", func(t *testing.T) {
+	newConnector := func(t *testing.T) (*JiraConnector, *MockRepository, *MockAPIClient) {
 		mockRepo := &MockRepository{}
 		mockAPIClient := &MockAPIClient{}
-		mockLogger := &logger.TestLogger{}
-
 		connector, err := NewJiraConnector(
 			WithRepository(mockRepo),
 			WithAPIClient(mockAPIClient),
-			WithLogger(mockLogger),
+			WithLogger(&logger.TestLogger{}),
+			WithPageBuffer(1),
 		)
 		require.NoError(t, err)
+		t.Cleanup(connector.Shutdown)
+		// Синхронизация выполняется как задание
+		mockRepo.On("CreateSyncJob", mock.Anything, "TEST").
+			Return(&models.SyncJob{ID: 1, ProjectKey: "TEST", State: models.SyncJobQueued}, true, nil)
+		mockRepo.On("UpdateSyncJob", mock.Anything, mock.Anything).Return(nil)
+		return connector, mockRepo, mockAPIClient
+	}
+
+	t.Run("ActiveJob", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		connector, err := NewJiraConnector(
+			WithRepository(mockRepo),
+			WithAPIClient(&MockAPIClient{}),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+
+		// Проект уже загружается заданием, запущенным по расписанию или через StartSync
+		mockRepo.On("CreateSyncJob", mock.Anything, "TEST").
+			Return(&models.SyncJob{ID: 7, ProjectKey: "TEST", State: models.SyncJobRunning}, false, nil)
+
+		result, err := connector.UpdateProject(context.Background(), "TEST")
+
+		assert.ErrorIs(t, err, ErrSyncActive)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "GetProjectInfo", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "SaveCheckpoint", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ExistingProjectWithNewIssues", func(t *testing.T) {
+		connector, mockRepo, mockAPIClient := newConnector(t)
 
 		projectKey := "TEST"
 		projectInfo := createTestProjectInfo()
-		testIssues := createTestIssues()
 		baseURL := "https://jira.test.com"
 
 		// Настройка моков
 		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
 			Return(projectInfo, nil) // Проект найден в БД
 
-		mockAPIClient.On("UpdateProject", mock.Anything, projectKey, projectInfo.LastUpdate).
-			Return(testIssues, nil)
+		mockAPIClient.On("IssuesJQL", projectKey, projectInfo.LastUpdate).
+			Return(testJQL)
+		mockAPIClient.On("StreamIssues", mock.Anything, testJQL, time.Time{}).
+			Return(createTestIssues(), nil)
 
 		mockAPIClient.On("GetBaseURL").
 			Return(baseURL)
 
-		mockRepo.On("SaveProject", mock.Anything, mock.AnythingOfType("models.JiraProject")).
+		mockRepo.On("GetCheckpoint", mock.Anything, projectKey).Return(nil, nil)
+		mockRepo.On("SaveCheckpoint", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("SaveIssues", mock.Anything, projectInfo.ID, mock.Anything).Return(nil)
+		mockRepo.On("SetLastUpdate", mock.Anything, projectKey, mock.AnythingOfType("time.Time")).
 			Return(nil)
+		refs := []models.IssueRef{{ID: "10001", Key: "TEST-1"}}
+		mockAPIClient.On("ListIssueRefs", mock.Anything, projectKey).Return(refs, nil)
+		mockRepo.On("ReconcileIssues", mock.Anything, projectInfo.ID, refs).
+			Return(&models.Reconciliation{}, nil)
+		mockAPIClient.On("GetProjectVersions", mock.Anything, projectKey).Return([]models.Version{}, nil)
+		mockRepo.On("SaveVersions", mock.Anything, projectKey, []models.Version{}).Return(nil)
 
 		// Вызов метода
 		ctx := context.Background()
@@ -317,38 +569,45 @@ func TestJiraConnector_UpdateProject(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, projectKey, result.Key)
-		assert.Len(t, result.Issues, 1)
+		assert.Equal(t, 1, result.TotalIssueCount)
+		assert.Equal(t, baseURL+"/projects/TEST", result.Self)
+		// В ответе id проекта из Jira, а не из БД
+		assert.Equal(t, projectInfo.JiraID, result.ID)
 
 		mockRepo.AssertExpectations(t)
 		mockAPIClient.AssertExpectations(t)
 	})
 
 	t.Run("ExistingProjectNoNewIssues", func(t *testing.T) {
-		mockRepo := &MockRepository{}
-		mockAPIClient := &MockAPIClient{}
-		mockLogger := &logger.TestLogger{}
-
-		connector, err := NewJiraConnector(
-			WithRepository(mockRepo),
-			WithAPIClient(mockAPIClient),
-			WithLogger(mockLogger),
-		)
-		require.NoError(t, err)
+		connector, mockRepo, mockAPIClient := newConnector(t)
 
 		projectKey := "TEST"
 		projectInfo := createTestProjectInfo()
-		emptyIssues := &[]models.JiraIssue{}
 
 		// Настройка моков
 		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
 			Return(projectInfo, nil)
 
-		mockAPIClient.On("UpdateProject", mock.Anything, projectKey, projectInfo.LastUpdate).
-			Return(emptyIssues, nil)
+		mockAPIClient.On("IssuesJQL", projectKey, projectInfo.LastUpdate).
+			Return(testJQL)
+		mockAPIClient.On("StreamIssues", mock.Anything, testJQL, time.Time{}).
+			Return(nil, nil)
 
 		mockAPIClient.On("GetBaseURL").
 			Return("https://jira.test.com")
 
+		mockRepo.On("GetCheckpoint", mock.Anything, projectKey).Return(nil, nil)
+		mockRepo.On("SaveCheckpoint", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("SetLastUpdate", mock.Anything, projectKey, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		// Удаленные в Jira задачи находятся и без новых обновлений
+		refs := []models.IssueRef{{ID: "10001", Key: "TEST-1"}}
+		mockAPIClient.On("ListIssueRefs", mock.Anything, projectKey).Return(refs, nil)
+		mockRepo.On("ReconcileIssues", mock.Anything, projectInfo.ID, refs).
+			Return(&models.Reconciliation{Deleted: 1}, nil)
+		mockAPIClient.On("GetProjectVersions", mock.Anything, projectKey).Return([]models.Version{}, nil)
+		mockRepo.On("SaveVersions", mock.Anything, projectKey, []models.Version{}).Return(nil)
+
 		// Вызов метода
 		ctx := context.Background()
 		result, err := connector.UpdateProject(ctx, projectKey)
@@ -357,26 +616,62 @@ func TestJiraConnector_UpdateProject(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, projectKey, result.Key)
-		assert.Len(t, result.Issues, 0) // Нет новых issues
-
-		// SaveProject не должен вызываться когда нет новых issues
-		mockRepo.AssertNotCalled(t, "SaveProject")
+		assert.Equal(t, 0, result.TotalIssueCount) // Нет новых issues
 
 		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "SaveIssues")
 		mockAPIClient.AssertExpectations(t)
 	})
 
-	t.Run("GetProjectInfoError", func(t *testing.T) {
-		mockRepo := &MockRepository{}
-		mockAPIClient := &MockAPIClient{}
-		mockLogger := &logger.TestLogger{}
+	t.Run("NewProjectStreamsAllPages", func(t *testing.T) {
+		connector, mockRepo, mockAPIClient := newConnector(t)
 
-		connector, err := NewJiraConnector(
-			WithRepository(mockRepo),
-			WithAPIClient(mockAPIClient),
-			WithLogger(mockLogger),
-		)
+		projectKey := "TEST"
+		project := createTestJiraProject()
+		project.Issues = nil
+		pages := createTestPages(10, 1)
+
+		// Настройка моков
+		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
+			Return(nil, nil).Once()
+		mockAPIClient.On("GetProjectInfo", mock.Anything, projectKey).
+			Return(project, nil)
+		mockRepo.On("SaveProjectInfo", mock.Anything, *project).
+			Return(nil)
+		// Задачи сохраняются с id проекта в БД, а не с id из Jira
+		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
+			Return(&models.ProjectInfo{ID: "1", Key: projectKey, Name: project.Name}, nil).Once()
+		// Новый проект загружается целиком: lastUpdate нулевой
+		mockAPIClient.On("IssuesJQL", projectKey, time.Time{}).
+			Return(testJQL)
+		mockAPIClient.On("StreamIssues", mock.Anything, testJQL, time.Time{}).
+			Return(pages, nil)
+		mockRepo.On("GetCheckpoint", mock.Anything, projectKey).Return(nil, nil)
+		mockRepo.On("SaveCheckpoint", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("SaveIssues", mock.Anything, "1", mock.Anything).Return(nil)
+		mockRepo.On("SetLastUpdate", mock.Anything, projectKey, mock.AnythingOfType("time.Time")).
+			Return(nil)
+		// Пустой список задач из Jira не сверяется
+		mockAPIClient.On("ListIssueRefs", mock.Anything, projectKey).Return(nil, nil)
+		mockAPIClient.On("GetProjectVersions", mock.Anything, projectKey).Return([]models.Version{}, nil)
+		mockRepo.On("SaveVersions", mock.Anything, projectKey, []models.Version{}).Return(nil)
+
+		// Вызов метода
+		result, err := connector.UpdateProject(context.Background(), projectKey)
+
+		// Проверки
 		require.NoError(t, err)
+		assert.Equal(t, 10, result.TotalIssueCount)
+		assert.Equal(t, project.ID, result.ID)
+		assert.Empty(t, result.Issues)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReconcileIssues")
+		mockAPIClient.AssertExpectations(t)
+	})
+
+	t.Run("GetProjectInfoError", func(t *testing.T) {
+		connector, mockRepo, mockAPIClient := newConnector(t)
 
 		projectKey := "TEST"
 		expectedError := errors.New("database error")
@@ -395,21 +690,12 @@ func TestJiraConnector_UpdateProject(t *testing.T) {
 		assert.Nil(t, result)
 
 		mockRepo.AssertExpectations(t)
-		mockAPIClient.AssertNotCalled(t, "GetProject")
-		mockAPIClient.AssertNotCalled(t, "UpdateProject")
+		mockAPIClient.AssertNotCalled(t, "GetProjectInfo")
+		mockAPIClient.AssertNotCalled(t, "StreamIssues")
 	})
 
 	t.Run("GetProjectError", func(t *testing.T) {
-		mockRepo := &MockRepository{}
-		mockAPIClient := &MockAPIClient{}
-		mockLogger := &logger.TestLogger{}
-
-		connector, err := NewJiraConnector(
-			WithRepository(mockRepo),
-			WithAPIClient(mockAPIClient),
-			WithLogger(mockLogger),
-		)
-		require.NoError(t, err)
+		connector, mockRepo, mockAPIClient := newConnector(t)
 
 		projectKey := "TEST"
 		expectedError := errors.New("API error")
@@ -418,7 +704,7 @@ func TestJiraConnector_UpdateProject(t *testing.T) {
 		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
 			Return(nil, nil)
 
-		mockAPIClient.On("GetProject", mock.Anything, projectKey).
+		mockAPIClient.On("GetProjectInfo", mock.Anything, projectKey).
 			Return(nil, expectedError)
 
 		// Вызов метода
@@ -432,30 +718,28 @@ func TestJiraConnector_UpdateProject(t *testing.T) {
 
 		mockRepo.AssertExpectations(t)
 		mockAPIClient.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "SaveProjectInfo")
 	})
 
 	t.Run("UpdateProjectError", func(t *testing.T) {
-		mockRepo := &MockRepository{}
-		mockAPIClient := &MockAPIClient{}
-		mockLogger := &logger.TestLogger{}
-
-		connector, err := NewJiraConnector(
-			WithRepository(mockRepo),
-			WithAPIClient(mockAPIClient),
-			WithLogger(mockLogger),
-		)
-		require.NoError(t, err)
+		connector, mockRepo, mockAPIClient := newConnector(t)
 
 		projectKey := "TEST"
 		projectInfo := createTestProjectInfo()
 		expectedError := errors.New("update error")
 
-		// Настройка моков
+		// Настройка моков: Jira падает после первой страницы, которую writer может не успеть сохранить
 		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
 			Return(projectInfo, nil)
-
-		mockAPIClient.On("UpdateProject", mock.Anything, projectKey, projectInfo.LastUpdate).
-			Return(nil, expectedError)
+		mockAPIClient.On("GetBaseURL").
+			Return("https://jira.test.com")
+		mockAPIClient.On("IssuesJQL", projectKey, projectInfo.LastUpdate).
+			Return(testJQL)
+		mockAPIClient.On("StreamIssues", mock.Anything, testJQL, time.Time{}).
+			Return(createTestIssues(), expectedError)
+		mockRepo.On("GetCheckpoint", mock.Anything, projectKey).Return(nil, nil)
+		mockRepo.On("SaveCheckpoint", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("SaveIssues", mock.Anything, projectInfo.ID, mock.Anything).Return(nil).Maybe()
 
 		// Вызов метода
 		ctx := context.Background()
@@ -468,5 +752,626 @@ func TestJiraConnector_UpdateProject(t *testing.T) {
 
 		mockRepo.AssertExpectations(t)
 		mockAPIClient.AssertExpectations(t)
+		// lastUpdate не сдвигается, следующий запуск повторит незавершенный период
+		mockRepo.AssertNotCalled(t, "SetLastUpdate")
+	})
+
+	t.Run("WriterError", func(t *testing.T) {
+		connector, mockRepo, mockAPIClient := newConnector(t)
+
+		projectKey := "TEST"
+		projectInfo := createTestProjectInfo()
+		expectedError := errors.New("db write error")
+		pages := createTestPages(10, 1)
+
+		// Настройка моков: writer падает, поток страниц должен остановиться, а не зависнуть
+		mockRepo.On("GetProjectInfo", mock.Anything, projectKey).
+			Return(projectInfo, nil)
+		mockAPIClient.On("GetBaseURL").
+			Return("https://jira.test.com")
+		mockAPIClient.On("IssuesJQL", projectKey, projectInfo.LastUpdate).
+			Return(testJQL)
+		mockAPIClient.On("StreamIssues", mock.Anything, testJQL, time.Time{}).
+			Return(pages, nil)
+		mockRepo.On("GetCheckpoint", mock.Anything, projectKey).Return(nil, nil)
+		mockRepo.On("SaveCheckpoint", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("SaveIssues", mock.Anything, projectInfo.ID, mock.Anything).Return(expectedError)
+
+		// Вызов метода
+		result, err := connector.UpdateProject(context.Background(), projectKey)
+
+		// Проверки
+		assert.ErrorIs(t, err, expectedError)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "SetLastUpdate")
+	})
+}
+
+func TestWithPageBuffer(t *testing.T) {
+	_, err := NewJiraConnector(WithPageBuffer(0))
+	assert.Error(t, err)
+
+	connector, err := NewJiraConnector(WithPageBuffer(8))
+	require.NoError(t, err)
+	assert.Equal(t, 8, connector.pageBuffer)
+}
+
+// fakeRepository хранит данные в памяти и может упасть на заданной странице
+type fakeRepository struct {
+	mu       sync.Mutex
+	jobs     []models.SyncJob
+	projects map[string]*models.ProjectInfo
+	issues   map[string]models.JiraIssue
+	// checkpoints по ключу проекта
+	checkpoints map[string]*models.SyncCheckpoint
+	saves       int
+	failOnSave  int
+	schedule    *models.SyncSchedule
+	runs        []models.ScheduleRun
+	// sprints и versions по ключу проекта
+	sprints  map[string]models.ProjectSprints
+	versions map[string][]models.Version
+	// deleted - ключи задач, помеченных удаленными
+	deleted []string
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		projects:    make(map[string]*models.ProjectInfo),
+		issues:      make(map[string]models.JiraIssue),
+		checkpoints: make(map[string]*models.SyncCheckpoint),
+		sprints:     make(map[string]models.ProjectSprints),
+		versions:    make(map[string][]models.Version),
+	}
+}
+
+func (r *fakeRepository) GetProjectInfo(_ context.Context, projectKey string) (*models.ProjectInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.projects[projectKey], nil
+}
+
+func (r *fakeRepository) SaveProject(_ context.Context, project models.JiraProject) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.projects[project.Key] = &models.ProjectInfo{ID: project.ID, JiraID: project.ID, Key: project.Key,
+		Name: project.Name, LastUpdate: project.LastUpdate}
+	for _, issue := range project.Issues {
+		r.issues[issue.Key] = issue
+	}
+	return nil
+}
+
+func (r *fakeRepository) SaveProjectInfo(_ context.Context, project models.JiraProject) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.projects[project.Key] = &models.ProjectInfo{ID: project.ID, JiraID: project.ID, Key: project.Key,
+		Name: project.Name}
+	return nil
+}
+
+func (r *fakeRepository) SaveIssues(_ context.Context, _ string, issues []models.JiraIssue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saves++
+	if r.saves == r.failOnSave {
+		return errors.New("db write error")
+	}
+	for _, issue := range issues {
+		// как и в БД, задача без эпика (например, из вебхука) сохраняет прежний
+		if old, ok := r.issues[issue.Key]; ok && issue.Fields.EpicLink == "" {
+			issue.Fields.EpicLink = old.Fields.EpicLink
+		}
+		r.issues[issue.Key] = issue
+	}
+	return nil
+}
+
+// ReconcileIssues переносит задачи по id из Jira и удаляет задачи, которых нет в списке
+func (r *fakeRepository) ReconcileIssues(_ context.Context, _ string,
+	issues []models.IssueRef) (*models.Reconciliation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make(map[string]string, len(issues))
+	for _, ref := range issues {
+		keys[ref.ID] = ref.Key
+	}
+	var result models.Reconciliation
+	for key, issue := range r.issues {
+		newKey, ok := keys[issue.ID]
+		switch {
+		case !ok:
+			delete(r.issues, key)
+			r.deleted = append(r.deleted, key)
+			result.Deleted++
+		case newKey != key:
+			delete(r.issues, key)
+			issue.Key = newKey
+			r.issues[newKey] = issue
+			result.Moved++
+		}
+	}
+	return &result, nil
+}
+
+func (r *fakeRepository) MarkIssueDeleted(_ context.Context, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.issues[key]
+	if ok {
+		delete(r.issues, key)
+		r.deleted = append(r.deleted, key)
+	}
+	return ok, nil
+}
+
+func (r *fakeRepository) SetLastUpdate(_ context.Context, projectKey string, lastUpdate time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.projects[projectKey].LastUpdate = lastUpdate
+	delete(r.checkpoints, projectKey)
+	return nil
+}
+
+func (r *fakeRepository) GetCheckpoint(_ context.Context, projectKey string) (*models.SyncCheckpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.checkpoints[projectKey] == nil {
+		return nil, nil
+	}
+	cp := *r.checkpoints[projectKey]
+	return &cp, nil
+}
+
+func (r *fakeRepository) SaveCheckpoint(_ context.Context, cp models.SyncCheckpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkpoints[cp.ProjectKey] = &cp
+	return nil
+}
+
+func (r *fakeRepository) CreateSyncJob(_ context.Context, projectKey string) (*models.SyncJob, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.ProjectKey == projectKey && !job.State.Finished() {
+			return &job, false, nil
+		}
+	}
+	job := models.SyncJob{
+		ID:         int64(len(r.jobs) + 1),
+		ProjectKey: projectKey,
+		State:      models.SyncJobQueued,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	r.jobs = append(r.jobs, job)
+	return &job, true, nil
+}
+
+func (r *fakeRepository) GetSyncJob(_ context.Context, id int64) (*models.SyncJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id < 1 || int(id) > len(r.jobs) {
+		return nil, nil
+	}
+	job := r.jobs[id-1]
+	return &job, nil
+}
+
+func (r *fakeRepository) UpdateSyncJob(_ context.Context, job models.SyncJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job.UpdatedAt = time.Now()
+	r.jobs[job.ID-1] = job
+	return nil
+}
+
+func (r *fakeRepository) TouchSyncJob(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.jobs[id-1].State.Finished() {
+		r.jobs[id-1].UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *fakeRepository) CancelStaleSyncJob(_ context.Context, id int64, staleAfter time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := &r.jobs[id-1]
+	if job.State.Finished() || time.Since(job.UpdatedAt) < staleAfter {
+		return false, nil
+	}
+	job.State = models.SyncJobCanceled
+	job.FinishedAt = time.Now()
+	job.UpdatedAt = job.FinishedAt
+	return true, nil
+}
+
+func (r *fakeRepository) FailActiveSyncJobs(_ context.Context, reason string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for i := range r.jobs {
+		if !r.jobs[i].State.Finished() {
+			r.jobs[i].State = models.SyncJobFailed
+			r.jobs[i].Error = reason
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeRepository) GetProjectKeys(context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.projects))
+	for key := range r.projects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (r *fakeRepository) GetSyncSchedule(context.Context) (*models.SyncSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.schedule == nil {
+		return nil, nil
+	}
+	schedule := *r.schedule
+	return &schedule, nil
+}
+
+func (r *fakeRepository) SaveSyncSchedule(_ context.Context, schedule models.SyncSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedule = &schedule
+	return nil
+}
+
+func (r *fakeRepository) CreateScheduleRun(_ context.Context, startedAt time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, models.ScheduleRun{ID: int64(len(r.runs) + 1), StartedAt: startedAt})
+	return int64(len(r.runs)), nil
+}
+
+func (r *fakeRepository) FinishScheduleRun(_ context.Context, run models.ScheduleRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[run.ID-1] = run
+	return nil
+}
+
+func (r *fakeRepository) ListScheduleRuns(_ context.Context, limit int) ([]models.ScheduleRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	runs := make([]models.ScheduleRun, 0, limit)
+	for i := len(r.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		runs = append(runs, r.runs[i])
+	}
+	return runs, nil
+}
+
+func (r *fakeRepository) FailUnfinishedScheduleRuns(_ context.Context, reason string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for i := range r.runs {
+		if r.runs[i].FinishedAt.IsZero() {
+			r.runs[i].FinishedAt = time.Now()
+			r.runs[i].Error = reason
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeRepository) SaveSprints(_ context.Context, sprints models.ProjectSprints) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sprints[sprints.ProjectKey] = sprints
+	return nil
+}
+
+func (r *fakeRepository) SaveVersions(_ context.Context, projectKey string, versions []models.Version) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.versions[projectKey] = versions
+	return nil
+}
+
+// ListSprints оставляет в спринтах только сохраненные задачи, как и join в БД
+func (r *fakeRepository) ListSprints(_ context.Context, projectKey string) ([]models.Sprint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sprints := make([]models.Sprint, 0)
+	for _, sprint := range r.sprints[projectKey].Sprints {
+		keys := make([]string, 0, len(sprint.IssueKeys))
+		for _, key := range sprint.IssueKeys {
+			if _, ok := r.issues[key]; ok {
+				keys = append(keys, key)
+			}
+		}
+		sprint.IssueKeys = keys
+		sprints = append(sprints, sprint)
+	}
+	return sprints, nil
+}
+
+// fakeJira отдает задачи страницами в порядке updated и может упасть после заданного числа страниц
+type fakeJira struct {
+	mu sync.Mutex
+	// gate, если задан, пропускает по одной странице
+	gate      chan struct{}
+	pages     []models.IssuePage
+	order     []int
+	failAfter int
+	froms     []time.Time
+	// pause, если задана, сообщается двумя воркерами перед первой страницей
+	pause time.Duration
+	// active и maxActive считают одновременные загрузки
+	active    int
+	maxActive int
+	// versions отдаются для любого проекта, versionsErr вместо них
+	versions    []models.Version
+	versionsErr error
+	// refs отдаются как список задач проекта, по умолчанию - задачи из pages; refsErr вместо них
+	refs    []models.IssueRef
+	refsErr error
+}
+
+func (j *fakeJira) GetProjectInfo(_ context.Context, projectKey string) (*models.JiraProject, error) {
+	return &models.JiraProject{ID: "10000", Key: projectKey, Name: "Test Project"}, nil
+}
+
+func (j *fakeJira) GetProjectVersions(context.Context, string) ([]models.Version, error) {
+	return j.versions, j.versionsErr
+}
+
+func (j *fakeJira) IssuesJQL(projectKey string, _ time.Time) string {
+	return "project=" + projectKey
+}
+
+func (j *fakeJira) StreamIssues(ctx context.Context, _ string, from time.Time, pages chan<- models.IssuePage) error {
+	j.mu.Lock()
+	j.froms = append(j.froms, from)
+	j.active++
+	j.maxActive = max(j.maxActive, j.active)
+	failAfter := j.failAfter
+	j.mu.Unlock()
+	defer func() {
+		j.mu.Lock()
+		j.active--
+		j.mu.Unlock()
+	}()
+
+	// JQL сравнивает даты с точностью до минуты
+	var issues []models.JiraIssue
+	for _, page := range j.pages {
+		for _, issue := range page.Issues {
+			if !issue.Fields.Updated.Before(from.Truncate(time.Minute)) {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	size := len(j.pages[0].Issues)
+	var result []models.IssuePage
+	for n := 0; n*size < len(issues); n++ {
+		result = append(result, models.IssuePage{Number: n, Total: len(issues),
+			Issues: issues[n*size : min((n+1)*size, len(issues))]})
+	}
+	for n := range result {
+		result[n].Pages = len(result)
+	}
+
+	trace := models.ContextSyncTrace(ctx)
+	if trace.TotalDiscovered != nil {
+		trace.TotalDiscovered(len(issues), len(result))
+	}
+	if j.pause > 0 && trace.Paused != nil {
+		trace.Paused(j.pause)
+		trace.Paused(j.pause)
+	}
+
+	order := j.order
+	if order == nil {
+		for n := range result {
+			order = append(order, n)
+		}
+	}
+	for sent, n := range order {
+		if sent == failAfter {
+			// дожидаемся, пока writer заберет отправленные страницы
+			for len(pages) > 0 {
+				time.Sleep(time.Millisecond)
+			}
+			j.mu.Lock()
+			j.failAfter = -1
+			j.mu.Unlock()
+			return errors.New("jira is unavailable")
+		}
+		if n >= len(result) {
+			continue
+		}
+		if j.gate != nil {
+			select {
+			case <-j.gate:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if trace.PageFetched != nil {
+			trace.PageFetched(n, len(result[n].Issues))
+		}
+		select {
+		case pages <- result[n]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (j *fakeJira) ListIssueRefs(context.Context, string) ([]models.IssueRef, error) {
+	if j.refs != nil || j.refsErr != nil {
+		return j.refs, j.refsErr
+	}
+	var refs []models.IssueRef
+	for _, page := range j.pages {
+		for _, issue := range page.Issues {
+			refs = append(refs, models.IssueRef{ID: issue.ID, Key: issue.Key})
+		}
+	}
+	return refs, nil
+}
+
+func (j *fakeJira) GetProjects(context.Context, int, int, string) ([]models.ProjectInfo, error) {
+	return nil, nil
+}
+
+func (j *fakeJira) GetBaseURL() string {
+	return "https://jira.test.com"
+}
+
+func (j *fakeJira) Health() models.JiraHealth {
+	return models.JiraHealth{BaseURL: j.GetBaseURL(), State: models.BreakerClosed}
+}
+
+// TestJiraConnector_JiraFixtures синхронизирует проект с фейковой Jira настоящим клиентом
+func TestJiraConnector_JiraFixtures(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+	server.FailNext(2, jiratest.Fault{Status: http.StatusTooManyRequests, Path: "/search"})
+	client := jira.NewClient(
+		jira.WithConfig(jira.Config{
+			BaseURL:      server.URL,
+			VersionAPI:   "/rest/api/2",
+			MaxResults:   2,
+			MaxProcesses: 2,
+		}),
+		jira.WithLogger(&logger.TestLogger{}),
+		jira.WithMaxDelay(10),
+	)
+	repo := newFakeRepository()
+	connector, err := NewJiraConnector(
+		WithRepository(repo),
+		WithAPIClient(client),
+		WithLogger(&logger.TestLogger{}),
+	)
+	require.NoError(t, err)
+
+	project, err := connector.UpdateProject(context.Background(), "TEST")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Test Project", project.Name)
+	assert.Equal(t, 5, project.TotalIssueCount)
+	assert.Len(t, repo.issues, 5)
+	assert.Len(t, repo.issues["TEST-10"].Changelogs.Histories, 2)
+	assert.Equal(t, "Reopened", repo.issues["TEST-10"].Fields.Status.Name)
+	assert.Nil(t, repo.checkpoints["TEST"])
+	assert.False(t, repo.projects["TEST"].LastUpdate.IsZero())
+
+	// Повторная синхронизация запрашивает только обновленные задачи,
+	// а задачи, которых больше нет в Jira, находит сверка
+	repo.issues["TEST-99"] = models.JiraIssue{ID: "10099", Key: "TEST-99"}
+	project, err = connector.UpdateProject(context.Background(), "TEST")
+	require.NoError(t, err)
+	assert.Equal(t, 0, project.TotalIssueCount)
+	assert.Equal(t, []string{"TEST-99"}, repo.deleted)
+	assert.Len(t, repo.issues, 5)
+}
+
+func TestJiraConnector_ResumeSync(t *testing.T) {
+	newConnector := func(t *testing.T, repo Repository, api APIClient) *JiraConnector {
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(api),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+		return connector
+	}
+
+	t.Run("RerunResumesFromLastCommittedPage", func(t *testing.T) {
+		repo := newFakeRepository()
+		pages := createTestPages(5, 2)
+		api := &fakeJira{pages: pages, failAfter: 3}
+		connector := newConnector(t, repo, api)
+
+		// Первый запуск падает после третьей страницы
+		_, err := connector.UpdateProject(context.Background(), "TEST")
+		require.Error(t, err)
+
+		require.NotNil(t, repo.checkpoints["TEST"])
+		assert.Equal(t, "project=TEST", repo.checkpoints["TEST"].JQL)
+		assert.Equal(t, 3, repo.checkpoints["TEST"].PagesCompleted)
+		assert.Equal(t, pages[2].HighWater(), repo.checkpoints["TEST"].HighWater)
+		assert.Len(t, repo.issues, 6)
+		assert.True(t, repo.projects["TEST"].LastUpdate.IsZero())
+		startedAt := repo.checkpoints["TEST"].StartedAt
+
+		// Повторный запуск продолжает с последней сохраненной страницы
+		result, err := connector.UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+
+		require.Len(t, api.froms, 2)
+		assert.True(t, api.froms[0].IsZero())
+		assert.Equal(t, pages[2].HighWater(), api.froms[1])
+		assert.Equal(t, 5, result.TotalIssueCount) // TEST-6 обновлена в ту же минуту и загружается повторно
+		assert.Len(t, repo.issues, 10)
+		assert.Nil(t, repo.checkpoints["TEST"])
+		assert.Equal(t, startedAt, repo.projects["TEST"].LastUpdate)
+	})
+
+	t.Run("OutOfOrderPages", func(t *testing.T) {
+		repo := newFakeRepository()
+		pages := createTestPages(5, 2)
+		// Страница 3 сохранена раньше страницы 2, которую не успели загрузить
+		api := &fakeJira{pages: pages, order: []int{1, 0, 3, 2, 4}, failAfter: 3}
+		connector := newConnector(t, repo, api)
+
+		_, err := connector.UpdateProject(context.Background(), "TEST")
+		require.Error(t, err)
+
+		require.NotNil(t, repo.checkpoints["TEST"])
+		assert.Equal(t, 2, repo.checkpoints["TEST"].PagesCompleted)
+		assert.Equal(t, pages[1].HighWater(), repo.checkpoints["TEST"].HighWater)
+		assert.Len(t, repo.issues, 6)
+
+		_, err = connector.ResumeSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, pages[1].HighWater(), api.froms[1])
+		assert.Len(t, repo.issues, 10)
+		assert.Nil(t, repo.checkpoints["TEST"])
+	})
+
+	t.Run("RepositoryFailure", func(t *testing.T) {
+		repo := newFakeRepository()
+		repo.failOnSave = 3
+		pages := createTestPages(5, 2)
+		api := &fakeJira{pages: pages, failAfter: -1}
+		connector := newConnector(t, repo, api)
+
+		_, err := connector.UpdateProject(context.Background(), "TEST")
+		require.Error(t, err)
+
+		require.NotNil(t, repo.checkpoints["TEST"])
+		assert.Equal(t, 2, repo.checkpoints["TEST"].PagesCompleted)
+		assert.Equal(t, pages[1].HighWater(), repo.checkpoints["TEST"].HighWater)
+
+		result, err := connector.ResumeSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, 7, result.TotalIssueCount)
+		assert.Len(t, repo.issues, 10)
+	})
+
+	t.Run("NoCheckpoint", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newConnector(t, repo, &fakeJira{})
+
+		_, err := connector.ResumeSync(context.Background(), "TEST")
+		assert.ErrorIs(t, err, ErrNoCheckpoint)
 	})
 }
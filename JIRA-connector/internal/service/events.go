@@ -0,0 +1,169 @@
+package connector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+)
+
+const watcherBuffer = 64
+
+// eventHub fans the events of a running job out to its watchers. A watcher which does not keep up
+// misses events instead of slowing the sync down. Watcher channels are closed when the job ends.
+type eventHub struct {
+	mu         sync.Mutex
+	watchers   map[chan models.SyncEvent]struct{}
+	total      *models.SyncEvent
+	pauseUntil time.Time
+	closed     bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{watchers: make(map[chan models.SyncEvent]struct{})}
+}
+
+func (h *eventHub) publish(event models.SyncEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	switch event.Type {
+	case models.SyncEventTotalDiscovered:
+		h.total = &event
+	case models.SyncEventPaused:
+		// every waiting worker reports the same pause
+		if event.Time.Before(h.pauseUntil) {
+			return
+		}
+		h.pauseUntil = event.Time.Add(event.RetryAfter)
+	}
+
+	for w := range h.watchers {
+		select {
+		case w <- event:
+		default:
+		}
+	}
+}
+
+// watch subscribes to the job events. The total, if it is already known, is sent first.
+func (h *eventHub) watch() (<-chan models.SyncEvent, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w := make(chan models.SyncEvent, watcherBuffer)
+	if h.closed {
+		close(w)
+		return w, func() {}
+	}
+	if h.total != nil {
+		w <- *h.total
+	}
+	h.watchers[w] = struct{}{}
+
+	return w, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.watchers[w]; ok {
+			delete(h.watchers, w)
+			close(w)
+		}
+	}
+}
+
+func (h *eventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for w := range h.watchers {
+		delete(h.watchers, w)
+		close(w)
+	}
+}
+
+// WatchSync starts a sync of the project, or joins the active one, and calls send for every
+// progress event until the sync completes or fails. The last event is SyncEventCompleted or
+// SyncEventFailed. The sync goes on if the watcher stops earlier.
+func (jc *JiraConnector) WatchSync(ctx context.Context, projectKey string, send func(models.SyncEvent) error) error {
+	job, err := jc.StartSync(ctx, projectKey)
+	if err != nil {
+		return err
+	}
+
+	events, stop := jc.watchJob(job.ID)
+	defer stop()
+	for events != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				break
+			}
+			if err = send(event); err != nil {
+				return err
+			}
+		}
+	}
+
+	// a job run by another connector has no events here and is followed by its state only
+	job, err = jc.waitJob(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	return send(finalEvent(job))
+}
+
+// waitJob polls the job state until the job is finished
+func (jc *JiraConnector) waitJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	ticker := time.NewTicker(jc.jobs.pollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := jc.GetSyncJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job.State.Finished() {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchJob subscribes to the events of the job running in this process.
+// It returns a nil channel if the job is not running.
+func (jc *JiraConnector) watchJob(id int64) (<-chan models.SyncEvent, func()) {
+	jc.jobs.mu.Lock()
+	rj, ok := jc.jobs.running[id]
+	jc.jobs.mu.Unlock()
+	if !ok {
+		return nil, func() {}
+	}
+	return rj.events.watch()
+}
+
+func finalEvent(job *models.SyncJob) models.SyncEvent {
+	event := models.SyncEvent{
+		JobID:      job.ID,
+		ProjectKey: job.ProjectKey,
+		Time:       job.FinishedAt,
+	}
+	if job.State == models.SyncJobSucceeded {
+		event.Type = models.SyncEventCompleted
+		event.Issues = job.IssuesDone
+		return event
+	}
+	event.Type = models.SyncEventFailed
+	event.Error = job.Error
+	event.Canceled = job.State == models.SyncJobCanceled
+	return event
+}
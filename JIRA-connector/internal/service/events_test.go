@@ -0,0 +1,246 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventTypes возвращает типы событий по порядку
+func eventTypes(events []models.SyncEvent) []models.SyncEventType {
+	types := make([]models.SyncEventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestJiraConnector_WatchSync(t *testing.T) {
+	t.Run("Completed", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		// Страницы пропускаются после того, как наблюдатель получил первое событие
+		var events []models.SyncEvent
+		err := connector.WatchSync(context.Background(), "TEST", func(event models.SyncEvent) error {
+			if len(events) == 0 {
+				close(api.gate)
+			}
+			events = append(events, event)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// Проверки
+		require.NotEmpty(t, events)
+		assert.Equal(t, models.SyncEventTotalDiscovered, events[0].Type)
+		assert.Equal(t, 6, events[0].Issues)
+		assert.Equal(t, 3, events[0].Pages)
+
+		last := events[len(events)-1]
+		assert.Equal(t, models.SyncEventCompleted, last.Type)
+		assert.Equal(t, 6, last.Issues)
+
+		var fetched, committed int
+		for _, event := range events {
+			assert.Equal(t, "TEST", event.ProjectKey)
+			assert.Equal(t, events[0].JobID, event.JobID)
+			switch event.Type {
+			case models.SyncEventPageFetched:
+				fetched++
+			case models.SyncEventBatchCommitted:
+				committed++
+				assert.Equal(t, 2, event.Issues)
+				assert.Equal(t, committed*2, event.IssuesDone)
+			}
+		}
+		assert.Equal(t, 3, fetched)
+		assert.Equal(t, 3, committed)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: 1}
+		connector := newJobsConnector(t, repo, api)
+
+		var events []models.SyncEvent
+		err := connector.WatchSync(context.Background(), "TEST", func(event models.SyncEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NotEmpty(t, events)
+		last := events[len(events)-1]
+		assert.Equal(t, models.SyncEventFailed, last.Type)
+		assert.Contains(t, last.Error, "jira is unavailable")
+		assert.False(t, last.Canceled)
+	})
+
+	t.Run("JoinsActiveJob", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		job, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		waitJobState(t, connector, job.ID, models.SyncJobRunning)
+
+		// Наблюдатель, подключившийся позже, сначала получает общее количество задач
+		var events []models.SyncEvent
+		err = connector.WatchSync(context.Background(), "TEST", func(event models.SyncEvent) error {
+			if len(events) == 0 {
+				close(api.gate)
+			}
+			events = append(events, event)
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NotEmpty(t, events)
+		assert.Equal(t, job.ID, events[0].JobID)
+		assert.Equal(t, models.SyncEventTotalDiscovered, events[0].Type)
+		assert.Equal(t, models.SyncEventCompleted, events[len(events)-1].Type)
+	})
+
+	t.Run("JobRunningElsewhere", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: -1}
+		connector := newJobsConnector(t, repo, api)
+		connector.jobs.pollInterval = time.Millisecond
+
+		// Задание запущено другим экземпляром коннектора и не выполняется в этом процессе
+		job, _, err := repo.CreateSyncJob(context.Background(), "TEST")
+		require.NoError(t, err)
+		job.State = models.SyncJobRunning
+		require.NoError(t, repo.UpdateSyncJob(context.Background(), *job))
+
+		done := make(chan error, 1)
+		var events []models.SyncEvent
+		go func() {
+			done <- connector.WatchSync(context.Background(), "TEST", func(event models.SyncEvent) error {
+				events = append(events, event)
+				return nil
+			})
+		}()
+
+		// Пока задание выполняется, наблюдатель ждет, а не сообщает об ошибке
+		select {
+		case err = <-done:
+			t.Fatalf("WatchSync returned while the job is running: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		job.State = models.SyncJobSucceeded
+		job.IssuesDone = 6
+		job.FinishedAt = time.Now()
+		require.NoError(t, repo.UpdateSyncJob(context.Background(), *job))
+
+		require.NoError(t, <-done)
+		require.Len(t, events, 1)
+		assert.Equal(t, models.SyncEventCompleted, events[0].Type)
+		assert.Equal(t, job.ID, events[0].JobID)
+		assert.Equal(t, 6, events[0].Issues)
+	})
+
+	t.Run("WatcherStops", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		// Ошибка отправки останавливает наблюдение, но не загрузку
+		errSend := errors.New("stream closed")
+		err := connector.WatchSync(context.Background(), "TEST", func(models.SyncEvent) error {
+			return errSend
+		})
+		assert.ErrorIs(t, err, errSend)
+
+		job, _, err := repo.CreateSyncJob(context.Background(), "TEST")
+		require.NoError(t, err)
+		close(api.gate)
+		waitJobState(t, connector, job.ID, models.SyncJobSucceeded)
+	})
+}
+
+func TestEventHub(t *testing.T) {
+	t.Run("DeduplicatesPauses", func(t *testing.T) {
+		hub := newEventHub()
+		events, stop := hub.watch()
+		defer stop()
+
+		now := time.Now()
+		hub.publish(models.SyncEvent{Type: models.SyncEventPaused, Time: now, RetryAfter: time.Minute})
+		hub.publish(models.SyncEvent{Type: models.SyncEventPaused, Time: now.Add(time.Second), RetryAfter: time.Minute})
+		hub.publish(models.SyncEvent{Type: models.SyncEventPaused, Time: now.Add(2 * time.Minute), RetryAfter: time.Minute})
+		hub.close()
+
+		var received []models.SyncEvent
+		for event := range events {
+			received = append(received, event)
+		}
+		require.Len(t, received, 2)
+		assert.Equal(t, now, received[0].Time)
+		assert.Equal(t, now.Add(2*time.Minute), received[1].Time)
+	})
+
+	t.Run("SlowWatcherMissesEvents", func(t *testing.T) {
+		hub := newEventHub()
+		events, stop := hub.watch()
+		defer stop()
+
+		for n := 0; n < watcherBuffer+10; n++ {
+			hub.publish(models.SyncEvent{Type: models.SyncEventPageFetched, Page: n})
+		}
+		hub.close()
+
+		var received []models.SyncEvent
+		for event := range events {
+			received = append(received, event)
+		}
+		assert.Len(t, received, watcherBuffer)
+		assert.Equal(t, []models.SyncEventType{models.SyncEventPageFetched}, eventTypes(received[:1]))
+	})
+
+	t.Run("ClosedHub", func(t *testing.T) {
+		hub := newEventHub()
+		hub.publish(models.SyncEvent{Type: models.SyncEventTotalDiscovered, Issues: 10})
+		hub.close()
+
+		events, stop := hub.watch()
+		defer stop()
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("ConcurrentWatchers", func(t *testing.T) {
+		hub := newEventHub()
+		hub.publish(models.SyncEvent{Type: models.SyncEventTotalDiscovered, Issues: 10})
+
+		var wg sync.WaitGroup
+		for n := 0; n < 3; n++ {
+			events, stop := hub.watch()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer stop()
+				var received []models.SyncEvent
+				for event := range events {
+					received = append(received, event)
+				}
+				assert.Equal(t, []models.SyncEventType{models.SyncEventTotalDiscovered, models.SyncEventCompleted},
+					eventTypes(received))
+			}()
+		}
+		hub.publish(models.SyncEvent{Type: models.SyncEventCompleted})
+		hub.close()
+		wg.Wait()
+	})
+}
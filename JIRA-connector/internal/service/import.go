@@ -0,0 +1,37 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sssidkn/jira-connector/internal/export"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+	"io"
+)
+
+// ErrInvalidExport is returned by ImportProjects when the export cannot be parsed
+var ErrInvalidExport = errors.New("invalid jira export")
+
+// ImportProjects saves the projects of a JSON or XML export of an air-gapped Jira instance.
+// The returned projects have only TotalIssueCount set, not the issues. Imported projects
+// are fully synced again if the Jira API becomes available.
+func (jc *JiraConnector) ImportProjects(ctx context.Context, r io.Reader, format export.Format,
+	opts ...export.Option) ([]Project, error) {
+
+	projects, err := export.Parse(r, format, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	imported := make([]Project, 0, len(projects))
+	for _, project := range projects {
+		if err = jc.repo.SaveProject(ctx, project); err != nil {
+			return imported, fmt.Errorf("failed to import project %s: %w", project.Key, err)
+		}
+		jc.logger.Info("Project imported",
+			logger.Field{Key: "project_key", Value: project.Key},
+			logger.Field{Key: "issues", Value: project.TotalIssueCount})
+		project.Issues = nil
+		imported = append(imported, project)
+	}
+	return imported, nil
+}
@@ -0,0 +1,110 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/export"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testJSONExport = `{"issues": [
+	{"id": "10102", "key": "TEST-2", "fields": {"project": {"id": "10000", "key": "TEST", "name": "Test Project"},
+		"status": {"name": "In Progress"}, "creator": {"displayName": "Alice Smith"}},
+	 "changelog": {"histories": [{"author": {"displayName": "Bob Jones"}, "created": "2025-03-10T12:00:00.000+0000",
+		"items": [{"field": "status", "fromString": "Open", "toString": "In Progress"}]}]}},
+	{"id": "10101", "key": "TEST-1", "fields": {"project": {"id": "10000", "key": "TEST"}}},
+	{"id": "10201", "key": "DEMO-1", "fields": {"project": {"id": "10001", "key": "DEMO", "name": "Demo Project"}}}
+]}`
+
+const testXMLExport = `<entity-engine-xml>
+	<Project id="10000" name="Test Project" originalkey="TEST"/>
+	<Issue id="10101" number="1" project="10000" summary="Login fails" created="2025-03-01 09:00:00.0"/>
+</entity-engine-xml>`
+
+func TestJiraConnector_ImportProjects(t *testing.T) {
+	newConnector := func(t *testing.T, repo Repository) *JiraConnector {
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+		return connector
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newConnector(t, repo)
+
+		projects, err := connector.ImportProjects(context.Background(), strings.NewReader(testJSONExport), export.FormatAuto)
+		require.NoError(t, err)
+
+		require.Len(t, projects, 2)
+		assert.Equal(t, "DEMO", projects[0].Key)
+		assert.Equal(t, 1, projects[0].TotalIssueCount)
+		assert.Equal(t, "TEST", projects[1].Key)
+		assert.Equal(t, 2, projects[1].TotalIssueCount)
+		assert.Nil(t, projects[1].Issues)
+
+		assert.Equal(t, "Test Project", repo.projects["TEST"].Name)
+		assert.True(t, repo.projects["TEST"].LastUpdate.IsZero())
+		assert.Len(t, repo.issues, 3)
+		require.Len(t, repo.issues["TEST-2"].Changelogs.Histories, 1)
+		assert.Equal(t, "In Progress", repo.issues["TEST-2"].Changelogs.Histories[0].Items[0].ToString)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newConnector(t, repo)
+
+		msk := time.FixedZone("MSK", 3*60*60)
+		projects, err := connector.ImportProjects(context.Background(), strings.NewReader(testXMLExport),
+			export.FormatXML, export.WithLocation(msk))
+		require.NoError(t, err)
+
+		require.Len(t, projects, 1)
+		assert.Equal(t, "Login fails", repo.issues["TEST-1"].Fields.Summary)
+		assert.Equal(t, time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC), repo.issues["TEST-1"].Fields.Created.UTC())
+	})
+
+	t.Run("InvalidExport", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newConnector(t, repo)
+
+		_, err := connector.ImportProjects(context.Background(), strings.NewReader("key,summary"), export.FormatAuto)
+		assert.ErrorIs(t, err, ErrInvalidExport)
+		assert.Empty(t, repo.projects)
+	})
+
+	t.Run("SaveError", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		connector := newConnector(t, mockRepo)
+
+		// Настройка моков
+		mockRepo.On("SaveProject", mock.Anything, mock.MatchedBy(func(p models.JiraProject) bool {
+			return p.Key == "DEMO"
+		})).Return(nil)
+		mockRepo.On("SaveProject", mock.Anything, mock.MatchedBy(func(p models.JiraProject) bool {
+			return p.Key == "TEST"
+		})).Return(errors.New("db error"))
+
+		// Вызов метода
+		projects, err := connector.ImportProjects(context.Background(), strings.NewReader(testJSONExport), export.FormatJSON)
+
+		// Проверки
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TEST")
+		assert.NotErrorIs(t, err, ErrInvalidExport)
+		require.Len(t, projects, 1)
+		assert.Equal(t, "DEMO", projects[0].Key)
+		mockRepo.AssertExpectations(t)
+	})
+}
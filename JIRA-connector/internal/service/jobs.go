@@ -0,0 +1,270 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// ErrSyncJobNotFound is returned for unknown sync job ids
+var ErrSyncJobNotFound = errors.New("sync job not found")
+
+// ErrSyncJobRunningElsewhere is returned when canceling a job run by another connector
+var ErrSyncJobRunningElsewhere = errors.New("sync job is running in another connector")
+
+const shutdownReason = "connector stopped"
+
+const (
+	// defaultJobPollInterval is how often the state of a job running elsewhere is checked
+	defaultJobPollInterval = time.Second
+	// defaultJobHeartbeat is how often a running job records that it is alive
+	defaultJobHeartbeat = 30 * time.Second
+	// staleJobHeartbeats is how many heartbeats a job may miss before it is taken for
+	// a job of a stopped connector
+	staleJobHeartbeats = 4
+)
+
+// jobRunner tracks the sync jobs running in this process
+type jobRunner struct {
+	ctx          context.Context
+	stop         context.CancelFunc
+	mu           sync.Mutex
+	running      map[int64]*runningJob
+	wg           sync.WaitGroup
+	pollInterval time.Duration
+	heartbeat    time.Duration
+}
+
+type runningJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	events *eventHub
+	// project and err are the result of the sync, set before done is closed
+	project *Project
+	err     error
+}
+
+func newJobRunner() *jobRunner {
+	ctx, stop := context.WithCancel(context.Background())
+	return &jobRunner{
+		ctx:          ctx,
+		stop:         stop,
+		running:      make(map[int64]*runningJob),
+		pollInterval: defaultJobPollInterval,
+		heartbeat:    defaultJobHeartbeat,
+	}
+}
+
+// StartSync enqueues a sync of the project and returns without waiting for it.
+// If the project is already being synced, the active job is returned.
+func (jc *JiraConnector) StartSync(ctx context.Context, projectKey string) (*models.SyncJob, error) {
+	job, _, err := jc.startSync(ctx, projectKey)
+	return job, err
+}
+
+// startSync returns the running job only if the job was created by this call
+func (jc *JiraConnector) startSync(ctx context.Context, projectKey string) (*models.SyncJob, *runningJob, error) {
+	job, created, err := jc.repo.CreateSyncJob(ctx, projectKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !created {
+		jc.logger.Info("Project sync is already active", logger.Field{Key: "project_key", Value: projectKey},
+			logger.Field{Key: "job_id", Value: job.ID})
+		return job, nil, nil
+	}
+
+	jobCtx, cancel := context.WithCancel(jc.jobs.ctx)
+	rj := &runningJob{cancel: cancel, done: make(chan struct{}), events: newEventHub()}
+	jc.jobs.mu.Lock()
+	jc.jobs.running[job.ID] = rj
+	jc.jobs.mu.Unlock()
+
+	jc.jobs.wg.Add(1)
+	go func(job models.SyncJob) {
+		defer jc.jobs.wg.Done()
+		defer close(rj.done)
+		defer func() {
+			jc.jobs.mu.Lock()
+			delete(jc.jobs.running, job.ID)
+			jc.jobs.mu.Unlock()
+			rj.events.close()
+			cancel()
+		}()
+		rj.project, rj.err = jc.runJob(jobCtx, job, rj.events)
+	}(*job)
+
+	return job, rj, nil
+}
+
+// GetSyncJob returns the job state and progress
+func (jc *JiraConnector) GetSyncJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	job, err := jc.repo.GetSyncJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrSyncJobNotFound
+	}
+	return job, nil
+}
+
+// CancelSyncJob stops the job and waits until it is stopped. The pages saved before stay
+// in the database and the next sync of the project resumes from them.
+func (jc *JiraConnector) CancelSyncJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	job, err := jc.GetSyncJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.State.Finished() {
+		return job, nil
+	}
+
+	jc.jobs.mu.Lock()
+	rj, ok := jc.jobs.running[id]
+	jc.jobs.mu.Unlock()
+	if !ok {
+		// only a job left active by a stopped connector is canceled here, the job of a running
+		// connector would overwrite the state
+		canceled, err := jc.repo.CancelStaleSyncJob(ctx, id, staleJobHeartbeats*jc.jobs.heartbeat)
+		if err != nil {
+			return nil, err
+		}
+		if job, err = jc.GetSyncJob(ctx, id); err != nil {
+			return nil, err
+		}
+		if !canceled && !job.State.Finished() {
+			return nil, fmt.Errorf("%w: job %d", ErrSyncJobRunningElsewhere, id)
+		}
+		return job, nil
+	}
+
+	rj.cancel()
+	select {
+	case <-rj.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return jc.GetSyncJob(ctx, id)
+}
+
+// FailInterruptedSyncJobs marks the jobs and scheduled runs left active by a previous run
+// of the connector as failed. It must be called before any job is started.
+func (jc *JiraConnector) FailInterruptedSyncJobs(ctx context.Context) error {
+	count, err := jc.repo.FailActiveSyncJobs(ctx, shutdownReason)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		jc.logger.Info("Failed interrupted sync jobs", logger.Field{Key: "count", Value: count})
+	}
+	count, err = jc.repo.FailUnfinishedScheduleRuns(ctx, shutdownReason)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		jc.logger.Info("Failed interrupted schedule runs", logger.Field{Key: "count", Value: count})
+	}
+	return nil
+}
+
+// Shutdown stops the running sync jobs and waits for them
+func (jc *JiraConnector) Shutdown() {
+	jc.jobs.stop()
+	jc.jobs.wg.Wait()
+}
+
+func (jc *JiraConnector) runJob(ctx context.Context, job models.SyncJob, events *eventHub) (*Project, error) {
+	log := jc.logger.With(logger.Field{Key: "project_key", Value: job.ProjectKey},
+		logger.Field{Key: "job_id", Value: job.ID})
+	// the final state is saved even if the job is canceled
+	saveCtx := context.WithoutCancel(ctx)
+
+	publish := func(event models.SyncEvent) {
+		event.JobID = job.ID
+		event.ProjectKey = job.ProjectKey
+		event.Time = time.Now()
+		events.publish(event)
+	}
+	ctx = models.WithSyncTrace(ctx, &models.SyncTrace{
+		TotalDiscovered: func(issues, pages int) {
+			publish(models.SyncEvent{Type: models.SyncEventTotalDiscovered, Issues: issues, Pages: pages})
+		},
+		PageFetched: func(page, issues int) {
+			publish(models.SyncEvent{Type: models.SyncEventPageFetched, Page: page, Issues: issues})
+		},
+		Paused: func(retryAfter time.Duration) {
+			publish(models.SyncEvent{Type: models.SyncEventPaused, RetryAfter: retryAfter})
+		},
+	})
+
+	job.State = models.SyncJobRunning
+	job.StartedAt = time.Now()
+	if err := jc.repo.UpdateSyncJob(ctx, job); err != nil {
+		log.Error("Failed to start sync job", logger.Field{Key: "error", Value: err.Error()})
+	}
+	stopHeartbeat := jc.startHeartbeat(ctx, job.ID, log)
+
+	project, syncErr := jc.syncProject(ctx, job.ProjectKey, func(page models.IssuePage, saved int) error {
+		job.PagesDone++
+		job.PagesTotal = page.Pages
+		job.IssuesDone = saved
+		job.IssuesTotal = page.Total
+		publish(models.SyncEvent{Type: models.SyncEventBatchCommitted, Page: page.Number,
+			Issues: len(page.Issues), IssuesDone: saved})
+		return jc.repo.UpdateSyncJob(ctx, job)
+	})
+
+	stopHeartbeat()
+
+	switch {
+	case syncErr == nil:
+		job.State = models.SyncJobSucceeded
+	case jc.jobs.ctx.Err() != nil:
+		job.State = models.SyncJobFailed
+		job.Error = shutdownReason
+	case ctx.Err() != nil:
+		job.State = models.SyncJobCanceled
+	default:
+		job.State = models.SyncJobFailed
+		job.Error = syncErr.Error()
+	}
+	job.FinishedAt = time.Now()
+	if err := jc.repo.UpdateSyncJob(saveCtx, job); err != nil {
+		log.Error("Failed to save sync job state", logger.Field{Key: "error", Value: err.Error()})
+	}
+	log.Info("Sync job finished", logger.Field{Key: "state", Value: job.State},
+		logger.Field{Key: "issues", Value: job.IssuesDone})
+	return project, syncErr
+}
+
+// startHeartbeat touches the job until the returned function is called, so that other
+// connectors can tell it from a job left by a stopped one
+func (jc *JiraConnector) startHeartbeat(ctx context.Context, id int64, log logger.Logger) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(jc.jobs.heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := jc.repo.TouchSyncJob(ctx, id); err != nil && ctx.Err() == nil {
+					log.Error("Failed to touch sync job", logger.Field{Key: "error", Value: err.Error()})
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,215 @@
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJobsConnector(t *testing.T, repo Repository, api APIClient) *JiraConnector {
+	connector, err := NewJiraConnector(
+		WithRepository(repo),
+		WithAPIClient(api),
+		WithLogger(&logger.TestLogger{}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(connector.Shutdown)
+	return connector
+}
+
+// waitJobState ждет, пока задание перейдет в состояние state
+func waitJobState(t *testing.T, connector *JiraConnector, id int64, state models.SyncJobState) *models.SyncJob {
+	var job *models.SyncJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = connector.GetSyncJob(context.Background(), id)
+		require.NoError(t, err)
+		return job.State == state
+	}, 5*time.Second, time.Millisecond)
+	return job
+}
+
+func TestJiraConnector_StartSync(t *testing.T) {
+	t.Run("RunsInBackground", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(5, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		// StartSync возвращается, не дожидаясь загрузки
+		job, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, models.SyncJobQueued, job.State)
+
+		waitJobState(t, connector, job.ID, models.SyncJobRunning)
+		close(api.gate)
+
+		job = waitJobState(t, connector, job.ID, models.SyncJobSucceeded)
+		assert.Equal(t, 5, job.PagesDone)
+		assert.Equal(t, 5, job.PagesTotal)
+		assert.Equal(t, 10, job.IssuesDone)
+		assert.Equal(t, 10, job.IssuesTotal)
+		assert.False(t, job.StartedAt.IsZero())
+		assert.False(t, job.FinishedAt.IsZero())
+		assert.Empty(t, job.Error)
+	})
+
+	t.Run("ReturnsActiveJob", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(5, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		first, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		second, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+
+		assert.Equal(t, first.ID, second.ID)
+		close(api.gate)
+		waitJobState(t, connector, first.ID, models.SyncJobSucceeded)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(5, 2), failAfter: 2}
+		connector := newJobsConnector(t, repo, api)
+
+		job, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+
+		job = waitJobState(t, connector, job.ID, models.SyncJobFailed)
+		assert.Contains(t, job.Error, "jira is unavailable")
+		assert.Equal(t, 2, job.PagesDone)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(5, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		job, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		waitJobState(t, connector, job.ID, models.SyncJobRunning)
+
+		connector.Shutdown()
+
+		job, err = connector.GetSyncJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.SyncJobFailed, job.State)
+		assert.Equal(t, shutdownReason, job.Error)
+	})
+}
+
+func TestJiraConnector_CancelSyncJob(t *testing.T) {
+	t.Run("RunningJob", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(5, 2), failAfter: -1, gate: make(chan struct{}, 5)}
+		connector := newJobsConnector(t, repo, api)
+
+		job, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+
+		// Пропускаем две страницы и ждем, пока они сохранятся
+		api.gate <- struct{}{}
+		api.gate <- struct{}{}
+		require.Eventually(t, func() bool {
+			job, _ = connector.GetSyncJob(context.Background(), job.ID)
+			return job.PagesDone == 2
+		}, 5*time.Second, time.Millisecond)
+
+		job, err = connector.CancelSyncJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.SyncJobCanceled, job.State)
+
+		// Следующий запуск продолжает с контрольной точки
+		cp, err := repo.GetCheckpoint(context.Background(), "TEST")
+		require.NoError(t, err)
+		require.NotNil(t, cp)
+		assert.Equal(t, 2, cp.PagesCompleted)
+
+		close(api.gate)
+		next, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.NotEqual(t, job.ID, next.ID)
+		waitJobState(t, connector, next.ID, models.SyncJobSucceeded)
+		assert.Equal(t, cp.HighWater, api.froms[1])
+	})
+
+	t.Run("JobOfStoppedConnector", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newJobsConnector(t, repo, &fakeJira{})
+
+		job, _, err := repo.CreateSyncJob(context.Background(), "TEST")
+		require.NoError(t, err)
+		// Остановленный коннектор давно не обновлял задание
+		repo.jobs[0].UpdatedAt = time.Now().Add(-staleJobHeartbeats * defaultJobHeartbeat)
+
+		job, err = connector.CancelSyncJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.SyncJobCanceled, job.State)
+	})
+
+	t.Run("JobRunningElsewhere", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newJobsConnector(t, repo, &fakeJira{})
+
+		job, _, err := repo.CreateSyncJob(context.Background(), "TEST")
+		require.NoError(t, err)
+		job.State = models.SyncJobRunning
+		require.NoError(t, repo.UpdateSyncJob(context.Background(), *job))
+
+		// Задание другого коннектора не отменяется, пока он его обновляет
+		_, err = connector.CancelSyncJob(context.Background(), job.ID)
+		assert.ErrorIs(t, err, ErrSyncJobRunningElsewhere)
+		job, err = connector.GetSyncJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.SyncJobRunning, job.State)
+	})
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		repo := newFakeRepository()
+		api := &fakeJira{pages: createTestPages(2, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+		connector.jobs.heartbeat = 10 * time.Millisecond
+
+		job, err := connector.StartSync(context.Background(), "TEST")
+		require.NoError(t, err)
+		job = waitJobState(t, connector, job.ID, models.SyncJobRunning)
+
+		// Задание обновляется, пока ждет Jira
+		require.Eventually(t, func() bool {
+			current, err := connector.GetSyncJob(context.Background(), job.ID)
+			require.NoError(t, err)
+			return current.UpdatedAt.After(job.UpdatedAt)
+		}, 5*time.Second, time.Millisecond)
+		close(api.gate)
+		waitJobState(t, connector, job.ID, models.SyncJobSucceeded)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		connector := newJobsConnector(t, newFakeRepository(), &fakeJira{})
+
+		_, err := connector.CancelSyncJob(context.Background(), 42)
+		assert.ErrorIs(t, err, ErrSyncJobNotFound)
+	})
+}
+
+func TestJiraConnector_FailInterruptedSyncJobs(t *testing.T) {
+	repo := newFakeRepository()
+	connector := newJobsConnector(t, repo, &fakeJira{})
+
+	job, _, err := repo.CreateSyncJob(context.Background(), "TEST")
+	require.NoError(t, err)
+
+	require.NoError(t, connector.FailInterruptedSyncJobs(context.Background()))
+
+	job, err = connector.GetSyncJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.SyncJobFailed, job.State)
+	assert.Equal(t, shutdownReason, job.Error)
+}
@@ -0,0 +1,31 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// reconcileIssues compares the saved issues of the project with the full list of its issues in Jira,
+// which an incremental sync does not see: issues deleted in Jira are marked deleted and issues moved
+// between projects are found by their Jira id. Failures are only logged, the next sync retries.
+func (jc *JiraConnector) reconcileIssues(ctx context.Context, projectID, projectKey string) {
+	log := jc.logger.With(logger.Field{Key: "project_key", Value: projectKey})
+	refs, err := jc.apiClient.ListIssueRefs(ctx, projectKey)
+	if err != nil {
+		log.Warn("Failed to list issues", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	// an empty list is more likely a permission problem than a project with every issue deleted
+	if len(refs) == 0 {
+		log.Warn("Jira listed no issues, the issues are not reconciled")
+		return
+	}
+	result, err := jc.repo.ReconcileIssues(ctx, projectID, refs)
+	if err != nil {
+		log.Warn("Failed to reconcile issues", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	log.Info("Issues reconciled", logger.Field{Key: "moved", Value: result.Moved},
+		logger.Field{Key: "deleted", Value: result.Deleted})
+}
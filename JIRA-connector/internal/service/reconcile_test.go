@@ -0,0 +1,60 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraConnector_ReconcileIssues(t *testing.T) {
+	newConnector := func(t *testing.T, repo Repository, jira *fakeJira) *JiraConnector {
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(jira),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+		return connector
+	}
+
+	t.Run("DeletedAndMovedIssues", func(t *testing.T) {
+		repo := newFakeRepository()
+		// Сохранены при прошлой синхронизации: TEST-90 удалена в Jira, TEST-91 перенесена в TEST-3
+		repo.issues["TEST-90"] = models.JiraIssue{ID: "10090", Key: "TEST-90"}
+		repo.issues["TEST-91"] = models.JiraIssue{ID: "10091", Key: "TEST-91"}
+		jira := &fakeJira{pages: createTestPages(1, 2), failAfter: -1, refs: []models.IssueRef{
+			{ID: "10001", Key: "TEST-1"},
+			{ID: "10002", Key: "TEST-2"},
+			{ID: "10091", Key: "TEST-3"},
+		}}
+
+		_, err := newConnector(t, repo, jira).UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"TEST-90"}, repo.deleted)
+		assert.Equal(t, "10091", repo.issues["TEST-3"].ID)
+		assert.NotContains(t, repo.issues, "TEST-91")
+		assert.Len(t, repo.issues, 3)
+	})
+
+	t.Run("FailureDoesNotFailSync", func(t *testing.T) {
+		repo := newFakeRepository()
+		repo.issues["TEST-90"] = models.JiraIssue{ID: "10090", Key: "TEST-90"}
+		jira := &fakeJira{pages: createTestPages(1, 2), failAfter: -1,
+			refsErr: errors.New("Jira API error: 503 - Service Unavailable")}
+
+		project, err := newConnector(t, repo, jira).UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, project.TotalIssueCount)
+		assert.Empty(t, repo.deleted)
+		assert.Contains(t, repo.issues, "TEST-90")
+		assert.False(t, repo.projects["TEST"].LastUpdate.IsZero())
+	})
+}
@@ -0,0 +1,284 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sssidkn/jira-connector/internal/config"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// ErrInvalidSchedule is returned for schedules which can't be run
+var ErrInvalidSchedule = errors.New("invalid sync schedule")
+
+const (
+	defaultCron         = "0 * * * *"
+	defaultScheduleRuns = 20
+	maxScheduleRuns     = 100
+)
+
+// scheduler plans the periodic sync of all tracked projects
+type scheduler struct {
+	mu       sync.Mutex
+	schedule models.SyncSchedule
+	cron     cron.Schedule
+	// reload wakes the loop up when the schedule is changed
+	reload chan struct{}
+	jitter func(max time.Duration) time.Duration
+}
+
+func newScheduler() *scheduler {
+	s := &scheduler{reload: make(chan struct{}, 1), jitter: randomJitter}
+	s.cron, _ = cron.ParseStandard(defaultCron)
+	s.schedule = models.SyncSchedule{Cron: defaultCron, MaxConcurrent: 1}
+	return s
+}
+
+func randomJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
+func parseSchedule(schedule models.SyncSchedule) (cron.Schedule, error) {
+	if schedule.MaxConcurrent < 1 {
+		return nil, fmt.Errorf("%w: max concurrent must be positive", ErrInvalidSchedule)
+	}
+	if schedule.Jitter < 0 {
+		return nil, fmt.Errorf("%w: jitter must not be negative", ErrInvalidSchedule)
+	}
+	c, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return c, nil
+}
+
+// set replaces the schedule and plans its next run
+func (s *scheduler) set(schedule models.SyncSchedule, c cron.Schedule, now time.Time) models.SyncSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedule = schedule
+	s.cron = c
+	s.planLocked(now)
+	select {
+	case s.reload <- struct{}{}:
+	default:
+	}
+	return s.schedule
+}
+
+func (s *scheduler) get() models.SyncSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.schedule
+}
+
+// plan plans the next run after now unless it is already planned
+func (s *scheduler) plan(now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.schedule.NextRunAt.IsZero() || !s.schedule.NextRunAt.After(now) {
+		s.planLocked(now)
+	}
+	return s.schedule.NextRunAt
+}
+
+func (s *scheduler) planLocked(now time.Time) {
+	if !s.schedule.Enabled {
+		s.schedule.NextRunAt = time.Time{}
+		return
+	}
+	s.schedule.NextRunAt = s.cron.Next(now).Add(s.jitter(s.schedule.Jitter))
+}
+
+// WithSchedule sets the schedule used until another one is saved through the API
+func WithSchedule(cfg config.SchedulerConfig) Option {
+	return func(jc *JiraConnector) error {
+		schedule := models.SyncSchedule{
+			Enabled:       cfg.Enabled,
+			Cron:          cfg.Cron,
+			Jitter:        cfg.Jitter,
+			MaxConcurrent: cfg.MaxConcurrent,
+		}
+		c, err := parseSchedule(schedule)
+		if err != nil {
+			return err
+		}
+		jc.schedule.set(schedule, c, time.Now())
+		return nil
+	}
+}
+
+// StartScheduler loads the saved schedule and starts the periodic sync of all tracked projects.
+// The scheduler is stopped by Shutdown.
+func (jc *JiraConnector) StartScheduler(ctx context.Context) error {
+	saved, err := jc.repo.GetSyncSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	if saved != nil {
+		c, err := parseSchedule(*saved)
+		if err != nil {
+			return err
+		}
+		jc.schedule.set(*saved, c, time.Now())
+	}
+
+	schedule := jc.schedule.get()
+	jc.logger.Info("Starting sync scheduler", logger.Field{Key: "enabled", Value: schedule.Enabled},
+		logger.Field{Key: "cron", Value: schedule.Cron}, logger.Field{Key: "next_run_at", Value: schedule.NextRunAt})
+
+	jc.jobs.wg.Add(1)
+	go func() {
+		defer jc.jobs.wg.Done()
+		jc.scheduleLoop(jc.jobs.ctx)
+	}()
+	return nil
+}
+
+func (jc *JiraConnector) scheduleLoop(ctx context.Context) {
+	for {
+		var fire <-chan time.Time
+		var timer *time.Timer
+		if next := jc.schedule.plan(time.Now()); !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			fire = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-jc.schedule.reload:
+		case <-fire:
+			// runs which would start while this one is in progress are skipped
+			if _, err := jc.runSchedule(ctx); err != nil {
+				jc.logger.Error("Scheduled sync failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// runSchedule syncs every tracked project, at most MaxConcurrent at once, and saves the outcomes
+func (jc *JiraConnector) runSchedule(ctx context.Context) (*models.ScheduleRun, error) {
+	saveCtx := context.WithoutCancel(ctx)
+	run := models.ScheduleRun{StartedAt: time.Now()}
+	id, err := jc.repo.CreateScheduleRun(ctx, run.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+	run.ID = id
+	log := jc.logger.With(logger.Field{Key: "schedule_run_id", Value: run.ID})
+
+	keys, err := jc.repo.GetProjectKeys(ctx)
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	run.Projects = make([]models.ScheduledSync, len(keys))
+	slots := make(chan struct{}, jc.schedule.get().MaxConcurrent)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			run.Projects[i] = models.ScheduledSync{ProjectKey: key, Outcome: models.ScheduleCanceled, Error: shutdownReason}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			run.Projects[i] = jc.scheduledSync(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	run.FinishedAt = time.Now()
+	if err = jc.repo.FinishScheduleRun(saveCtx, run); err != nil {
+		return nil, err
+	}
+	log.Info("Scheduled sync finished", logger.Field{Key: "succeeded", Value: run.Count(models.ScheduleSucceeded)},
+		logger.Field{Key: "failed", Value: run.Count(models.ScheduleFailed)},
+		logger.Field{Key: "skipped", Value: run.Count(models.ScheduleSkipped)})
+	return &run, nil
+}
+
+// scheduledSync starts a sync of the project and waits for it to finish
+func (jc *JiraConnector) scheduledSync(ctx context.Context, key string) models.ScheduledSync {
+	result := models.ScheduledSync{ProjectKey: key}
+	job, rj, err := jc.startSync(ctx, key)
+	if err != nil {
+		result.Outcome = models.ScheduleFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.JobID = job.ID
+	if rj == nil {
+		result.Outcome = models.ScheduleSkipped
+		return result
+	}
+
+	<-rj.done
+	job, err = jc.GetSyncJob(context.WithoutCancel(ctx), job.ID)
+	if err != nil {
+		result.Outcome = models.ScheduleFailed
+		result.Error = err.Error()
+		return result
+	}
+	switch job.State {
+	case models.SyncJobSucceeded:
+		result.Outcome = models.ScheduleSucceeded
+	case models.SyncJobCanceled:
+		result.Outcome = models.ScheduleCanceled
+	default:
+		result.Outcome = models.ScheduleFailed
+		result.Error = job.Error
+	}
+	return result
+}
+
+// GetSyncSchedule returns the schedule with its next run
+func (jc *JiraConnector) GetSyncSchedule(context.Context) (*models.SyncSchedule, error) {
+	schedule := jc.schedule.get()
+	return &schedule, nil
+}
+
+// UpdateSyncSchedule validates and saves the schedule. It is applied immediately.
+func (jc *JiraConnector) UpdateSyncSchedule(ctx context.Context, schedule models.SyncSchedule) (*models.SyncSchedule, error) {
+	c, err := parseSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	schedule.NextRunAt = time.Time{}
+	schedule.UpdatedAt = time.Now()
+	if err = jc.repo.SaveSyncSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	schedule = jc.schedule.set(schedule, c, time.Now())
+	jc.logger.Info("Sync schedule updated", logger.Field{Key: "enabled", Value: schedule.Enabled},
+		logger.Field{Key: "cron", Value: schedule.Cron}, logger.Field{Key: "next_run_at", Value: schedule.NextRunAt})
+	return &schedule, nil
+}
+
+// ListScheduleRuns returns the latest scheduled runs, newest first
+func (jc *JiraConnector) ListScheduleRuns(ctx context.Context, limit int) ([]models.ScheduleRun, error) {
+	if limit <= 0 {
+		limit = defaultScheduleRuns
+	}
+	return jc.repo.ListScheduleRuns(ctx, min(limit, maxScheduleRuns))
+}
@@ -0,0 +1,252 @@
+package connector
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sssidkn/jira-connector/internal/config"
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// everySchedule запускается через заданный интервал, cron не позволяет интервалы меньше минуты
+type everySchedule time.Duration
+
+func (e everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// trackProjects добавляет проекты в репозиторий, как после первой синхронизации
+func trackProjects(repo *fakeRepository, keys ...string) {
+	for i, key := range keys {
+		repo.projects[key] = &models.ProjectInfo{ID: strconv.Itoa(i + 1), Key: key, Name: key}
+	}
+}
+
+func TestJiraConnector_RunSchedule(t *testing.T) {
+	t.Run("SyncsTrackedProjects", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "ALPHA", "BETA", "GAMMA")
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: -1}
+		connector := newJobsConnector(t, repo, api)
+		connector.schedule.set(models.SyncSchedule{Cron: defaultCron, MaxConcurrent: 2},
+			everySchedule(time.Hour), time.Now())
+
+		// Вызов метода
+		run, err := connector.runSchedule(context.Background())
+		require.NoError(t, err)
+
+		// Проверки
+		require.Len(t, run.Projects, 3)
+		for i, key := range []string{"ALPHA", "BETA", "GAMMA"} {
+			assert.Equal(t, key, run.Projects[i].ProjectKey)
+			assert.Equal(t, models.ScheduleSucceeded, run.Projects[i].Outcome)
+			assert.NotZero(t, run.Projects[i].JobID)
+		}
+		assert.LessOrEqual(t, api.maxActive, 2)
+		assert.False(t, run.FinishedAt.IsZero())
+
+		require.Len(t, repo.runs, 1)
+		assert.Equal(t, *run, repo.runs[0])
+		assert.Equal(t, 3, repo.runs[0].Count(models.ScheduleSucceeded))
+		for _, key := range []string{"ALPHA", "BETA", "GAMMA"} {
+			assert.False(t, repo.projects[key].LastUpdate.IsZero())
+		}
+	})
+
+	t.Run("SkipsRunningProject", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "ALPHA", "BETA")
+		connector := newJobsConnector(t, repo, &fakeJira{pages: createTestPages(3, 2), failAfter: -1})
+
+		active, _, err := repo.CreateSyncJob(context.Background(), "ALPHA")
+		require.NoError(t, err)
+
+		run, err := connector.runSchedule(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, run.Projects, 2)
+		assert.Equal(t, models.ScheduledSync{ProjectKey: "ALPHA", JobID: active.ID, Outcome: models.ScheduleSkipped},
+			run.Projects[0])
+		assert.Equal(t, models.ScheduleSucceeded, run.Projects[1].Outcome)
+	})
+
+	t.Run("RecordsFailures", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "ALPHA", "BETA")
+		connector := newJobsConnector(t, repo, &fakeJira{pages: createTestPages(3, 2), failAfter: 1})
+
+		run, err := connector.runSchedule(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, run.Projects, 2)
+		assert.Equal(t, models.ScheduleFailed, run.Projects[0].Outcome)
+		assert.Contains(t, run.Projects[0].Error, "jira is unavailable")
+		assert.Equal(t, models.ScheduleSucceeded, run.Projects[1].Outcome)
+		assert.Equal(t, 1, run.Count(models.ScheduleFailed))
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "ALPHA", "BETA")
+		api := &fakeJira{pages: createTestPages(3, 2), failAfter: -1, gate: make(chan struct{})}
+		connector := newJobsConnector(t, repo, api)
+
+		done := make(chan *models.ScheduleRun)
+		go func() {
+			run, err := connector.runSchedule(connector.jobs.ctx)
+			assert.NoError(t, err)
+			done <- run
+		}()
+		require.Eventually(t, func() bool {
+			job, _ := repo.GetSyncJob(context.Background(), 1)
+			return job != nil && job.State == models.SyncJobRunning
+		}, 5*time.Second, time.Millisecond)
+
+		connector.jobs.stop()
+		run := <-done
+
+		assert.Equal(t, models.ScheduledSync{ProjectKey: "ALPHA", JobID: 1, Outcome: models.ScheduleFailed,
+			Error: shutdownReason}, run.Projects[0])
+		assert.Equal(t, models.ScheduleCanceled, run.Projects[1].Outcome)
+		assert.False(t, repo.runs[0].FinishedAt.IsZero())
+	})
+}
+
+func TestJiraConnector_StartScheduler(t *testing.T) {
+	repo := newFakeRepository()
+	trackProjects(repo, "ALPHA")
+	connector := newJobsConnector(t, repo, &fakeJira{pages: createTestPages(3, 2), failAfter: -1})
+	connector.schedule.set(models.SyncSchedule{Enabled: true, Cron: defaultCron, MaxConcurrent: 1},
+		everySchedule(10*time.Millisecond), time.Now())
+
+	require.NoError(t, connector.StartScheduler(context.Background()))
+
+	// Планировщик запускает синхронизацию по расписанию
+	require.Eventually(t, func() bool {
+		runs, err := repo.ListScheduleRuns(context.Background(), 2)
+		require.NoError(t, err)
+		return len(runs) == 2 && !runs[1].FinishedAt.IsZero()
+	}, 5*time.Second, time.Millisecond)
+
+	// Отключенное расписание больше не запускается
+	_, err := connector.UpdateSyncSchedule(context.Background(),
+		models.SyncSchedule{Enabled: false, Cron: defaultCron, MaxConcurrent: 1})
+	require.NoError(t, err)
+	repo.mu.Lock()
+	count := len(repo.runs)
+	repo.mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	repo.mu.Lock()
+	assert.LessOrEqual(t, len(repo.runs), count+1)
+	repo.mu.Unlock()
+}
+
+func TestJiraConnector_UpdateSyncSchedule(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newJobsConnector(t, repo, &fakeJira{})
+		connector.schedule.jitter = func(max time.Duration) time.Duration { return max }
+
+		// Вызов метода
+		schedule, err := connector.UpdateSyncSchedule(context.Background(), models.SyncSchedule{
+			Enabled: true, Cron: "30 */6 * * *", Jitter: 5 * time.Minute, MaxConcurrent: 3,
+		})
+		require.NoError(t, err)
+
+		// Проверки
+		c, err := cron.ParseStandard("30 */6 * * *")
+		require.NoError(t, err)
+		assert.WithinDuration(t, c.Next(time.Now()).Add(5*time.Minute), schedule.NextRunAt, time.Minute)
+		assert.Equal(t, 3, schedule.MaxConcurrent)
+
+		require.NotNil(t, repo.schedule)
+		assert.Equal(t, "30 */6 * * *", repo.schedule.Cron)
+		assert.True(t, repo.schedule.NextRunAt.IsZero())
+		assert.False(t, repo.schedule.UpdatedAt.IsZero())
+
+		got, err := connector.GetSyncSchedule(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, schedule, got)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		connector := newJobsConnector(t, newFakeRepository(), &fakeJira{})
+
+		schedule, err := connector.UpdateSyncSchedule(context.Background(),
+			models.SyncSchedule{Cron: defaultCron, MaxConcurrent: 1})
+		require.NoError(t, err)
+		assert.True(t, schedule.NextRunAt.IsZero())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			schedule models.SyncSchedule
+		}{
+			{name: "Cron", schedule: models.SyncSchedule{Cron: "every hour", MaxConcurrent: 1}},
+			{name: "MaxConcurrent", schedule: models.SyncSchedule{Cron: defaultCron}},
+			{name: "Jitter", schedule: models.SyncSchedule{Cron: defaultCron, MaxConcurrent: 1, Jitter: -time.Second}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := newFakeRepository()
+				connector := newJobsConnector(t, repo, &fakeJira{})
+
+				_, err := connector.UpdateSyncSchedule(context.Background(), tt.schedule)
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				assert.Nil(t, repo.schedule)
+			})
+		}
+	})
+
+	t.Run("SavedScheduleOverridesConfig", func(t *testing.T) {
+		repo := newFakeRepository()
+		repo.schedule = &models.SyncSchedule{Cron: "0 3 * * *", MaxConcurrent: 4}
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{}),
+			WithLogger(&logger.TestLogger{}),
+			WithSchedule(config.SchedulerConfig{Enabled: true, Cron: defaultCron, MaxConcurrent: 1}),
+		)
+		require.NoError(t, err)
+		t.Cleanup(connector.Shutdown)
+
+		require.NoError(t, connector.StartScheduler(context.Background()))
+
+		schedule, err := connector.GetSyncSchedule(context.Background())
+		require.NoError(t, err)
+		assert.False(t, schedule.Enabled)
+		assert.Equal(t, "0 3 * * *", schedule.Cron)
+		assert.Equal(t, 4, schedule.MaxConcurrent)
+	})
+}
+
+func TestWithSchedule(t *testing.T) {
+	_, err := NewJiraConnector(WithSchedule(config.SchedulerConfig{Cron: "61 * * * *", MaxConcurrent: 1}))
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+}
+
+func TestJiraConnector_ListScheduleRuns(t *testing.T) {
+	repo := newFakeRepository()
+	connector := newJobsConnector(t, repo, &fakeJira{})
+	for i := 0; i < defaultScheduleRuns+5; i++ {
+		_, err := repo.CreateScheduleRun(context.Background(), time.Now())
+		require.NoError(t, err)
+	}
+
+	runs, err := connector.ListScheduleRuns(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, runs, defaultScheduleRuns)
+	assert.Equal(t, int64(defaultScheduleRuns+5), runs[0].ID)
+
+	runs, err = connector.ListScheduleRuns(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Len(t, runs, 3)
+}
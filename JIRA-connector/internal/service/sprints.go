@@ -0,0 +1,59 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// ErrProjectNotFound is returned for projects which are not synced yet
+var ErrProjectNotFound = errors.New("project not found")
+
+type AgileClient interface {
+	GetProjectSprints(ctx context.Context, projectKey string) (*models.ProjectSprints, error)
+}
+
+// WithAgileClient syncs the boards and sprints of a project after its issues
+func WithAgileClient(agile AgileClient) Option {
+	return func(jc *JiraConnector) error {
+		if agile == nil {
+			return fmt.Errorf("agile client is nil")
+		}
+		jc.agile = agile
+		return nil
+	}
+}
+
+// syncSprints saves the boards and sprints of the project. Failures are only logged,
+// Jira instances without Jira Software have no agile API.
+func (jc *JiraConnector) syncSprints(ctx context.Context, projectKey string) {
+	if jc.agile == nil {
+		return
+	}
+	log := jc.logger.With(logger.Field{Key: "project_key", Value: projectKey})
+	sprints, err := jc.agile.GetProjectSprints(ctx, projectKey)
+	if err != nil {
+		log.Warn("Failed to fetch sprints", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	if err = jc.repo.SaveSprints(ctx, *sprints); err != nil {
+		log.Warn("Failed to save sprints", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	log.Info("Sprints saved", logger.Field{Key: "sprints", Value: len(sprints.Sprints)})
+}
+
+// ListSprints returns the sprints of a synced project with the project issues in them
+func (jc *JiraConnector) ListSprints(ctx context.Context, projectKey string) ([]models.Sprint, error) {
+	projectInfo, err := jc.repo.GetProjectInfo(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	if projectInfo == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectKey)
+	}
+	return jc.repo.ListSprints(ctx, projectKey)
+}
@@ -0,0 +1,112 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAgile отдает заданные спринты или ошибку
+type fakeAgile struct {
+	mu      sync.Mutex
+	sprints []models.Sprint
+	err     error
+	calls   int
+}
+
+func (a *fakeAgile) GetProjectSprints(_ context.Context, projectKey string) (*models.ProjectSprints, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls++
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &models.ProjectSprints{
+		ProjectKey: projectKey,
+		Boards:     []models.Board{{ID: 1, Name: "TEST board", Type: "scrum"}},
+		Sprints:    a.sprints,
+	}, nil
+}
+
+func TestJiraConnector_SyncSprints(t *testing.T) {
+	start := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	sprints := []models.Sprint{
+		{
+			ID: 1, BoardID: 1, Name: "Sprint 1", State: "closed",
+			StartDate:    models.JiraTime{Time: start},
+			EndDate:      models.JiraTime{Time: start.Add(14 * 24 * time.Hour)},
+			CompleteDate: models.JiraTime{Time: start.Add(14 * 24 * time.Hour)},
+			IssueKeys:    []string{"TEST-1", "TEST-2", "OTHER-1"},
+		},
+		{ID: 2, BoardID: 1, Name: "Sprint 2", State: "future", IssueKeys: []string{"TEST-3"}},
+	}
+
+	t.Run("SavedAfterIssues", func(t *testing.T) {
+		repo := newFakeRepository()
+		agile := &fakeAgile{sprints: sprints}
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{pages: createTestPages(2, 2), failAfter: -1}),
+			WithAgileClient(agile),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+
+		_, err = connector.UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, 1, agile.calls)
+
+		result, err := connector.ListSprints(context.Background(), "TEST")
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "Sprint 1", result[0].Name)
+		assert.Equal(t, start, result[0].StartDate.Time)
+		// Задачи других проектов не сохранены и не попадают в спринт
+		assert.Equal(t, []string{"TEST-1", "TEST-2"}, result[0].IssueKeys)
+		assert.True(t, result[1].StartDate.IsZero())
+		assert.Equal(t, []string{"TEST-3"}, result[1].IssueKeys)
+	})
+
+	t.Run("AgileFailureDoesNotFailSync", func(t *testing.T) {
+		repo := newFakeRepository()
+		agile := &fakeAgile{err: errors.New("Jira API error: 404 - Not Found")}
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{pages: createTestPages(1, 2), failAfter: -1}),
+			WithAgileClient(agile),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+
+		project, err := connector.UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, 2, project.TotalIssueCount)
+		assert.Equal(t, 1, agile.calls)
+		assert.Empty(t, repo.sprints)
+	})
+}
+
+func TestJiraConnector_ListSprints(t *testing.T) {
+	connector, err := NewJiraConnector(
+		WithRepository(newFakeRepository()),
+		WithAPIClient(&fakeJira{}),
+		WithLogger(&logger.TestLogger{}),
+	)
+	require.NoError(t, err)
+
+	_, err = connector.ListSprints(context.Background(), "NONE")
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+func TestWithAgileClient(t *testing.T) {
+	_, err := NewJiraConnector(WithAgileClient(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// syncVersions saves the versions of the project with their release dates. Failures are only logged,
+// the issues refer to their versions on their own.
+func (jc *JiraConnector) syncVersions(ctx context.Context, projectKey string) {
+	log := jc.logger.With(logger.Field{Key: "project_key", Value: projectKey})
+	versions, err := jc.apiClient.GetProjectVersions(ctx, projectKey)
+	if err != nil {
+		log.Warn("Failed to fetch versions", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	if err = jc.repo.SaveVersions(ctx, projectKey, versions); err != nil {
+		log.Warn("Failed to save versions", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	log.Info("Versions saved", logger.Field{Key: "versions", Value: len(versions)})
+}
@@ -0,0 +1,53 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraConnector_SyncVersions(t *testing.T) {
+	versions := []models.Version{
+		{ID: "10100", Name: "1.0", Released: true,
+			ReleaseDate: models.JiraTime{Time: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)}},
+		{ID: "10101", Name: "1.1"},
+	}
+
+	t.Run("SavedAfterIssues", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{pages: createTestPages(1, 2), failAfter: -1, versions: versions}),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+
+		_, err = connector.UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, versions, repo.versions["TEST"])
+	})
+
+	t.Run("FailureDoesNotFailSync", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{pages: createTestPages(1, 2), failAfter: -1,
+				versionsErr: errors.New("Jira API error: 403 - Forbidden")}),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+
+		project, err := connector.UpdateProject(context.Background(), "TEST")
+		require.NoError(t, err)
+		assert.Equal(t, 2, project.TotalIssueCount)
+		assert.Empty(t, repo.versions)
+		assert.False(t, repo.projects["TEST"].LastUpdate.IsZero())
+	})
+}
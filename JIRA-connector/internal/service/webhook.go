@@ -0,0 +1,44 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+// HandleIssueEvent applies a Jira issue webhook to the database. Events of projects which
+// are not tracked yet and unknown events are ignored.
+func (jc *JiraConnector) HandleIssueEvent(ctx context.Context, event models.IssueEvent) error {
+	log := jc.logger.With(logger.Field{Key: "event", Value: event.WebhookEvent},
+		logger.Field{Key: "issue_key", Value: event.Issue.Key})
+
+	switch event.WebhookEvent {
+	case models.IssueCreated, models.IssueUpdated:
+		projectKey := event.Issue.Fields.Project.Key
+		projectInfo, err := jc.repo.GetProjectInfo(ctx, projectKey)
+		if err != nil {
+			return err
+		}
+		if projectInfo == nil {
+			log.Debug("Ignoring issue event of untracked project", logger.Field{Key: "project_key", Value: projectKey})
+			return nil
+		}
+		// the epic link and custom fields are not read from webhooks, SaveIssues keeps the saved ones
+		issue := event.IssueWithChanges()
+		issue.Render(jc.descriptionFormat)
+		if err = jc.repo.SaveIssues(ctx, projectInfo.ID, []models.JiraIssue{issue}); err != nil {
+			return err
+		}
+		log.Debug("Issue saved from webhook")
+	case models.IssueDeleted:
+		deleted, err := jc.repo.MarkIssueDeleted(ctx, event.Issue.Key)
+		if err != nil {
+			return err
+		}
+		log.Debug("Issue marked deleted from webhook", logger.Field{Key: "deleted", Value: deleted})
+	default:
+		log.Debug("Ignoring unsupported webhook event")
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/adf"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// issueEvent создает событие вебхука для задачи проекта TEST
+func issueEvent(eventType models.IssueEventType, status string, updated time.Time) models.IssueEvent {
+	event := models.IssueEvent{
+		Timestamp:    updated.Add(time.Second).UnixMilli(),
+		WebhookEvent: eventType,
+		User:         models.JiraUser{Name: "asmith", DisplayName: "Alice Smith"},
+	}
+	event.Issue.ID = "10101"
+	event.Issue.Key = "TEST-101"
+	event.Issue.Fields.Project.Key = "TEST"
+	event.Issue.Fields.Status.Name = status
+	event.Issue.Fields.Updated = models.JiraTime{Time: updated}
+	return event
+}
+
+func TestJiraConnector_HandleIssueEvent(t *testing.T) {
+	updated := time.Date(2025, 5, 1, 14, 0, 0, 0, time.UTC)
+
+	newConnector := func(t *testing.T, repo Repository) *JiraConnector {
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{}),
+			WithLogger(&logger.TestLogger{}),
+		)
+		require.NoError(t, err)
+		return connector
+	}
+
+	t.Run("CreatedAndUpdated", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "TEST")
+		connector := newConnector(t, repo)
+
+		// Создание задачи
+		err := connector.HandleIssueEvent(context.Background(), issueEvent(models.IssueCreated, "Open", updated))
+		require.NoError(t, err)
+		require.Contains(t, repo.issues, "TEST-101")
+		assert.Empty(t, repo.issues["TEST-101"].Changelogs.Histories)
+
+		// Смена статуса сохраняется как история задачи
+		event := issueEvent(models.IssueUpdated, "In Progress", updated.Add(time.Hour))
+		event.Changelog.Items = []models.Item{{Field: "status", FromString: "Open", ToString: "In Progress"}}
+		err = connector.HandleIssueEvent(context.Background(), event)
+		require.NoError(t, err)
+
+		issue := repo.issues["TEST-101"]
+		assert.Equal(t, "In Progress", issue.Fields.Status.Name)
+		require.Len(t, issue.Changelogs.Histories, 1)
+		assert.Equal(t, updated.Add(time.Hour), issue.Changelogs.Histories[0].Created.Time)
+		assert.Equal(t, "Alice Smith", issue.Changelogs.Histories[0].Author.DisplayName)
+	})
+
+	t.Run("RendersDescription", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "TEST")
+		connector, err := NewJiraConnector(
+			WithRepository(repo),
+			WithAPIClient(&fakeJira{}),
+			WithLogger(&logger.TestLogger{}),
+			WithDescriptionFormat(adf.FormatMarkdown),
+		)
+		require.NoError(t, err)
+
+		// Описание в ADF сохраняется в том же формате, что и при синхронизации
+		event := issueEvent(models.IssueUpdated, "Open", updated)
+		require.NoError(t, json.Unmarshal([]byte(`{"type":"doc","content":[{"type":"paragraph","content":`+
+			`[{"type":"text","text":"bold","marks":[{"type":"strong"}]}]}]}`), &event.Issue.Fields.Description))
+		err = connector.HandleIssueEvent(context.Background(), event)
+		require.NoError(t, err)
+		assert.Equal(t, "**bold**", repo.issues["TEST-101"].Fields.Description.Text)
+	})
+
+	t.Run("KeepsEpicLink", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "TEST")
+		issue := models.JiraIssue{Key: "TEST-101"}
+		issue.Fields.EpicLink = "TEST-1"
+		repo.issues["TEST-101"] = issue
+		connector := newConnector(t, repo)
+
+		// В вебхуке нет поля Epic Link из EpicLinkField, связь с эпиком не теряется
+		err := connector.HandleIssueEvent(context.Background(), issueEvent(models.IssueUpdated, "In Progress", updated))
+		require.NoError(t, err)
+		assert.Equal(t, "In Progress", repo.issues["TEST-101"].Fields.Status.Name)
+		assert.Equal(t, "TEST-1", repo.issues["TEST-101"].Fields.EpicLink)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		repo := newFakeRepository()
+		trackProjects(repo, "TEST")
+		connector := newConnector(t, repo)
+		require.NoError(t, connector.HandleIssueEvent(context.Background(),
+			issueEvent(models.IssueCreated, "Open", updated)))
+
+		err := connector.HandleIssueEvent(context.Background(), issueEvent(models.IssueDeleted, "Open", updated))
+		require.NoError(t, err)
+		assert.NotContains(t, repo.issues, "TEST-101")
+		assert.Equal(t, []string{"TEST-101"}, repo.deleted)
+
+		// Повторная доставка не считается ошибкой и не помечает задачу снова
+		err = connector.HandleIssueEvent(context.Background(), issueEvent(models.IssueDeleted, "Open", updated))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"TEST-101"}, repo.deleted)
+	})
+
+	t.Run("UntrackedProject", func(t *testing.T) {
+		repo := newFakeRepository()
+		connector := newConnector(t, repo)
+
+		err := connector.HandleIssueEvent(context.Background(), issueEvent(models.IssueUpdated, "Open", updated))
+		require.NoError(t, err)
+		assert.Empty(t, repo.issues)
+		assert.Empty(t, repo.projects)
+	})
+
+	t.Run("UnsupportedEvent", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		connector := newConnector(t, mockRepo)
+
+		err := connector.HandleIssueEvent(context.Background(), issueEvent("comment_created", "Open", updated))
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "SaveIssues", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		connector := newConnector(t, mockRepo)
+
+		// Настройка моков
+		mockRepo.On("GetProjectInfo", mock.Anything, "TEST").
+			Return(&models.ProjectInfo{ID: "1", Key: "TEST"}, nil)
+		mockRepo.On("SaveIssues", mock.Anything, "1", mock.Anything).Return(errors.New("db error"))
+
+		// Вызов метода
+		err := connector.HandleIssueEvent(context.Background(), issueEvent(models.IssueUpdated, "Open", updated))
+
+		// Проверки
+		assert.EqualError(t, err, "db error")
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -1,16 +1,26 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"github.com/sssidkn/jira-connector/internal/export"
+	"github.com/sssidkn/jira-connector/internal/jira"
 	"github.com/sssidkn/jira-connector/internal/models"
+	connector "github.com/sssidkn/jira-connector/internal/service"
 	connectorApi "github.com/sssidkn/jira-connector/pkg/api/connector"
 	"github.com/sssidkn/jira-connector/pkg/logger"
+	"io"
 	"net"
 	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Option func(*GRPCServer)
@@ -18,18 +28,34 @@ type Option func(*GRPCServer)
 type Service interface {
 	UpdateProject(ctx context.Context, projectKey string) (*models.JiraProject, error)
 	GetProjects(ctx context.Context, limit, page int, search string) (*connectorApi.GetProjectsResponse, error)
+	StartSync(ctx context.Context, projectKey string) (*models.SyncJob, error)
+	GetSyncJob(ctx context.Context, id int64) (*models.SyncJob, error)
+	CancelSyncJob(ctx context.Context, id int64) (*models.SyncJob, error)
+	WatchSync(ctx context.Context, projectKey string, send func(models.SyncEvent) error) error
+	GetSyncSchedule(ctx context.Context) (*models.SyncSchedule, error)
+	UpdateSyncSchedule(ctx context.Context, schedule models.SyncSchedule) (*models.SyncSchedule, error)
+	ListScheduleRuns(ctx context.Context, limit int) ([]models.ScheduleRun, error)
+	ImportProjects(ctx context.Context, r io.Reader, format export.Format, opts ...export.Option) ([]models.JiraProject, error)
+	ListSprints(ctx context.Context, projectKey string) ([]models.Sprint, error)
+	Health(ctx context.Context) models.JiraHealth
 }
 
+// maxRecvMsgSize allows importing exports of large projects
+const maxRecvMsgSize = 256 << 20
+
 type GRPCServer struct {
 	connectorApi.UnimplementedJiraConnectorServer
-	server  *grpc.Server
+	server *grpc.Server
+	// service serves the default source
 	service Service
+	// sources serve the other Jira sources by id
+	sources map[string]Service
 	wg      *sync.WaitGroup
 	logger  *logger.Logger
 }
 
 func NewGRPCServer(options ...Option) *GRPCServer {
-	srv := &GRPCServer{}
+	srv := &GRPCServer{sources: make(map[string]Service)}
 	srv.wg = &sync.WaitGroup{}
 	for _, opt := range options {
 		opt(srv)
@@ -43,6 +69,13 @@ func WithService(service Service) Option {
 	}
 }
 
+// WithSource serves the requests of the source with the given id
+func WithSource(id string, service Service) Option {
+	return func(s *GRPCServer) {
+		s.sources[id] = service
+	}
+}
+
 func WithLogger(log logger.Logger) Option {
 	if log == nil {
 		log = logger.NewLogrusLogger()
@@ -52,12 +85,28 @@ func WithLogger(log logger.Logger) Option {
 	}
 }
 
+// source returns the service of the source, the default one if id is empty
+func (s *GRPCServer) source(id string) (Service, error) {
+	if id == "" || id == models.DefaultSource {
+		return s.service, nil
+	}
+	service, ok := s.sources[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown source %q", id)
+	}
+	return service, nil
+}
+
 func (s *GRPCServer) UpdateProject(ctx context.Context,
 	req *connectorApi.UpdateProjectRequest) (*connectorApi.UpdateProjectResponse, error) {
-	project, err := s.service.UpdateProject(ctx, req.GetProjectKey())
+	service, err := s.source(req.GetSource())
 	if err != nil {
 		return nil, err
 	}
+	project, err := service.UpdateProject(ctx, req.GetProjectKey())
+	if err != nil {
+		return nil, jiraError(err)
+	}
 
 	return &connectorApi.UpdateProjectResponse{
 		Project: &connectorApi.JiraProject{
@@ -71,14 +120,362 @@ func (s *GRPCServer) UpdateProject(ctx context.Context,
 }
 
 func (s *GRPCServer) GetProjects(ctx context.Context, req *connectorApi.GetProjectsRequest) (*connectorApi.GetProjectsResponse, error) {
-	response, err := s.service.GetProjects(ctx, int(req.GetLimit()), int(req.GetPage()), req.GetSearch())
+	service, err := s.source(req.GetSource())
 	if err != nil {
 		return nil, err
 	}
+	response, err := service.GetProjects(ctx, int(req.GetLimit()), int(req.GetPage()), req.GetSearch())
+	if err != nil {
+		return nil, jiraError(err)
+	}
 
 	return response, nil
 }
 
+func (s *GRPCServer) StartSync(ctx context.Context, req *connectorApi.StartSyncRequest) (*connectorApi.SyncJob, error) {
+	if req.GetProjectKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project key is required")
+	}
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	job, err := service.StartSync(ctx, req.GetProjectKey())
+	if err != nil {
+		return nil, err
+	}
+	return syncJobToProto(job), nil
+}
+
+func (s *GRPCServer) GetSyncJob(ctx context.Context, req *connectorApi.GetSyncJobRequest) (*connectorApi.SyncJob, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	job, err := service.GetSyncJob(ctx, req.GetId())
+	if err != nil {
+		return nil, syncJobError(err)
+	}
+	return syncJobToProto(job), nil
+}
+
+func (s *GRPCServer) CancelSyncJob(ctx context.Context,
+	req *connectorApi.CancelSyncJobRequest) (*connectorApi.SyncJob, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	job, err := service.CancelSyncJob(ctx, req.GetId())
+	if err != nil {
+		return nil, syncJobError(err)
+	}
+	return syncJobToProto(job), nil
+}
+
+func (s *GRPCServer) WatchSync(req *connectorApi.WatchSyncRequest,
+	stream grpc.ServerStreamingServer[connectorApi.SyncEvent]) error {
+	if req.GetProjectKey() == "" {
+		return status.Error(codes.InvalidArgument, "project key is required")
+	}
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return err
+	}
+	err = service.WatchSync(stream.Context(), req.GetProjectKey(), func(event models.SyncEvent) error {
+		return stream.Send(syncEventToProto(event))
+	})
+	return jiraError(err)
+}
+
+// jiraError makes the clients retry later instead of failing while the circuit breaker is open
+// and reports a sync rejected because another job syncs the project
+func jiraError(err error) error {
+	if errors.Is(err, jira.ErrJiraUnavailable) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if errors.Is(err, connector.ErrSyncActive) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func syncEventToProto(event models.SyncEvent) *connectorApi.SyncEvent {
+	pe := &connectorApi.SyncEvent{
+		JobId:      event.JobID,
+		ProjectKey: event.ProjectKey,
+		Time:       timestampOrNil(event.Time),
+	}
+	switch event.Type {
+	case models.SyncEventTotalDiscovered:
+		pe.Event = &connectorApi.SyncEvent_TotalDiscovered{TotalDiscovered: &connectorApi.TotalDiscovered{
+			Issues: int64(event.Issues),
+			Pages:  int64(event.Pages),
+		}}
+	case models.SyncEventPageFetched:
+		pe.Event = &connectorApi.SyncEvent_PageFetched{PageFetched: &connectorApi.PageFetched{
+			Page:   int64(event.Page),
+			Issues: int64(event.Issues),
+		}}
+	case models.SyncEventPaused:
+		pe.Event = &connectorApi.SyncEvent_RateLimitPaused{RateLimitPaused: &connectorApi.RateLimitPaused{
+			RetryAfter: durationpb.New(event.RetryAfter),
+		}}
+	case models.SyncEventBatchCommitted:
+		pe.Event = &connectorApi.SyncEvent_BatchCommitted{BatchCommitted: &connectorApi.BatchCommitted{
+			Page:       int64(event.Page),
+			Issues:     int64(event.Issues),
+			IssuesDone: int64(event.IssuesDone),
+		}}
+	case models.SyncEventCompleted:
+		pe.Event = &connectorApi.SyncEvent_Completed{Completed: &connectorApi.SyncCompleted{
+			Issues: int64(event.Issues),
+		}}
+	case models.SyncEventFailed:
+		pe.Event = &connectorApi.SyncEvent_Failed{Failed: &connectorApi.SyncFailed{
+			Error:    event.Error,
+			Canceled: event.Canceled,
+		}}
+	}
+	return pe
+}
+
+func syncJobError(err error) error {
+	if errors.Is(err, connector.ErrSyncJobNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, connector.ErrSyncJobRunningElsewhere) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+var syncJobStates = map[models.SyncJobState]connectorApi.SyncJobState{
+	models.SyncJobQueued:    connectorApi.SyncJobState_SYNC_JOB_STATE_QUEUED,
+	models.SyncJobRunning:   connectorApi.SyncJobState_SYNC_JOB_STATE_RUNNING,
+	models.SyncJobSucceeded: connectorApi.SyncJobState_SYNC_JOB_STATE_SUCCEEDED,
+	models.SyncJobFailed:    connectorApi.SyncJobState_SYNC_JOB_STATE_FAILED,
+	models.SyncJobCanceled:  connectorApi.SyncJobState_SYNC_JOB_STATE_CANCELED,
+}
+
+func syncJobToProto(job *models.SyncJob) *connectorApi.SyncJob {
+	return &connectorApi.SyncJob{
+		Id:          job.ID,
+		ProjectKey:  job.ProjectKey,
+		State:       syncJobStates[job.State],
+		PagesDone:   int64(job.PagesDone),
+		PagesTotal:  int64(job.PagesTotal),
+		IssuesDone:  int64(job.IssuesDone),
+		IssuesTotal: int64(job.IssuesTotal),
+		Error:       job.Error,
+		CreatedAt:   timestampOrNil(job.CreatedAt),
+		StartedAt:   timestampOrNil(job.StartedAt),
+		FinishedAt:  timestampOrNil(job.FinishedAt),
+		UpdatedAt:   timestampOrNil(job.UpdatedAt),
+	}
+}
+
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (s *GRPCServer) GetSyncSchedule(ctx context.Context,
+	req *connectorApi.GetSyncScheduleRequest) (*connectorApi.SyncSchedule, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := service.GetSyncSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return syncScheduleToProto(schedule), nil
+}
+
+func (s *GRPCServer) UpdateSyncSchedule(ctx context.Context,
+	req *connectorApi.UpdateSyncScheduleRequest) (*connectorApi.SyncSchedule, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := service.UpdateSyncSchedule(ctx, models.SyncSchedule{
+		Enabled:       req.GetEnabled(),
+		Cron:          req.GetCron(),
+		Jitter:        req.GetJitter().AsDuration(),
+		MaxConcurrent: int(req.GetMaxConcurrent()),
+	})
+	if errors.Is(err, connector.ErrInvalidSchedule) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return syncScheduleToProto(schedule), nil
+}
+
+func (s *GRPCServer) ListScheduleRuns(ctx context.Context,
+	req *connectorApi.ListScheduleRunsRequest) (*connectorApi.ListScheduleRunsResponse, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	runs, err := service.ListScheduleRuns(ctx, int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	resp := &connectorApi.ListScheduleRunsResponse{Runs: make([]*connectorApi.ScheduleRun, 0, len(runs))}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, scheduleRunToProto(run))
+	}
+	return resp, nil
+}
+
+func syncScheduleToProto(schedule *models.SyncSchedule) *connectorApi.SyncSchedule {
+	return &connectorApi.SyncSchedule{
+		Enabled:       schedule.Enabled,
+		Cron:          schedule.Cron,
+		Jitter:        durationpb.New(schedule.Jitter),
+		MaxConcurrent: int64(schedule.MaxConcurrent),
+		NextRunAt:     timestampOrNil(schedule.NextRunAt),
+		UpdatedAt:     timestampOrNil(schedule.UpdatedAt),
+	}
+}
+
+var scheduleOutcomes = map[models.ScheduleOutcome]connectorApi.ScheduleOutcome{
+	models.ScheduleSucceeded: connectorApi.ScheduleOutcome_SCHEDULE_OUTCOME_SUCCEEDED,
+	models.ScheduleFailed:    connectorApi.ScheduleOutcome_SCHEDULE_OUTCOME_FAILED,
+	models.ScheduleCanceled:  connectorApi.ScheduleOutcome_SCHEDULE_OUTCOME_CANCELED,
+	models.ScheduleSkipped:   connectorApi.ScheduleOutcome_SCHEDULE_OUTCOME_SKIPPED,
+}
+
+func scheduleRunToProto(run models.ScheduleRun) *connectorApi.ScheduleRun {
+	pr := &connectorApi.ScheduleRun{
+		Id:         run.ID,
+		StartedAt:  timestampOrNil(run.StartedAt),
+		FinishedAt: timestampOrNil(run.FinishedAt),
+		Succeeded:  int64(run.Count(models.ScheduleSucceeded)),
+		Failed:     int64(run.Count(models.ScheduleFailed)),
+		Skipped:    int64(run.Count(models.ScheduleSkipped)),
+		Error:      run.Error,
+		Projects:   make([]*connectorApi.ScheduledSync, 0, len(run.Projects)),
+	}
+	for _, p := range run.Projects {
+		pr.Projects = append(pr.Projects, &connectorApi.ScheduledSync{
+			ProjectKey: p.ProjectKey,
+			JobId:      p.JobID,
+			Outcome:    scheduleOutcomes[p.Outcome],
+			Error:      p.Error,
+		})
+	}
+	return pr
+}
+
+var importFormats = map[connectorApi.ImportFormat]export.Format{
+	connectorApi.ImportFormat_IMPORT_FORMAT_UNSPECIFIED: export.FormatAuto,
+	connectorApi.ImportFormat_IMPORT_FORMAT_JSON:        export.FormatJSON,
+	connectorApi.ImportFormat_IMPORT_FORMAT_XML:         export.FormatXML,
+}
+
+func (s *GRPCServer) ImportProjects(ctx context.Context,
+	req *connectorApi.ImportProjectsRequest) (*connectorApi.ImportProjectsResponse, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	format, ok := importFormats[req.GetFormat()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown import format")
+	}
+	var opts []export.Option
+	if req.GetTimezone() != "" {
+		loc, err := time.LoadLocation(req.GetTimezone())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid timezone: %v", err))
+		}
+		opts = append(opts, export.WithLocation(loc))
+	}
+
+	projects, err := service.ImportProjects(ctx, bytes.NewReader(req.GetData()), format, opts...)
+	if errors.Is(err, connector.ErrInvalidExport) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &connectorApi.ImportProjectsResponse{Projects: make([]*connectorApi.ImportedProject, 0, len(projects))}
+	for _, project := range projects {
+		resp.Projects = append(resp.Projects, &connectorApi.ImportedProject{
+			Project: &connectorApi.JiraProject{
+				Id:   project.ID,
+				Key:  project.Key,
+				Name: project.Name,
+			},
+			Issues: int64(project.TotalIssueCount),
+		})
+	}
+	return resp, nil
+}
+
+func (s *GRPCServer) ListSprints(ctx context.Context,
+	req *connectorApi.ListSprintsRequest) (*connectorApi.ListSprintsResponse, error) {
+	if req.GetProjectKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project key is required")
+	}
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	sprints, err := service.ListSprints(ctx, req.GetProjectKey())
+	if errors.Is(err, connector.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &connectorApi.ListSprintsResponse{Sprints: make([]*connectorApi.Sprint, 0, len(sprints))}
+	for _, sprint := range sprints {
+		resp.Sprints = append(resp.Sprints, &connectorApi.Sprint{
+			Id:           int64(sprint.ID),
+			BoardId:      int64(sprint.BoardID),
+			Name:         sprint.Name,
+			State:        sprint.State,
+			Goal:         sprint.Goal,
+			StartDate:    timestampOrNil(sprint.StartDate.Time),
+			EndDate:      timestampOrNil(sprint.EndDate.Time),
+			CompleteDate: timestampOrNil(sprint.CompleteDate.Time),
+			IssueKeys:    sprint.IssueKeys,
+		})
+	}
+	return resp, nil
+}
+
+func (s *GRPCServer) Health(ctx context.Context, req *connectorApi.HealthRequest) (*connectorApi.HealthResponse, error) {
+	service, err := s.source(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	health := service.Health(ctx)
+	return &connectorApi.HealthResponse{
+		Serving: health.State != models.BreakerOpen,
+		Jira: &connectorApi.JiraHealth{
+			BaseUrl:  health.BaseURL,
+			State:    breakerStates[health.State],
+			Requests: int64(health.Requests),
+			Failures: int64(health.Failures),
+			OpenedAt: timestampOrNil(health.OpenedAt),
+			RetryAt:  timestampOrNil(health.RetryAt),
+		},
+	}, nil
+}
+
+var breakerStates = map[models.BreakerState]connectorApi.BreakerState{
+	models.BreakerClosed:   connectorApi.BreakerState_BREAKER_STATE_CLOSED,
+	models.BreakerOpen:     connectorApi.BreakerState_BREAKER_STATE_OPEN,
+	models.BreakerHalfOpen: connectorApi.BreakerState_BREAKER_STATE_HALF_OPEN,
+}
+
 func (s *GRPCServer) Start(addr string) error {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -87,6 +484,7 @@ func (s *GRPCServer) Start(addr string) error {
 
 	s.server = grpc.NewServer(
 		grpc.UnaryInterceptor(logger.Interceptor(*s.logger)),
+		grpc.MaxRecvMsgSize(maxRecvMsgSize),
 	)
 	connectorApi.RegisterJiraConnectorServer(s.server, s)
 
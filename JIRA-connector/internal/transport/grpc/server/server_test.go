@@ -3,9 +3,14 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/sssidkn/jira-connector/internal/export"
+	"github.com/sssidkn/jira-connector/internal/jira"
 	"github.com/sssidkn/jira-connector/internal/models"
+	connector "github.com/sssidkn/jira-connector/internal/service"
 	connectorApi "github.com/sssidkn/jira-connector/pkg/api/connector"
 	"github.com/sssidkn/jira-connector/pkg/logger"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -17,6 +22,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // MockService мок для Service интерфейса
@@ -40,18 +46,100 @@ func (m *MockService) GetProjects(ctx context.Context, limit, page int, search s
 	return args.Get(0).(*connectorApi.GetProjectsResponse), args.Error(1)
 }
 
+func (m *MockService) StartSync(ctx context.Context, projectKey string) (*models.SyncJob, error) {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncJob), args.Error(1)
+}
+
+func (m *MockService) GetSyncJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncJob), args.Error(1)
+}
+
+func (m *MockService) CancelSyncJob(ctx context.Context, id int64) (*models.SyncJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncJob), args.Error(1)
+}
+
+// WatchSync отправляет заданные события и возвращает заданную ошибку
+func (m *MockService) WatchSync(ctx context.Context, projectKey string, send func(models.SyncEvent) error) error {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) != nil {
+		for _, event := range args.Get(0).([]models.SyncEvent) {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockService) GetSyncSchedule(ctx context.Context) (*models.SyncSchedule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncSchedule), args.Error(1)
+}
+
+func (m *MockService) UpdateSyncSchedule(ctx context.Context, schedule models.SyncSchedule) (*models.SyncSchedule, error) {
+	args := m.Called(ctx, schedule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncSchedule), args.Error(1)
+}
+
+func (m *MockService) ListScheduleRuns(ctx context.Context, limit int) ([]models.ScheduleRun, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ScheduleRun), args.Error(1)
+}
+
+func (m *MockService) ImportProjects(ctx context.Context, r io.Reader, format export.Format, opts ...export.Option) ([]models.JiraProject, error) {
+	args := m.Called(ctx, r, format, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.JiraProject), args.Error(1)
+}
+
+func (m *MockService) ListSprints(ctx context.Context, projectKey string) ([]models.Sprint, error) {
+	args := m.Called(ctx, projectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Sprint), args.Error(1)
+}
+
+func (m *MockService) Health(ctx context.Context) models.JiraHealth {
+	args := m.Called(ctx)
+	return args.Get(0).(models.JiraHealth)
+}
+
 // bufConnListener создает in-memory соединение для тестов
 const bufSize = 1024 * 1024
 
-func createTestServer(t *testing.T, service Service) (*GRPCServer, *grpc.ClientConn, func()) {
+func createTestServer(t *testing.T, service Service, opts ...Option) (*GRPCServer, *grpc.ClientConn, func()) {
 	lis := bufconn.Listen(bufSize)
 
 	testLogger := &logger.TestLogger{}
 
-	server := NewGRPCServer(
+	server := NewGRPCServer(append([]Option{
 		WithService(service),
 		WithLogger(testLogger),
-	)
+	}, opts...)...)
 
 	grpcServer := grpc.NewServer()
 	connectorApi.RegisterJiraConnectorServer(grpcServer, server)
@@ -166,6 +254,52 @@ func TestGRPCServer_UpdateProject(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("JiraUnavailable", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Настройка моков
+		mockService.On("UpdateProject", mock.Anything, "TEST").
+			Return(nil, fmt.Errorf("failed to get project: %w", jira.ErrJiraUnavailable))
+
+		// Вызов метода
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := client.UpdateProject(ctx, &connectorApi.UpdateProjectRequest{
+			ProjectKey: "TEST",
+		})
+
+		// Проверки
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("SyncActive", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Проект уже загружается другим заданием
+		mockService.On("UpdateProject", mock.Anything, "TEST").
+			Return(nil, fmt.Errorf("%w: job 7", connector.ErrSyncActive))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := client.UpdateProject(ctx, &connectorApi.UpdateProjectRequest{
+			ProjectKey: "TEST",
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestGRPCServer_GetProjects(t *testing.T) {
@@ -374,3 +508,513 @@ func TestGRPCServer_ConcurrentRequests(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestGRPCServer_SyncJobs(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("StartSync", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Настройка моков
+		mockService.On("StartSync", mock.Anything, "TEST").
+			Return(&models.SyncJob{ID: 7, ProjectKey: "TEST", State: models.SyncJobQueued, CreatedAt: createdAt}, nil)
+
+		// Вызов метода
+		job, err := client.StartSync(context.Background(), &connectorApi.StartSyncRequest{ProjectKey: "TEST"})
+
+		// Проверки
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), job.Id)
+		assert.Equal(t, connectorApi.SyncJobState_SYNC_JOB_STATE_QUEUED, job.State)
+		assert.Equal(t, createdAt, job.CreatedAt.AsTime())
+		assert.Nil(t, job.StartedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("StartSyncWithoutKey", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		_, err := client.StartSync(context.Background(), &connectorApi.StartSyncRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "StartSync")
+	})
+
+	t.Run("GetSyncJob", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Настройка моков
+		mockService.On("GetSyncJob", mock.Anything, int64(7)).
+			Return(&models.SyncJob{
+				ID:          7,
+				ProjectKey:  "TEST",
+				State:       models.SyncJobRunning,
+				PagesDone:   3,
+				PagesTotal:  10,
+				IssuesDone:  150,
+				IssuesTotal: 480,
+			}, nil)
+
+		// Вызов метода
+		job, err := client.GetSyncJob(context.Background(), &connectorApi.GetSyncJobRequest{Id: 7})
+
+		// Проверки
+		require.NoError(t, err)
+		assert.Equal(t, connectorApi.SyncJobState_SYNC_JOB_STATE_RUNNING, job.State)
+		assert.Equal(t, int64(3), job.PagesDone)
+		assert.Equal(t, int64(10), job.PagesTotal)
+		assert.Equal(t, int64(150), job.IssuesDone)
+		assert.Equal(t, int64(480), job.IssuesTotal)
+	})
+
+	t.Run("GetSyncJobNotFound", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("GetSyncJob", mock.Anything, int64(8)).
+			Return(nil, connector.ErrSyncJobNotFound)
+
+		_, err := client.GetSyncJob(context.Background(), &connectorApi.GetSyncJobRequest{Id: 8})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("CancelSyncJob", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("CancelSyncJob", mock.Anything, int64(7)).
+			Return(&models.SyncJob{ID: 7, ProjectKey: "TEST", State: models.SyncJobCanceled}, nil)
+
+		job, err := client.CancelSyncJob(context.Background(), &connectorApi.CancelSyncJobRequest{Id: 7})
+
+		require.NoError(t, err)
+		assert.Equal(t, connectorApi.SyncJobState_SYNC_JOB_STATE_CANCELED, job.State)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("CancelSyncJobRunningElsewhere", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("CancelSyncJob", mock.Anything, int64(7)).
+			Return(nil, connector.ErrSyncJobRunningElsewhere)
+
+		_, err := client.CancelSyncJob(context.Background(), &connectorApi.CancelSyncJobRequest{Id: 7})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestGRPCServer_WatchSync(t *testing.T) {
+	t.Run("StreamsEvents", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Настройка моков
+		mockService.On("WatchSync", mock.Anything, "TEST").Return([]models.SyncEvent{
+			{JobID: 7, Type: models.SyncEventTotalDiscovered, Issues: 120, Pages: 3},
+			{JobID: 7, Type: models.SyncEventPageFetched, Page: 0, Issues: 50},
+			{JobID: 7, Type: models.SyncEventPaused, RetryAfter: 2 * time.Second},
+			{JobID: 7, Type: models.SyncEventBatchCommitted, Page: 0, Issues: 50, IssuesDone: 50},
+			{JobID: 7, Type: models.SyncEventCompleted, Issues: 120},
+		}, nil)
+
+		// Вызов метода
+		stream, err := client.WatchSync(context.Background(), &connectorApi.WatchSyncRequest{ProjectKey: "TEST"})
+		require.NoError(t, err)
+
+		events := make([]*connectorApi.SyncEvent, 0)
+		for {
+			event, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			events = append(events, event)
+		}
+
+		// Проверки
+		require.Len(t, events, 5)
+		assert.Equal(t, int64(7), events[0].JobId)
+		assert.Equal(t, int64(120), events[0].GetTotalDiscovered().GetIssues())
+		assert.Equal(t, int64(3), events[0].GetTotalDiscovered().GetPages())
+		assert.Equal(t, int64(50), events[1].GetPageFetched().GetIssues())
+		assert.Equal(t, 2*time.Second, events[2].GetRateLimitPaused().GetRetryAfter().AsDuration())
+		assert.Equal(t, int64(50), events[3].GetBatchCommitted().GetIssuesDone())
+		assert.Equal(t, int64(120), events[4].GetCompleted().GetIssues())
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("WatchSync", mock.Anything, "TEST").Return([]models.SyncEvent{
+			{JobID: 7, Type: models.SyncEventFailed, Error: "jira is unavailable"},
+		}, nil)
+
+		stream, err := client.WatchSync(context.Background(), &connectorApi.WatchSyncRequest{ProjectKey: "TEST"})
+		require.NoError(t, err)
+
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "jira is unavailable", event.GetFailed().GetError())
+		assert.False(t, event.GetFailed().GetCanceled())
+
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("WithoutKey", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		stream, err := client.WatchSync(context.Background(), &connectorApi.WatchSyncRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "WatchSync")
+	})
+}
+
+func TestGRPCServer_SyncSchedule(t *testing.T) {
+	nextRunAt := time.Date(2025, 5, 1, 12, 3, 0, 0, time.UTC)
+
+	t.Run("GetSyncSchedule", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Настройка моков
+		mockService.On("GetSyncSchedule", mock.Anything).Return(&models.SyncSchedule{
+			Enabled: true, Cron: "0 */6 * * *", Jitter: 5 * time.Minute, MaxConcurrent: 2, NextRunAt: nextRunAt,
+		}, nil)
+
+		// Вызов метода
+		schedule, err := client.GetSyncSchedule(context.Background(), &connectorApi.GetSyncScheduleRequest{})
+
+		// Проверки
+		require.NoError(t, err)
+		assert.True(t, schedule.Enabled)
+		assert.Equal(t, "0 */6 * * *", schedule.Cron)
+		assert.Equal(t, 5*time.Minute, schedule.Jitter.AsDuration())
+		assert.Equal(t, int64(2), schedule.MaxConcurrent)
+		assert.Equal(t, nextRunAt, schedule.NextRunAt.AsTime())
+		assert.Nil(t, schedule.UpdatedAt)
+	})
+
+	t.Run("UpdateSyncSchedule", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		schedule := models.SyncSchedule{Enabled: true, Cron: "0 3 * * *", Jitter: time.Minute, MaxConcurrent: 4}
+		updated := schedule
+		updated.NextRunAt = nextRunAt
+		mockService.On("UpdateSyncSchedule", mock.Anything, schedule).Return(&updated, nil)
+
+		resp, err := client.UpdateSyncSchedule(context.Background(), &connectorApi.UpdateSyncScheduleRequest{
+			Enabled: true, Cron: "0 3 * * *", Jitter: durationpb.New(time.Minute), MaxConcurrent: 4,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, nextRunAt, resp.NextRunAt.AsTime())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateSyncScheduleInvalid", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("UpdateSyncSchedule", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: bad cron", connector.ErrInvalidSchedule))
+
+		_, err := client.UpdateSyncSchedule(context.Background(), &connectorApi.UpdateSyncScheduleRequest{Cron: "bad"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ListScheduleRuns", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("ListScheduleRuns", mock.Anything, 5).Return([]models.ScheduleRun{{
+			ID:        3,
+			StartedAt: nextRunAt,
+			Projects: []models.ScheduledSync{
+				{ProjectKey: "ALPHA", JobID: 10, Outcome: models.ScheduleSucceeded},
+				{ProjectKey: "BETA", JobID: 11, Outcome: models.ScheduleFailed, Error: "jira is unavailable"},
+				{ProjectKey: "GAMMA", JobID: 8, Outcome: models.ScheduleSkipped},
+			},
+		}}, nil)
+
+		resp, err := client.ListScheduleRuns(context.Background(), &connectorApi.ListScheduleRunsRequest{Limit: 5})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Runs, 1)
+		run := resp.Runs[0]
+		assert.Equal(t, int64(3), run.Id)
+		assert.Nil(t, run.FinishedAt)
+		assert.Equal(t, int64(1), run.Succeeded)
+		assert.Equal(t, int64(1), run.Failed)
+		assert.Equal(t, int64(1), run.Skipped)
+		require.Len(t, run.Projects, 3)
+		assert.Equal(t, connectorApi.ScheduleOutcome_SCHEDULE_OUTCOME_FAILED, run.Projects[1].Outcome)
+		assert.Equal(t, "jira is unavailable", run.Projects[1].Error)
+		assert.Equal(t, int64(8), run.Projects[2].JobId)
+	})
+}
+
+func TestGRPCServer_Health(t *testing.T) {
+	t.Run("Closed", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("Health", mock.Anything).Return(models.JiraHealth{
+			BaseURL:  "https://jira.test.com",
+			State:    models.BreakerClosed,
+			Requests: 20,
+			Failures: 2,
+		})
+
+		response, err := client.Health(context.Background(), &connectorApi.HealthRequest{})
+
+		require.NoError(t, err)
+		assert.True(t, response.Serving)
+		assert.Equal(t, "https://jira.test.com", response.Jira.BaseUrl)
+		assert.Equal(t, connectorApi.BreakerState_BREAKER_STATE_CLOSED, response.Jira.State)
+		assert.Equal(t, int64(20), response.Jira.Requests)
+		assert.Equal(t, int64(2), response.Jira.Failures)
+		assert.Nil(t, response.Jira.RetryAt)
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		openedAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		mockService.On("Health", mock.Anything).Return(models.JiraHealth{
+			State:    models.BreakerOpen,
+			OpenedAt: openedAt,
+			RetryAt:  openedAt.Add(30 * time.Second),
+		})
+
+		response, err := client.Health(context.Background(), &connectorApi.HealthRequest{})
+
+		require.NoError(t, err)
+		assert.False(t, response.Serving)
+		assert.Equal(t, connectorApi.BreakerState_BREAKER_STATE_OPEN, response.Jira.State)
+		assert.Equal(t, openedAt, response.Jira.OpenedAt.AsTime())
+		assert.Equal(t, openedAt.Add(30*time.Second), response.Jira.RetryAt.AsTime())
+	})
+}
+
+func TestGRPCServer_ImportProjects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		// Настройка моков
+		mockService.On("ImportProjects", mock.Anything, mock.Anything, export.FormatXML, mock.Anything).
+			Run(func(args mock.Arguments) {
+				data, err := io.ReadAll(args.Get(1).(io.Reader))
+				require.NoError(t, err)
+				assert.Equal(t, "<entity-engine-xml/>", string(data))
+				assert.Len(t, args.Get(3), 1)
+			}).
+			Return([]models.JiraProject{{ID: "10000", Key: "TEST", Name: "Test Project", TotalIssueCount: 5}}, nil)
+
+		// Вызов метода
+		response, err := client.ImportProjects(context.Background(), &connectorApi.ImportProjectsRequest{
+			Data:     []byte("<entity-engine-xml/>"),
+			Format:   connectorApi.ImportFormat_IMPORT_FORMAT_XML,
+			Timezone: "Europe/Moscow",
+		})
+
+		// Проверки
+		require.NoError(t, err)
+		require.Len(t, response.Projects, 1)
+		assert.Equal(t, "TEST", response.Projects[0].Project.Key)
+		assert.Equal(t, "Test Project", response.Projects[0].Project.Name)
+		assert.Equal(t, int64(5), response.Projects[0].Issues)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidExport", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("ImportProjects", mock.Anything, mock.Anything, export.FormatAuto, mock.Anything).
+			Return(nil, fmt.Errorf("%w: unexpected EOF", connector.ErrInvalidExport))
+
+		_, err := client.ImportProjects(context.Background(), &connectorApi.ImportProjectsRequest{
+			Data: []byte("{"),
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		_, err := client.ImportProjects(context.Background(), &connectorApi.ImportProjectsRequest{
+			Data:     []byte("[]"),
+			Timezone: "Mars/Olympus",
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "ImportProjects")
+	})
+}
+
+func TestGRPCServer_ListSprints(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+		mockService.On("ListSprints", mock.Anything, "TEST").Return([]models.Sprint{
+			{ID: 10, BoardID: 1, Name: "Sprint 1", State: "active", StartDate: models.JiraTime{Time: start},
+				EndDate: models.JiraTime{Time: start.Add(14 * 24 * time.Hour)}, IssueKeys: []string{"TEST-1"}},
+			{ID: 11, BoardID: 1, Name: "Sprint 2", State: "future"},
+		}, nil)
+
+		response, err := client.ListSprints(context.Background(), &connectorApi.ListSprintsRequest{ProjectKey: "TEST"})
+
+		require.NoError(t, err)
+		require.Len(t, response.Sprints, 2)
+		sprint := response.Sprints[0]
+		assert.Equal(t, int64(10), sprint.Id)
+		assert.Equal(t, "active", sprint.State)
+		assert.Equal(t, start, sprint.StartDate.AsTime())
+		assert.Nil(t, sprint.CompleteDate)
+		assert.Equal(t, []string{"TEST-1"}, sprint.IssueKeys)
+		// Даты будущего спринта не заданы
+		assert.Nil(t, response.Sprints[1].StartDate)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ProjectNotFound", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		mockService.On("ListSprints", mock.Anything, "NONE").
+			Return(nil, fmt.Errorf("%w: NONE", connector.ErrProjectNotFound))
+
+		_, err := client.ListSprints(context.Background(), &connectorApi.ListSprintsRequest{ProjectKey: "NONE"})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("EmptyProjectKey", func(t *testing.T) {
+		mockService := &MockService{}
+		_, conn, cleanup := createTestServer(t, mockService)
+		defer cleanup()
+		client := connectorApi.NewJiraConnectorClient(conn)
+
+		_, err := client.ListSprints(context.Background(), &connectorApi.ListSprintsRequest{})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "ListSprints")
+	})
+}
+
+func TestGRPCServer_Sources(t *testing.T) {
+	defaultService := &MockService{}
+	cloudService := &MockService{}
+	_, conn, cleanup := createTestServer(t, defaultService, WithSource("cloud", cloudService))
+	defer cleanup()
+
+	client := connectorApi.NewJiraConnectorClient(conn)
+	ctx := context.Background()
+
+	t.Run("NamedSource", func(t *testing.T) {
+		cloudService.On("UpdateProject", mock.Anything, "TEST").
+			Return(&models.JiraProject{ID: "2", Key: "TEST"}, nil).Once()
+
+		resp, err := client.UpdateProject(ctx, &connectorApi.UpdateProjectRequest{ProjectKey: "TEST", Source: "cloud"})
+		require.NoError(t, err)
+		assert.Equal(t, "2", resp.GetProject().GetId())
+		defaultService.AssertNotCalled(t, "UpdateProject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DefaultSource", func(t *testing.T) {
+		// Запросы без источника и с явным default идут в источник по умолчанию
+		defaultService.On("ListSprints", mock.Anything, "TEST").Return([]models.Sprint{}, nil).Twice()
+
+		_, err := client.ListSprints(ctx, &connectorApi.ListSprintsRequest{ProjectKey: "TEST"})
+		require.NoError(t, err)
+		_, err = client.ListSprints(ctx, &connectorApi.ListSprintsRequest{ProjectKey: "TEST", Source: "default"})
+		require.NoError(t, err)
+		cloudService.AssertNotCalled(t, "ListSprints", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownSource", func(t *testing.T) {
+		_, err := client.StartSync(ctx, &connectorApi.StartSyncRequest{ProjectKey: "TEST", Source: "other"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	defaultService.AssertExpectations(t)
+	cloudService.AssertExpectations(t)
+}
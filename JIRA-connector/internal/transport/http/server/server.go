@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	connector "github.com/sssidkn/jira-connector/internal/service"
+	"github.com/sssidkn/jira-connector/internal/models"
 	connectorApi "github.com/sssidkn/jira-connector/pkg/api/connector"
 	"github.com/sssidkn/jira-connector/pkg/logger"
 	"net/http"
@@ -17,17 +17,27 @@ import (
 
 type Option func(*HTTPServer)
 
+type Service interface {
+	HandleIssueEvent(ctx context.Context, event models.IssueEvent) error
+}
+
 type HTTPServer struct {
-	server   *http.Server
-	service  *connector.JiraConnector
+	server *http.Server
+	// service handles the webhooks of the default source
+	service Service
+	// sources handle the webhooks of the other Jira sources by id
+	sources  map[string]Service
 	wg       *sync.WaitGroup
 	logger   *logger.Logger
 	grpcAddr string
+	// webhookSecret signs Jira webhooks, the webhook endpoint is disabled without it
+	webhookSecret string
 }
 
 func NewHTTPServer(options ...Option) *HTTPServer {
 	srv := &HTTPServer{
-		wg: &sync.WaitGroup{},
+		wg:      &sync.WaitGroup{},
+		sources: make(map[string]Service),
 	}
 	for _, opt := range options {
 		opt(srv)
@@ -35,12 +45,19 @@ func NewHTTPServer(options ...Option) *HTTPServer {
 	return srv
 }
 
-func WithService(service *connector.JiraConnector) Option {
+func WithService(service Service) Option {
 	return func(s *HTTPServer) {
 		s.service = service
 	}
 }
 
+// WithSource handles the webhooks of the source with the given id
+func WithSource(id string, service Service) Option {
+	return func(s *HTTPServer) {
+		s.sources[id] = service
+	}
+}
+
 func WithLogger(log logger.Logger) Option {
 	if log == nil {
 		log = logger.NewLogrusLogger()
@@ -56,13 +73,19 @@ func WithGRPCAddress(addr string) Option {
 	}
 }
 
-func (s *HTTPServer) Start(addr string) error {
+// WithWebhookSecret enables the Jira webhook endpoint
+func WithWebhookSecret(secret string) Option {
+	return func(s *HTTPServer) {
+		s.webhookSecret = secret
+	}
+}
+
+func (s *HTTPServer) newMux(ctx context.Context) (*runtime.ServeMux, error) {
 	mux := runtime.NewServeMux()
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	}
 
-	ctx := context.Background()
 	err := connectorApi.RegisterJiraConnectorHandlerFromEndpoint(
 		ctx,
 		mux,
@@ -70,7 +93,23 @@ func (s *HTTPServer) Start(addr string) error {
 		opts,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to register HTTP gateway: %w", err)
+		return nil, fmt.Errorf("failed to register HTTP gateway: %w", err)
+	}
+
+	if s.webhookSecret == "" {
+		(*s.logger).Info("Jira webhook is disabled, webhook secret is not set")
+		return mux, nil
+	}
+	if err = mux.HandlePath(http.MethodPost, webhookPath, s.handleWebhook); err != nil {
+		return nil, fmt.Errorf("failed to register webhook handler: %w", err)
+	}
+	return mux, nil
+}
+
+func (s *HTTPServer) Start(addr string) error {
+	mux, err := s.newMux(context.Background())
+	if err != nil {
+		return err
 	}
 
 	s.server = &http.Server{
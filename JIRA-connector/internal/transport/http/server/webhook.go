@@ -0,0 +1,75 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+)
+
+const (
+	webhookPath = "/api/v1/connector/webhook"
+	// signatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body, as sent by Jira
+	signatureHeader = "X-Hub-Signature"
+	maxWebhookBody  = 10 << 20
+	// sourceParam names the Jira source of the webhook, e.g. /api/v1/connector/webhook?source=cloud
+	sourceParam = "source"
+)
+
+// handleWebhook applies a Jira issue webhook. Jira retries the delivery if it gets a 5xx response.
+func (s *HTTPServer) handleWebhook(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	service := s.service
+	if source := r.URL.Query().Get(sourceParam); source != "" && source != models.DefaultSource {
+		var ok bool
+		if service, ok = s.sources[source]; !ok {
+			http.Error(w, "unknown source", http.StatusNotFound)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if !validSignature(s.webhookSecret, body, r.Header.Get(signatureHeader)) {
+		(*s.logger).Warn("Rejected Jira webhook with invalid signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event models.IssueEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		http.Error(w, fmt.Sprintf("invalid payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err = service.HandleIssueEvent(r.Context(), event); err != nil {
+		(*s.logger).Error("Failed to handle Jira webhook", logger.Field{Key: "event", Value: event.WebhookEvent},
+			logger.Field{Key: "issue_key", Value: event.Issue.Key}, logger.Field{Key: "error", Value: err.Error()})
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validSignature(secret string, body []byte, signature string) bool {
+	got, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	gotMAC, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(gotMAC, mac.Sum(nil))
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/internal/models"
+	"github.com/sssidkn/jira-connector/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "webhook-secret"
+
+// MockService мок для Service
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) HandleIssueEvent(ctx context.Context, event models.IssueEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// newTestMux создает mux HTTP сервера с заданным секретом
+func newTestMux(t *testing.T, service Service, secret string) http.Handler {
+	srv := NewHTTPServer(
+		WithService(service),
+		WithLogger(&logger.TestLogger{}),
+		WithGRPCAddress("localhost:0"),
+		WithWebhookSecret(secret),
+	)
+	mux, err := srv.newMux(context.Background())
+	require.NoError(t, err)
+	return mux
+}
+
+// readWebhook читает записанное тело вебхука из testdata
+func readWebhook(t *testing.T, name string) []byte {
+	body, err := os.ReadFile(filepath.Join("testdata", "webhook", name))
+	require.NoError(t, err)
+	return body
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func postWebhook(handler http.Handler, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, webhookPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(signatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHTTPServer_Webhook(t *testing.T) {
+	t.Run("IssueCreated", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := readWebhook(t, "issue_created.json")
+
+		// Настройка моков
+		var event models.IssueEvent
+		service.On("HandleIssueEvent", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { event = args.Get(1).(models.IssueEvent) }).
+			Return(nil)
+
+		// Вызов метода
+		rec := postWebhook(handler, body, sign(testSecret, body))
+
+		// Проверки
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, models.IssueCreated, event.WebhookEvent)
+		assert.Equal(t, "TEST-101", event.Issue.Key)
+		assert.Equal(t, "TEST", event.Issue.Fields.Project.Key)
+		assert.Equal(t, "Open", event.Issue.Fields.Status.Name)
+		assert.Equal(t, "John Doe", event.Issue.Fields.Creator.DisplayName)
+		assert.Empty(t, event.Issue.Fields.Assignee.ID())
+		assert.Empty(t, event.IssueWithChanges().Changelogs.Histories)
+	})
+
+	t.Run("IssueUpdated", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := readWebhook(t, "issue_updated.json")
+
+		var event models.IssueEvent
+		service.On("HandleIssueEvent", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { event = args.Get(1).(models.IssueEvent) }).
+			Return(nil)
+
+		rec := postWebhook(handler, body, sign(testSecret, body))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, models.IssueUpdated, event.WebhookEvent)
+		assert.Equal(t, "In Progress", event.Issue.Fields.Status.Name)
+		require.NotNil(t, event.Issue.Fields.Timetracking.TimeSpentSeconds)
+		assert.Equal(t, 3600, *event.Issue.Fields.Timetracking.TimeSpentSeconds)
+
+		// Изменения датируются временем обновления задачи, как в changelog
+		histories := event.IssueWithChanges().Changelogs.Histories
+		require.Len(t, histories, 1)
+		assert.True(t, histories[0].Created.Equal(time.Date(2025, 5, 1, 11, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "Alice Smith", histories[0].Author.DisplayName)
+		require.Len(t, histories[0].Items, 2)
+		assert.Equal(t, models.Item{Field: "status", From: "1", FromString: "Open", To: "3", ToString: "In Progress"}, histories[0].Items[1])
+	})
+
+	t.Run("IssueDeleted", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := readWebhook(t, "issue_deleted.json")
+
+		service.On("HandleIssueEvent", mock.Anything, mock.MatchedBy(func(event models.IssueEvent) bool {
+			return event.WebhookEvent == models.IssueDeleted && event.Issue.Key == "TEST-101"
+		})).Return(nil)
+
+		rec := postWebhook(handler, body, sign(testSecret, body))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("UnsupportedEvent", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := readWebhook(t, "comment_created.json")
+
+		service.On("HandleIssueEvent", mock.Anything, mock.Anything).Return(nil)
+
+		rec := postWebhook(handler, body, sign(testSecret, body))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		body := readWebhook(t, "issue_created.json")
+		tests := []struct {
+			name      string
+			signature string
+		}{
+			{name: "Missing", signature: ""},
+			{name: "WrongSecret", signature: sign("other-secret", body)},
+			{name: "NotHex", signature: "sha256=zz"},
+			{name: "WithoutPrefix", signature: sign(testSecret, body)[len("sha256="):]},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service := &MockService{}
+				handler := newTestMux(t, service, testSecret)
+
+				rec := postWebhook(handler, body, tt.signature)
+
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+				service.AssertNotCalled(t, "HandleIssueEvent")
+			})
+		}
+	})
+
+	t.Run("ModifiedBody", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := readWebhook(t, "issue_created.json")
+		signature := sign(testSecret, body)
+
+		rec := postWebhook(handler, bytes.Replace(body, []byte("TEST-101"), []byte("TEST-102"), 1), signature)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		service.AssertNotCalled(t, "HandleIssueEvent")
+	})
+
+	t.Run("InvalidPayload", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := []byte(`{"webhookEvent": `)
+
+		rec := postWebhook(handler, body, sign(testSecret, body))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "HandleIssueEvent")
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, testSecret)
+		body := readWebhook(t, "issue_updated.json")
+
+		service.On("HandleIssueEvent", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+		rec := postWebhook(handler, body, sign(testSecret, body))
+
+		// Jira повторит доставку
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("Source", func(t *testing.T) {
+		service, cloud := &MockService{}, &MockService{}
+		srv := NewHTTPServer(
+			WithService(service),
+			WithSource("cloud", cloud),
+			WithLogger(&logger.TestLogger{}),
+			WithGRPCAddress("localhost:0"),
+			WithWebhookSecret(testSecret),
+		)
+		handler, err := srv.newMux(context.Background())
+		require.NoError(t, err)
+		body := readWebhook(t, "issue_created.json")
+
+		cloud.On("HandleIssueEvent", mock.Anything, mock.Anything).Return(nil)
+
+		post := func(source string) int {
+			req := httptest.NewRequest(http.MethodPost, webhookPath+"?source="+source, bytes.NewReader(body))
+			req.Header.Set(signatureHeader, sign(testSecret, body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		// Вебхук применяется в источнике из параметра source
+		assert.Equal(t, http.StatusNoContent, post("cloud"))
+		assert.Equal(t, http.StatusNotFound, post("other"))
+		cloud.AssertNumberOfCalls(t, "HandleIssueEvent", 1)
+		service.AssertNotCalled(t, "HandleIssueEvent")
+	})
+
+	t.Run("DisabledWithoutSecret", func(t *testing.T) {
+		service := &MockService{}
+		handler := newTestMux(t, service, "")
+		body := readWebhook(t, "issue_created.json")
+
+		rec := postWebhook(handler, body, sign("", body))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		service.AssertNotCalled(t, "HandleIssueEvent")
+	})
+}
@@ -0,0 +1,250 @@
+// Package adf converts Atlassian Document Format documents, returned by Jira Cloud REST v3
+// for rich text fields, into plain text or markdown.
+package adf
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+)
+
+type Node struct {
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []Mark                 `json:"marks,omitempty"`
+	Content []Node                 `json:"content,omitempty"`
+}
+
+type Mark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// Convert renders the document in the requested format. Unknown formats fall back to plain text.
+func Convert(doc *Node, format Format) string {
+	if doc == nil {
+		return ""
+	}
+	r := renderer{markdown: format == FormatMarkdown}
+	r.block(*doc, "")
+	return strings.TrimSpace(r.b.String())
+}
+
+func ToText(doc *Node) string {
+	return Convert(doc, FormatText)
+}
+
+func ToMarkdown(doc *Node) string {
+	return Convert(doc, FormatMarkdown)
+}
+
+type renderer struct {
+	b        strings.Builder
+	markdown bool
+}
+
+func (r *renderer) block(n Node, indent string) {
+	switch n.Type {
+	case "doc", "layoutSection", "layoutColumn", "expand", "nestedExpand":
+		if title := attr(n, "title"); title != "" && n.Type != "doc" {
+			r.line(indent, r.strong(title))
+		}
+		r.blocks(n.Content, indent)
+	case "paragraph":
+		r.line(indent, r.inline(n.Content))
+	case "heading":
+		text := r.inline(n.Content)
+		if r.markdown {
+			level := 1
+			if l, ok := n.Attrs["level"].(float64); ok && l >= 1 && l <= 6 {
+				level = int(l)
+			}
+			text = strings.Repeat("#", level) + " " + text
+		}
+		r.line(indent, text)
+	case "bulletList", "orderedList":
+		r.list(n, indent)
+		r.b.WriteString("\n")
+	case "codeBlock":
+		code := r.plain(n.Content)
+		if r.markdown {
+			r.line(indent, "```"+attr(n, "language")+"\n"+code+"\n```")
+		} else {
+			r.line(indent, code)
+		}
+	case "blockquote", "panel":
+		inner := renderer{markdown: r.markdown}
+		inner.blocks(n.Content, "")
+		text := strings.TrimSpace(inner.b.String())
+		if r.markdown && n.Type == "blockquote" {
+			text = "> " + strings.ReplaceAll(text, "\n", "\n> ")
+		}
+		r.line(indent, text)
+	case "rule":
+		if r.markdown {
+			r.line(indent, "---")
+		}
+	case "table":
+		r.table(n, indent)
+	case "mediaSingle", "mediaGroup", "media":
+		// attachments are not downloaded by the connector
+	default:
+		if len(n.Content) > 0 {
+			r.blocks(n.Content, indent)
+		} else if text := r.inline([]Node{n}); text != "" {
+			r.line(indent, text)
+		}
+	}
+}
+
+func (r *renderer) blocks(nodes []Node, indent string) {
+	for _, n := range nodes {
+		r.block(n, indent)
+	}
+}
+
+func (r *renderer) line(indent, text string) {
+	if text == "" {
+		return
+	}
+	r.b.WriteString(indent)
+	r.b.WriteString(strings.ReplaceAll(text, "\n", "\n"+indent))
+	r.b.WriteString("\n\n")
+}
+
+func (r *renderer) list(n Node, indent string) {
+	for i, item := range n.Content {
+		bullet := "- "
+		if n.Type == "orderedList" {
+			start := 1
+			if s, ok := n.Attrs["order"].(float64); ok {
+				start = int(s)
+			}
+			bullet = fmt.Sprintf("%d. ", start+i)
+		}
+		first := true
+		for _, child := range item.Content {
+			switch child.Type {
+			case "bulletList", "orderedList":
+				r.list(child, indent+"  ")
+			default:
+				inner := renderer{markdown: r.markdown}
+				inner.block(child, "")
+				text := strings.TrimSpace(inner.b.String())
+				prefix := indent + "  "
+				if first {
+					prefix = indent + bullet
+					first = false
+				}
+				r.b.WriteString(prefix + strings.ReplaceAll(text, "\n", "\n"+indent+"  ") + "\n")
+			}
+		}
+	}
+}
+
+func (r *renderer) table(n Node, indent string) {
+	for i, row := range n.Content {
+		cells := make([]string, 0, len(row.Content))
+		for _, cell := range row.Content {
+			inner := renderer{markdown: r.markdown}
+			inner.blocks(cell.Content, "")
+			text := strings.TrimSpace(inner.b.String())
+			cells = append(cells, strings.ReplaceAll(text, "\n", " "))
+		}
+		if r.markdown {
+			r.b.WriteString(indent + "| " + strings.Join(cells, " | ") + " |\n")
+			if i == 0 {
+				r.b.WriteString(indent + "|" + strings.Repeat(" --- |", len(cells)) + "\n")
+			}
+		} else {
+			r.b.WriteString(indent + strings.Join(cells, "\t") + "\n")
+		}
+	}
+	r.b.WriteString("\n")
+}
+
+func (r *renderer) inline(nodes []Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "text":
+			b.WriteString(r.marks(n.Text, n.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			text := attr(n, "text")
+			if text != "" && !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			b.WriteString(text)
+		case "emoji":
+			if text := attr(n, "text"); text != "" {
+				b.WriteString(text)
+			} else {
+				b.WriteString(attr(n, "shortName"))
+			}
+		case "inlineCard", "blockCard":
+			b.WriteString(attr(n, "url"))
+		case "status":
+			b.WriteString("[" + attr(n, "text") + "]")
+		case "date":
+			b.WriteString(attr(n, "timestamp"))
+		default:
+			b.WriteString(r.inline(n.Content))
+		}
+	}
+	return b.String()
+}
+
+func (r *renderer) plain(nodes []Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(n.Text)
+		b.WriteString(r.plain(n.Content))
+	}
+	return b.String()
+}
+
+func (r *renderer) marks(text string, marks []Mark) string {
+	if !r.markdown || text == "" {
+		return text
+	}
+	for _, m := range marks {
+		switch m.Type {
+		case "strong":
+			text = r.strong(text)
+		case "em":
+			text = "_" + text + "_"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "code":
+			text = "`" + text + "`"
+		case "link":
+			if href, ok := m.Attrs["href"].(string); ok {
+				text = "[" + text + "](" + href + ")"
+			}
+		}
+	}
+	return text
+}
+
+func (r *renderer) strong(text string) string {
+	if !r.markdown {
+		return text
+	}
+	return "**" + text + "**"
+}
+
+func attr(n Node, key string) string {
+	if v, ok := n.Attrs[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
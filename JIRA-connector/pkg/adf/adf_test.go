@@ -0,0 +1,80 @@
+package adf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, doc string) *Node {
+	var n Node
+	require.NoError(t, json.Unmarshal([]byte(doc), &n))
+	return &n
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		text     string
+		markdown string
+	}{
+		{
+			name:     "Paragraphs",
+			doc:      `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]},{"type":"paragraph","content":[{"type":"text","text":"two"},{"type":"hardBreak"},{"type":"text","text":"three"}]}]}`,
+			text:     "one\n\ntwo\nthree",
+			markdown: "one\n\ntwo\nthree",
+		},
+		{
+			name:     "Marks",
+			doc:      `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"bold","marks":[{"type":"strong"}]},{"type":"text","text":" "},{"type":"text","text":"it","marks":[{"type":"em"}]},{"type":"text","text":" "},{"type":"text","text":"gone","marks":[{"type":"strike"}]}]}]}`,
+			text:     "bold it gone",
+			markdown: "**bold** _it_ ~~gone~~",
+		},
+		{
+			name:     "NestedList",
+			doc:      `{"type":"doc","content":[{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]},{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"b"}]}]}]}]}]}]}`,
+			text:     "- a\n  - b",
+			markdown: "- a\n  - b",
+		},
+		{
+			name:     "CodeBlock",
+			doc:      `{"type":"doc","content":[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"fmt.Println()"}]}]}`,
+			text:     "fmt.Println()",
+			markdown: "```go\nfmt.Println()\n```",
+		},
+		{
+			name:     "Table",
+			doc:      `{"type":"doc","content":[{"type":"table","content":[{"type":"tableRow","content":[{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"k"}]}]},{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"v"}]}]}]},{"type":"tableRow","content":[{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]}]},{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]}]}]}]}`,
+			text:     "k\tv\na\t1",
+			markdown: "| k | v |\n| --- | --- |\n| a | 1 |",
+		},
+		{
+			name:     "InlineNodes",
+			doc:      `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"status","attrs":{"text":"DONE"}},{"type":"text","text":" "},{"type":"emoji","attrs":{"shortName":":smile:"}},{"type":"text","text":" "},{"type":"inlineCard","attrs":{"url":"https://example.com"}}]}]}`,
+			text:     "[DONE] :smile: https://example.com",
+			markdown: "[DONE] :smile: https://example.com",
+		},
+		{
+			name:     "Blockquote",
+			doc:      `{"type":"doc","content":[{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"q1"}]},{"type":"paragraph","content":[{"type":"text","text":"q2"}]}]}]}`,
+			text:     "q1\n\nq2",
+			markdown: "> q1\n> \n> q2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parse(t, tt.doc)
+			assert.Equal(t, tt.text, ToText(doc))
+			assert.Equal(t, tt.markdown, ToMarkdown(doc))
+		})
+	}
+}
+
+func TestConvert_Nil(t *testing.T) {
+	assert.Empty(t, ToText(nil))
+	assert.Empty(t, Convert(nil, FormatMarkdown))
+}
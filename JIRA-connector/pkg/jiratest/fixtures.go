@@ -0,0 +1,16 @@
+package jiratest
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed fixtures
+var sample embed.FS
+
+// Sample returns the fixtures of a small instance: project TEST with five issues
+// updated in March 2025 and project DEMO with one issue
+func Sample() fs.FS {
+	sub, _ := fs.Sub(sample, "fixtures")
+	return sub
+}
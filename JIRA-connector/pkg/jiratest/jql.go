@@ -0,0 +1,168 @@
+package jiratest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// query is the subset of JQL sent by the connector: project, updated and id clauses joined by AND
+// with an optional ORDER BY updated/key
+type query struct {
+	project string
+	ids     map[string]bool
+	after   []updatedClause
+	order   []orderBy
+}
+
+type updatedClause struct {
+	op   string
+	time time.Time
+}
+
+type orderBy struct {
+	field string
+	desc  bool
+}
+
+var (
+	andRe     = regexp.MustCompile(`(?i)\s+AND\s+`)
+	orderRe   = regexp.MustCompile(`(?i)\s*ORDER\s+BY\s+(.+)$`)
+	projectRe = regexp.MustCompile(`(?i)^project\s*=\s*"?([^"\s]+)"?$`)
+	updatedRe = regexp.MustCompile(`(?i)^updated\s*(>=|>|<=|<)\s*"([^"]+)"$`)
+	idsRe     = regexp.MustCompile(`(?i)^id\s+in\s+\(?([^()]*)\)?$`)
+)
+
+// JQL dates are written with or without the time of day
+var jqlLayouts = []string{"2006/01/02 15:04", "2006-01-02 15:04", "2006/01/02", "2006-01-02"}
+
+func parseJQL(jql string, loc *time.Location) (query, error) {
+	var q query
+	jql = strings.TrimSpace(jql)
+	if m := orderRe.FindStringSubmatchIndex(jql); m != nil {
+		for _, field := range strings.Split(jql[m[2]:m[3]], ",") {
+			parts := strings.Fields(field)
+			if len(parts) == 0 || len(parts) > 2 {
+				return q, fmt.Errorf("unsupported ORDER BY %q", field)
+			}
+			name := strings.ToLower(parts[0])
+			if name != "updated" && name != "key" {
+				return q, fmt.Errorf("unsupported ORDER BY field %q", parts[0])
+			}
+			q.order = append(q.order, orderBy{field: name, desc: len(parts) == 2 && strings.EqualFold(parts[1], "DESC")})
+		}
+		jql = jql[:m[0]]
+	}
+
+	for _, clause := range andRe.Split(jql, -1) {
+		// the parentheses of the connector queries only group AND clauses
+		clause = strings.TrimSpace(strings.Trim(strings.TrimSpace(clause), "()"))
+		if m := idsRe.FindStringSubmatch(clause); m != nil {
+			q.ids = make(map[string]bool)
+			for _, id := range strings.Split(m[1], ",") {
+				q.ids[strings.TrimSpace(id)] = true
+			}
+			continue
+		}
+		if m := projectRe.FindStringSubmatch(clause); m != nil {
+			q.project = m[1]
+			continue
+		}
+		if m := updatedRe.FindStringSubmatch(clause); m != nil {
+			t, err := parseJQLTime(m[2], loc)
+			if err != nil {
+				return q, err
+			}
+			q.after = append(q.after, updatedClause{op: m[1], time: t})
+			continue
+		}
+		if clause != "" {
+			return q, fmt.Errorf("unsupported JQL clause %q", clause)
+		}
+	}
+	return q, nil
+}
+
+func parseJQLTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range jqlLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func (q query) match(i *issue) bool {
+	if q.project != "" && !strings.EqualFold(q.project, i.project) {
+		return false
+	}
+	if q.ids != nil && !q.ids[i.id] {
+		return false
+	}
+	for _, c := range q.after {
+		switch c.op {
+		case ">":
+			if !i.updated.After(c.time) {
+				return false
+			}
+		case ">=":
+			if i.updated.Before(c.time) {
+				return false
+			}
+		case "<":
+			if !i.updated.Before(c.time) {
+				return false
+			}
+		case "<=":
+			if i.updated.After(c.time) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (q query) sort(issues []*issue) {
+	if len(q.order) == 0 {
+		return
+	}
+	sort.SliceStable(issues, func(a, b int) bool {
+		for _, o := range q.order {
+			c := 0
+			if o.field == "updated" {
+				c = issues[a].updated.Compare(issues[b].updated)
+			} else {
+				c = compareKeys(issues[a].key, issues[b].key)
+			}
+			if o.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareKeys orders issue keys like Jira does: by project, then by the issue number
+func compareKeys(a, b string) int {
+	pa, na := splitKey(a)
+	pb, nb := splitKey(b)
+	if c := strings.Compare(pa, pb); c != 0 {
+		return c
+	}
+	return na - nb
+}
+
+func splitKey(key string) (string, int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, 0
+	}
+	n, _ := strconv.Atoi(key[i+1:])
+	return key[:i], n
+}
@@ -0,0 +1,78 @@
+package jiratest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJQL(t *testing.T) {
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		jql      string
+		expected query
+	}{
+		{
+			name:     "Project",
+			jql:      "project=TEST",
+			expected: query{project: "TEST"},
+		},
+		{
+			name: "UpdatedAfter",
+			jql:  `project = "TEST" AND updated > "2025/03/05"`,
+			expected: query{
+				project: "TEST",
+				after:   []updatedClause{{op: ">", time: day}},
+			},
+		},
+		{
+			name: "SyncQuery",
+			jql:  `(project=TEST AND updated > "2025/03/05") AND updated >= "2025/03/05 16:30" ORDER BY updated ASC, key ASC`,
+			expected: query{
+				project: "TEST",
+				after: []updatedClause{
+					{op: ">", time: day},
+					{op: ">=", time: day.Add(16*time.Hour + 30*time.Minute)},
+				},
+				order: []orderBy{{field: "updated"}, {field: "key"}},
+			},
+		},
+		{
+			name:     "IDs",
+			jql:      "id in (10101,10102) ORDER BY key DESC",
+			expected: query{ids: map[string]bool{"10101": true, "10102": true}, order: []orderBy{{field: "key", desc: true}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := parseJQL(tt.jql, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, q)
+		})
+	}
+
+	t.Run("Unsupported", func(t *testing.T) {
+		for _, jql := range []string{"assignee = bob", "project=TEST ORDER BY priority", `updated > "yesterday"`} {
+			_, err := parseJQL(jql, time.UTC)
+			assert.Error(t, err, jql)
+		}
+	})
+
+	t.Run("Location", func(t *testing.T) {
+		loc := time.FixedZone("MSK", 3*60*60)
+		q, err := parseJQL(`updated >= "2025/03/05 16:30"`, loc)
+		require.NoError(t, err)
+		assert.True(t, q.after[0].time.Equal(time.Date(2025, 3, 5, 13, 30, 0, 0, time.UTC)))
+	})
+}
+
+func TestCompareKeys(t *testing.T) {
+	assert.Negative(t, compareKeys("TEST-2", "TEST-10"))
+	assert.Positive(t, compareKeys("TEST-1", "DEMO-5"))
+	assert.Zero(t, compareKeys("TEST-4", "TEST-4"))
+}
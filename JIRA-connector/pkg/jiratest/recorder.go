@@ -0,0 +1,360 @@
+package jiratest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// forwardedHeaders are copied from the client to Jira and back
+var forwardedHeaders = []string{
+	"Authorization", "Accept", "Content-Type",
+	"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset",
+}
+
+// Recorder proxies requests to a real Jira instance and saves the successful responses
+// as the fixtures served by Server
+type Recorder struct {
+	target *url.URL
+	dir    string
+	client *http.Client
+
+	mu       sync.Mutex
+	projects []project
+	issues   []*issue
+	fields   json.RawMessage
+}
+
+// NewRecorder proxies to the Jira base URL, fixtures already recorded in dir are kept
+func NewRecorder(target, dir string) (*Recorder, error) {
+	u, err := url.Parse(strings.TrimRight(target, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid jira url: %w", err)
+	}
+	r := &Recorder{target: u, dir: dir, client: &http.Client{}}
+	if _, err = os.Stat(dir); err == nil {
+		if r.projects, r.issues, err = load(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+		if r.fields, err = loadFields(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	upstream := *rec.target
+	upstream.Path = rec.target.Path + r.URL.Path
+	upstream.RawQuery = r.URL.RawQuery
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, upstream.String(), r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	copyHeaders(req.Header, r.Header)
+
+	resp, err := rec.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	if r.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		if err = rec.record(apiPrefix.ReplaceAllString(r.URL.Path, ""), body); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to record: %v", err))
+			return
+		}
+	}
+
+	copyHeaders(w.Header(), resp.Header)
+	w.WriteHeader(resp.StatusCode)
+	w.Write(body)
+}
+
+func copyHeaders(dst, src http.Header) {
+	for _, h := range forwardedHeaders {
+		if v := src.Get(h); v != "" {
+			dst.Set(h, v)
+		}
+	}
+}
+
+func (rec *Recorder) record(route string, body []byte) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	switch {
+	case route == "/project":
+		var raw []json.RawMessage
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return err
+		}
+		for _, r := range raw {
+			if err := rec.addProject(r, false); err != nil {
+				return err
+			}
+		}
+	case strings.HasPrefix(route, "/project/") && strings.HasSuffix(route, "/versions"):
+		key := strings.TrimSuffix(strings.TrimPrefix(route, "/project/"), "/versions")
+		if !rec.addVersions(key, body) {
+			return nil
+		}
+	case strings.HasPrefix(route, "/project/"):
+		if err := rec.addProject(body, true); err != nil {
+			return err
+		}
+	case route == "/field":
+		var fields []json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return err
+		}
+		rec.fields = body
+	case route == "/search" || route == "/search/jql":
+		var result struct {
+			Issues []map[string]json.RawMessage `json:"issues"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return err
+		}
+		for _, r := range result.Issues {
+			if err := rec.addIssue(r); err != nil {
+				return err
+			}
+		}
+	case strings.HasPrefix(route, "/issue/") && strings.HasSuffix(route, "/changelog"):
+		var page struct {
+			Values []json.RawMessage `json:"values"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		key := strings.TrimSuffix(strings.TrimPrefix(route, "/issue/"), "/changelog")
+		if !rec.addHistories(key, page.Values) {
+			return nil
+		}
+	case strings.HasPrefix(route, "/issue/") && pagedFields[path.Base(route)] != "":
+		field := path.Base(route)
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(page[pagedFields[field]], &items); err != nil {
+			return err
+		}
+		if !rec.addListItems(path.Base(path.Dir(route)), field, items) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return rec.save()
+}
+
+// addProject keeps the detailed /project/{key} answer over the /project list item
+func (rec *Recorder) addProject(raw json.RawMessage, detailed bool) error {
+	p, err := parseProject(raw)
+	if err != nil {
+		return err
+	}
+	for i := range rec.projects {
+		if rec.projects[i].key == p.key {
+			if detailed {
+				rec.projects[i] = p
+			}
+			return nil
+		}
+	}
+	rec.projects = append(rec.projects, p)
+	return nil
+}
+
+// addVersions stores the versions in the project fixture, as the detailed project answer lists them too.
+// It returns false if the project is not recorded.
+func (rec *Recorder) addVersions(keyOrID string, versions json.RawMessage) bool {
+	for i, p := range rec.projects {
+		if !strings.EqualFold(p.key, keyOrID) && p.id != keyOrID {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(p.raw, &fields); err != nil {
+			return false
+		}
+		fields["versions"] = versions
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return false
+		}
+		rec.projects[i].raw = raw
+		return true
+	}
+	return false
+}
+
+// addIssue merges the issue with the recorded one, so a page requested with fewer fields,
+// without changelog or with cut changelog and worklogs does not erase them
+func (rec *Recorder) addIssue(raw map[string]json.RawMessage) error {
+	parsed, err := parseIssue(raw)
+	if err != nil {
+		return err
+	}
+	for _, recorded := range rec.issues {
+		if recorded.key != parsed.key {
+			continue
+		}
+		fields := make(map[string]json.RawMessage)
+		_ = json.Unmarshal(recorded.raw["fields"], &fields)
+		_ = json.Unmarshal(raw["fields"], &fields)
+		histories := recorded.histories()
+		lists := make(map[string][]json.RawMessage, len(pagedFields))
+		for field := range pagedFields {
+			lists[field] = recorded.list(field)
+		}
+		for k, v := range raw {
+			recorded.raw[k] = v
+		}
+		if recorded.raw["fields"], err = json.Marshal(fields); err != nil {
+			return err
+		}
+		if _, ok := recorded.raw["changelog"]; ok {
+			recorded.setHistories(mergeByID(histories, recorded.histories()))
+		}
+		for field, items := range lists {
+			if _, ok := fields[field]; ok {
+				recorded.setList(field, mergeByID(items, recorded.list(field)))
+			}
+		}
+		merged, err := parseIssue(recorded.raw)
+		if err != nil {
+			return err
+		}
+		*recorded = *merged
+		return nil
+	}
+	rec.issues = append(rec.issues, parsed)
+	return nil
+}
+
+// addHistories adds a page of /issue/{key}/changelog to the recorded issue, histories
+// already embedded in search results are skipped. It reports false if the issue is not recorded yet.
+func (rec *Recorder) addHistories(keyOrID string, values []json.RawMessage) bool {
+	for _, recorded := range rec.issues {
+		if strings.EqualFold(recorded.key, keyOrID) || recorded.id == keyOrID {
+			recorded.setHistories(mergeByID(recorded.histories(), values))
+			return true
+		}
+	}
+	return false
+}
+
+func (i *issue) setHistories(histories []json.RawMessage) {
+	i.raw["changelog"], _ = json.Marshal(map[string]any{
+		"startAt":    0,
+		"maxResults": len(histories),
+		"total":      len(histories),
+		"histories":  histories,
+	})
+}
+
+// addListItems adds a page of /issue/{key}/worklog or /issue/{key}/comment to the recorded issue.
+// It reports false if the issue is not recorded yet.
+func (rec *Recorder) addListItems(keyOrID, field string, items []json.RawMessage) bool {
+	for _, recorded := range rec.issues {
+		if strings.EqualFold(recorded.key, keyOrID) || recorded.id == keyOrID {
+			recorded.setList(field, mergeByID(recorded.list(field), items))
+			return true
+		}
+	}
+	return false
+}
+
+func (i *issue) setList(field string, items []json.RawMessage) {
+	fields := make(map[string]json.RawMessage)
+	_ = json.Unmarshal(i.raw["fields"], &fields)
+	fields[field], _ = json.Marshal(listPage(pagedFields[field], items, 0, len(items)))
+	i.raw["fields"], _ = json.Marshal(fields)
+}
+
+// mergeByID appends the values missing from recorded, the items of paged lists are told apart by id
+func mergeByID(recorded, values []json.RawMessage) []json.RawMessage {
+	seen := make(map[string]bool, len(recorded))
+	for _, v := range recorded {
+		seen[itemID(v)] = true
+	}
+	for _, v := range values {
+		if id := itemID(v); id == "" || !seen[id] {
+			seen[id] = true
+			recorded = append(recorded, v)
+		}
+	}
+	return recorded
+}
+
+func itemID(raw json.RawMessage) string {
+	var v struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(raw, &v)
+	return v.ID
+}
+
+func (rec *Recorder) save() error {
+	if err := os.MkdirAll(filepath.Join(rec.dir, issuesDir), 0o755); err != nil {
+		return err
+	}
+
+	projects := make([]json.RawMessage, 0, len(rec.projects))
+	for _, p := range rec.projects {
+		projects = append(projects, p.raw)
+	}
+	if err := writeFixture(filepath.Join(rec.dir, projectsFile), projects); err != nil {
+		return err
+	}
+	if rec.fields != nil {
+		if err := writeFixture(filepath.Join(rec.dir, fieldsFile), rec.fields); err != nil {
+			return err
+		}
+	}
+
+	byProject := make(map[string][]map[string]json.RawMessage)
+	sorted := append([]*issue(nil), rec.issues...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return compareKeys(sorted[a].key, sorted[b].key) < 0
+	})
+	for _, i := range sorted {
+		byProject[i.project] = append(byProject[i.project], i.raw)
+	}
+	for key, issues := range byProject {
+		if err := writeFixture(filepath.Join(rec.dir, issuesDir, key+".json"), issues); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFixture(name string, v any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,123 @@
+package jiratest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/pkg/jiratest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	// Настоящую Jira заменяет сервер с готовыми фикстурами
+	upstream := jiratest.Start(t, jiratest.Sample())
+	dir := filepath.Join(t.TempDir(), "fixtures")
+
+	recorder, err := jiratest.NewRecorder(upstream.URL+"/", dir)
+	require.NoError(t, err)
+	proxy := httptest.NewServer(recorder)
+	defer proxy.Close()
+
+	get(t, proxy.URL, "/project", nil, nil)
+	get(t, proxy.URL, "/project/TEST", nil, nil)
+	get(t, proxy.URL, "/project/DEMO/versions", nil, nil)
+	get(t, proxy.URL, "/field", nil, nil)
+	for _, startAt := range []string{"0", "3"} {
+		resp := get(t, proxy.URL, "/search", url.Values{
+			"jql":        {"project=TEST"},
+			"expand":     {"changelog"},
+			"maxResults": {"3"},
+			"startAt":    {startAt},
+		}, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	// Страница только с id не затирает записанные поля и историю
+	get(t, proxy.URL, "/search/jql", url.Values{"jql": {"project=TEST"}, "fields": {"id"}}, nil)
+
+	// Ошибки передаются клиенту и не записываются
+	upstream.FailNext(1, jiratest.Fault{Status: http.StatusTooManyRequests, RetryAfter: 5 * time.Second})
+	resp := get(t, proxy.URL, "/search", url.Values{"jql": {"project=DEMO"}}, nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "5", resp.Header.Get("Retry-After"))
+
+	assert.FileExists(t, filepath.Join(dir, "projects.json"))
+	assert.FileExists(t, filepath.Join(dir, "fields.json"))
+	assert.FileExists(t, filepath.Join(dir, "issues", "TEST.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "issues", "DEMO.json"))
+
+	replay := jiratest.Start(t, os.DirFS(dir))
+
+	var projects []struct {
+		Key string `json:"key"`
+	}
+	get(t, replay.URL, "/project", nil, &projects)
+	assert.Len(t, projects, 2)
+	var versions []json.RawMessage
+	get(t, replay.URL, "/project/TEST/versions", nil, &versions)
+	assert.Len(t, versions, 2)
+	var fields []json.RawMessage
+	get(t, replay.URL, "/field", nil, &fields)
+	assert.NotEmpty(t, fields)
+
+	var result searchResult
+	get(t, replay.URL, "/search", url.Values{
+		"jql":    {`project=TEST AND updated > "2025/03/10"`},
+		"expand": {"changelog"},
+	}, &result)
+	assert.Equal(t, []string{"TEST-2", "TEST-3", "TEST-10"}, result.keys())
+	require.NotNil(t, result.Issues[2].Changelog)
+	assert.Len(t, result.Issues[2].Changelog.Histories, 2)
+	assert.Contains(t, result.Issues[2].Fields, "summary")
+
+	// Повторная запись дополняет фикстуры
+	recorder, err = jiratest.NewRecorder(upstream.URL, dir)
+	require.NoError(t, err)
+	proxy2 := httptest.NewServer(recorder)
+	defer proxy2.Close()
+	get(t, proxy2.URL, "/search", url.Values{"jql": {"project=DEMO"}}, nil)
+	assert.FileExists(t, filepath.Join(dir, "issues", "DEMO.json"))
+	assert.FileExists(t, filepath.Join(dir, "issues", "TEST.json"))
+}
+
+func TestRecorder_PagedLists(t *testing.T) {
+	upstream := jiratest.Start(t, jiratest.Sample(), jiratest.WithChangelogLimit(1), jiratest.WithWorklogLimit(1),
+		jiratest.WithCommentLimit(1))
+	dir := t.TempDir()
+
+	recorder, err := jiratest.NewRecorder(upstream.URL, dir)
+	require.NoError(t, err)
+	proxy := httptest.NewServer(recorder)
+	defer proxy.Close()
+
+	get(t, proxy.URL, "/search", url.Values{"jql": {"project=TEST"}, "expand": {"changelog"}}, nil)
+	get(t, proxy.URL, "/issue/TEST-1/changelog", url.Values{"startAt": {"0"}}, nil)
+	get(t, proxy.URL, "/issue/TEST-1/worklog", nil, nil)
+	get(t, proxy.URL, "/issue/TEST-1/comment", url.Values{"startAt": {"1"}}, nil)
+	// Повторная страница поиска с обрезанными списками не затирает полные
+	get(t, proxy.URL, "/search", url.Values{"jql": {"project=TEST"}, "expand": {"changelog"}}, nil)
+
+	replay := jiratest.Start(t, os.DirFS(dir))
+	var result searchResult
+	get(t, replay.URL, "/search", url.Values{"jql": {"id in (10101)"}, "expand": {"changelog"}}, &result)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, 2, result.Issues[0].Changelog.Total)
+	assert.Len(t, result.Issues[0].Changelog.Histories, 2)
+	var worklog struct {
+		Worklogs []json.RawMessage `json:"worklogs"`
+	}
+	require.NoError(t, json.Unmarshal(result.Issues[0].Fields["worklog"], &worklog))
+	assert.Len(t, worklog.Worklogs, 3)
+	var comment struct {
+		Comments []json.RawMessage `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(result.Issues[0].Fields["comment"], &comment))
+	assert.Len(t, comment.Comments, 3)
+}
@@ -0,0 +1,651 @@
+// Package jiratest is an in-process fake of the Jira REST API serving fixture files.
+//
+// Fixtures are a directory with projects.json, the array returned by /project,
+// issues/<PROJECT>.json, the issues of every project as returned by /search with the full changelog,
+// and optionally fields.json, the array returned by /field.
+// Such a directory is written by Recorder from a real Jira instance.
+package jiratest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	projectsFile = "projects.json"
+	fieldsFile   = "fields.json"
+	issuesDir    = "issues"
+	// jiraTimeLayout is the layout of issue dates in the Jira REST API
+	jiraTimeLayout    = "2006-01-02T15:04:05.000-0700"
+	defaultMaxResults = 50
+	// defaultChangelogLimit is how many histories Jira embeds in search results
+	defaultChangelogLimit = 100
+	// defaultWorklogLimit is how many worklogs Jira embeds in search results
+	defaultWorklogLimit = 20
+	// defaultListMaxResults is the page size of /issue/{key}/worklog and /issue/{key}/comment,
+	// comments are not cut from search results by default either
+	defaultListMaxResults = 5000
+)
+
+// pagedFields are the issue fields Jira cuts in search results by their list name.
+// The rest of a list is served by /issue/{key}/<field>.
+var pagedFields = map[string]string{
+	"worklog": "worklogs",
+	"comment": "comments",
+}
+
+// apiPrefix is stripped from the request path, so the server answers both /rest/api/2 and /rest/api/3
+var apiPrefix = regexp.MustCompile(`^/rest/api/(2|3|latest)`)
+
+type project struct {
+	key string
+	id  string
+	raw json.RawMessage
+}
+
+type issue struct {
+	id      string
+	key     string
+	project string
+	updated time.Time
+	raw     map[string]json.RawMessage
+}
+
+// Fault is an error response sent instead of the fixture
+type Fault struct {
+	Status int
+	// RetryAfter is sent in the Retry-After header if positive
+	RetryAfter time.Duration
+	// Path limits the fault to the requests whose path contains it, e.g. "/search"
+	Path string
+}
+
+type fault struct {
+	Fault
+	// times left for FailNext, 0 for FailEvery
+	times int
+	every int
+	seen  int
+}
+
+type Server struct {
+	// URL is the base URL of the server started by Start, e.g. Jira BaseURL
+	URL string
+
+	mu       sync.Mutex
+	location *time.Location
+	// limits are the sizes of the lists embedded in search results, by field
+	limits   map[string]int
+	projects []project
+	issues   []*issue
+	fields   json.RawMessage
+	faults   []*fault
+	requests []string
+}
+
+type Option func(*Server)
+
+// WithLocation sets the timezone JQL dates are read in, UTC by default
+func WithLocation(loc *time.Location) Option {
+	return func(s *Server) {
+		s.location = loc
+	}
+}
+
+// WithChangelogLimit sets how many histories are embedded in search results, 100 like Jira by default.
+// The rest is served only by /issue/{key}/changelog.
+func WithChangelogLimit(limit int) Option {
+	return func(s *Server) {
+		s.limits["changelog"] = limit
+	}
+}
+
+// WithWorklogLimit sets how many worklogs are embedded in search results, 20 like Jira by default.
+// The rest is served only by /issue/{key}/worklog.
+func WithWorklogLimit(limit int) Option {
+	return func(s *Server) {
+		s.limits["worklog"] = limit
+	}
+}
+
+// WithCommentLimit sets how many comments are embedded in search results, all of them by default.
+// The rest is served only by /issue/{key}/comment.
+func WithCommentLimit(limit int) Option {
+	return func(s *Server) {
+		s.limits["comment"] = limit
+	}
+}
+
+// New loads the fixtures, the server is used as an http.Handler
+func New(fixtures fs.FS, opts ...Option) (*Server, error) {
+	s := &Server{
+		location: time.UTC,
+		limits: map[string]int{
+			"changelog": defaultChangelogLimit,
+			"worklog":   defaultWorklogLimit,
+			"comment":   defaultListMaxResults,
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	projects, issues, err := load(fixtures)
+	if err != nil {
+		return nil, err
+	}
+	s.projects = projects
+	s.issues = issues
+	if s.fields, err = loadFields(fixtures); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start serves the fixtures on a local port until the test ends
+func Start(t testing.TB, fixtures fs.FS, opts ...Option) *Server {
+	t.Helper()
+	s, err := New(fixtures, opts...)
+	if err != nil {
+		t.Fatalf("failed to load jira fixtures: %v", err)
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	s.URL = srv.URL
+	return s
+}
+
+func load(fixtures fs.FS) ([]project, []*issue, error) {
+	var projects []project
+	data, err := fs.ReadFile(fixtures, projectsFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", projectsFile, err)
+	}
+	if err == nil {
+		var raw []json.RawMessage
+		if err = json.Unmarshal(data, &raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", projectsFile, err)
+		}
+		for _, r := range raw {
+			p, err := parseProject(r)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse %s: %w", projectsFile, err)
+			}
+			projects = append(projects, p)
+		}
+	}
+
+	files, err := fs.Glob(fixtures, path.Join(issuesDir, "*.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var issues []*issue
+	for _, file := range files {
+		data, err := fs.ReadFile(fixtures, file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var raw []map[string]json.RawMessage
+		if err = json.Unmarshal(data, &raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		for _, r := range raw {
+			i, err := parseIssue(r)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			issues = append(issues, i)
+		}
+	}
+	return projects, issues, nil
+}
+
+// loadFields reads the optional fields.json, nil if there is none
+func loadFields(fixtures fs.FS) (json.RawMessage, error) {
+	data, err := fs.ReadFile(fixtures, fieldsFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fieldsFile, err)
+	}
+	var fields []json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fieldsFile, err)
+	}
+	return data, nil
+}
+
+func parseProject(raw json.RawMessage) (project, error) {
+	var p struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return project{}, err
+	}
+	if p.Key == "" {
+		return project{}, errors.New("project without key")
+	}
+	return project{key: p.Key, id: p.ID, raw: raw}, nil
+}
+
+func parseIssue(raw map[string]json.RawMessage) (*issue, error) {
+	var i struct {
+		ID     string `json:"id"`
+		Key    string `json:"key"`
+		Fields struct {
+			Project struct {
+				Key string `json:"key"`
+			} `json:"project"`
+			Updated string `json:"updated"`
+		} `json:"fields"`
+	}
+	data, _ := json.Marshal(raw)
+	if err := json.Unmarshal(data, &i); err != nil {
+		return nil, err
+	}
+	if i.Key == "" {
+		return nil, errors.New("issue without key")
+	}
+	parsed := &issue{id: i.ID, key: i.Key, project: i.Fields.Project.Key, raw: raw}
+	if parsed.project == "" {
+		parsed.project, _ = splitKey(i.Key)
+	}
+	if i.Fields.Updated != "" {
+		updated, err := time.Parse(jiraTimeLayout, i.Fields.Updated)
+		if err != nil {
+			return nil, fmt.Errorf("issue %s: %w", i.Key, err)
+		}
+		parsed.updated = updated
+	}
+	return parsed, nil
+}
+
+// FailNext answers the next times matching requests with the fault
+func (s *Server) FailNext(times int, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{Fault: f, times: times})
+}
+
+// FailEvery answers every n-th matching request with the fault
+func (s *Server) FailEvery(n int, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{Fault: f, every: n})
+}
+
+// Requests returns the number of requests whose path contains p, faulted ones included
+func (s *Server) Requests(p string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, r := range s.requests {
+		if strings.Contains(r, p) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route := apiPrefix.ReplaceAllString(r.URL.Path, "")
+	if f := s.nextFault(route); f != nil {
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+		}
+		writeError(w, f.Status, http.StatusText(f.Status))
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	switch {
+	case route == "/project":
+		s.serveProjects(w)
+	case strings.HasPrefix(route, "/project/") && strings.HasSuffix(route, "/versions"):
+		s.serveVersions(w, strings.TrimSuffix(strings.TrimPrefix(route, "/project/"), "/versions"))
+	case strings.HasPrefix(route, "/project/"):
+		s.serveProject(w, strings.TrimPrefix(route, "/project/"))
+	case route == "/field":
+		s.serveFields(w)
+	case route == "/search":
+		s.serveSearch(w, r, false)
+	case route == "/search/jql":
+		s.serveSearch(w, r, true)
+	case strings.HasPrefix(route, "/issue/") && strings.HasSuffix(route, "/changelog"):
+		s.serveChangelog(w, r, strings.TrimSuffix(strings.TrimPrefix(route, "/issue/"), "/changelog"))
+	case strings.HasPrefix(route, "/issue/") && pagedFields[path.Base(route)] != "":
+		s.serveField(w, r, path.Base(path.Dir(route)), path.Base(route))
+	case strings.HasPrefix(route, "/issue/") && strings.Count(route, "/") == 2:
+		s.serveIssue(w, r, strings.TrimPrefix(route, "/issue/"))
+	default:
+		writeError(w, http.StatusNotFound, "null for uri: "+r.URL.Path)
+	}
+}
+
+func (s *Server) nextFault(route string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, route)
+	for i, f := range s.faults {
+		if !strings.Contains(route, f.Path) {
+			continue
+		}
+		if f.every > 0 {
+			f.seen++
+			if f.seen%f.every == 0 {
+				return &f.Fault
+			}
+			continue
+		}
+		f.times--
+		if f.times <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return &f.Fault
+	}
+	return nil
+}
+
+func (s *Server) serveProjects(w http.ResponseWriter) {
+	s.mu.Lock()
+	projects := make([]json.RawMessage, 0, len(s.projects))
+	for _, p := range s.projects {
+		projects = append(projects, p.raw)
+	}
+	s.mu.Unlock()
+	writeJSON(w, projects)
+}
+
+func (s *Server) serveProject(w http.ResponseWriter, keyOrID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.projects {
+		if strings.EqualFold(p.key, keyOrID) || p.id == keyOrID {
+			writeJSON(w, p.raw)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("No project could be found with key '%s'.", keyOrID))
+}
+
+// serveFields answers /field with fields.json or an empty list
+func (s *Server) serveFields(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fields == nil {
+		writeJSON(w, []json.RawMessage{})
+		return
+	}
+	writeJSON(w, s.fields)
+}
+
+// serveVersions answers /project/{key}/versions with the versions listed in the project fixture
+func (s *Server) serveVersions(w http.ResponseWriter, keyOrID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.projects {
+		if strings.EqualFold(p.key, keyOrID) || p.id == keyOrID {
+			var fields struct {
+				Versions []json.RawMessage `json:"versions"`
+			}
+			_ = json.Unmarshal(p.raw, &fields)
+			if fields.Versions == nil {
+				fields.Versions = []json.RawMessage{}
+			}
+			writeJSON(w, fields.Versions)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("No project could be found with key '%s'.", keyOrID))
+}
+
+// serveSearch answers /search with startAt pages and /search/jql with nextPageToken pages
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request, token bool) {
+	params := r.URL.Query()
+	q, err := parseJQL(params.Get("jql"), s.location)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	maxResults := defaultMaxResults
+	if v := params.Get("maxResults"); v != "" {
+		if maxResults, err = strconv.Atoi(v); err != nil || maxResults < 0 {
+			writeError(w, http.StatusBadRequest, "invalid maxResults")
+			return
+		}
+	}
+	startParam := "startAt"
+	if token {
+		startParam = "nextPageToken"
+	}
+	startAt := 0
+	if v := params.Get(startParam); v != "" {
+		if startAt, err = strconv.Atoi(v); err != nil || startAt < 0 {
+			writeError(w, http.StatusBadRequest, "invalid "+startParam)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	matched := make([]*issue, 0)
+	for _, i := range s.issues {
+		if q.match(i) {
+			matched = append(matched, i)
+		}
+	}
+	s.mu.Unlock()
+	q.sort(matched)
+
+	end := min(startAt+maxResults, len(matched))
+	page := make([]map[string]json.RawMessage, 0)
+	changelog := strings.Contains(params.Get("expand"), "changelog")
+	fields := splitFields(params.Get("fields"))
+	for _, i := range matched[min(startAt, end):end] {
+		page = append(page, i.render(changelog, s.limits, fields))
+	}
+
+	if token {
+		response := map[string]any{"issues": page, "isLast": end >= len(matched)}
+		if end < len(matched) {
+			response["nextPageToken"] = strconv.Itoa(end)
+		}
+		writeJSON(w, response)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(matched),
+		"issues":     page,
+	})
+}
+
+// serveChangelog answers /issue/{key}/changelog with startAt pages of the issue histories
+func (s *Server) serveChangelog(w http.ResponseWriter, r *http.Request, keyOrID string) {
+	found, startAt, maxResults, ok := s.issuePage(w, r, keyOrID, defaultChangelogLimit)
+	if !ok {
+		return
+	}
+	histories := found.histories()
+	end := min(startAt+maxResults, len(histories))
+	writeJSON(w, map[string]any{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(histories),
+		"isLast":     end >= len(histories),
+		"values":     histories[min(startAt, end):end],
+	})
+}
+
+// serveIssue answers /issue/{key} with the issue and, if expanded, its whole changelog like Jira Server
+func (s *Server) serveIssue(w http.ResponseWriter, r *http.Request, keyOrID string) {
+	params := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, i := range s.issues {
+		if strings.EqualFold(i.key, keyOrID) || i.id == keyOrID {
+			limits := make(map[string]int, len(s.limits))
+			for k, v := range s.limits {
+				limits[k] = v
+			}
+			limits["changelog"] = len(i.histories())
+			changelog := strings.Contains(params.Get("expand"), "changelog")
+			writeJSON(w, i.render(changelog, limits, splitFields(params.Get("fields"))))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+}
+
+// serveField answers /issue/{key}/<field> with startAt pages of a list cut in search results
+func (s *Server) serveField(w http.ResponseWriter, r *http.Request, keyOrID, field string) {
+	found, startAt, maxResults, ok := s.issuePage(w, r, keyOrID, defaultListMaxResults)
+	if !ok {
+		return
+	}
+	writeJSON(w, listPage(pagedFields[field], found.list(field), startAt, maxResults))
+}
+
+// issuePage finds the issue of an /issue/{key}/... request and reads its paging parameters
+func (s *Server) issuePage(w http.ResponseWriter, r *http.Request, keyOrID string,
+	defaultMax int) (*issue, int, int, bool) {
+	params := r.URL.Query()
+	startAt, maxResults := 0, defaultMax
+	var err error
+	if v := params.Get("startAt"); v != "" {
+		if startAt, err = strconv.Atoi(v); err != nil || startAt < 0 {
+			writeError(w, http.StatusBadRequest, "invalid startAt")
+			return nil, 0, 0, false
+		}
+	}
+	if v := params.Get("maxResults"); v != "" {
+		if maxResults, err = strconv.Atoi(v); err != nil || maxResults < 0 {
+			writeError(w, http.StatusBadRequest, "invalid maxResults")
+			return nil, 0, 0, false
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, i := range s.issues {
+		if strings.EqualFold(i.key, keyOrID) || i.id == keyOrID {
+			return i, startAt, maxResults, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+	return nil, 0, 0, false
+}
+
+// listPage is a page of a list field in the Jira format, e.g. {"startAt": 0, "total": 1, "worklogs": [...]}
+func listPage(name string, items []json.RawMessage, startAt, maxResults int) map[string]any {
+	end := min(startAt+maxResults, len(items))
+	return map[string]any{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(items),
+		name:         items[min(startAt, end):end],
+	}
+}
+
+// splitFields returns nil if every field is requested
+func splitFields(value string) map[string]bool {
+	if value == "" || value == "*all" || value == "*navigable" {
+		return nil
+	}
+	fields := make(map[string]bool)
+	for _, f := range strings.Split(value, ",") {
+		fields[strings.TrimSpace(f)] = true
+	}
+	return fields
+}
+
+func (i *issue) render(changelog bool, limits map[string]int, fields map[string]bool) map[string]json.RawMessage {
+	out := make(map[string]json.RawMessage, len(i.raw))
+	for k, v := range i.raw {
+		out[k] = v
+	}
+	if !changelog {
+		delete(out, "changelog")
+	} else if _, ok := i.raw["changelog"]; ok {
+		histories := i.histories()
+		embedded := histories[:min(len(histories), limits["changelog"])]
+		out["changelog"], _ = json.Marshal(map[string]any{
+			"startAt":    0,
+			"maxResults": len(embedded),
+			"total":      len(histories),
+			"histories":  embedded,
+		})
+	}
+	if _, ok := i.raw["fields"]; !ok {
+		return out
+	}
+	var all map[string]json.RawMessage
+	_ = json.Unmarshal(i.raw["fields"], &all)
+	selected := make(map[string]json.RawMessage, len(all))
+	for k, v := range all {
+		if fields != nil && !fields[k] {
+			continue
+		}
+		if name, ok := pagedFields[k]; ok && string(v) != "null" {
+			v, _ = json.Marshal(listPage(name, i.list(k), 0, limits[k]))
+		}
+		selected[k] = v
+	}
+	out["fields"], _ = json.Marshal(selected)
+	return out
+}
+
+// list returns the items of a paged field, e.g. the worklogs of fields.worklog
+func (i *issue) list(field string) []json.RawMessage {
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(i.raw["fields"], &fields)
+	var value map[string]json.RawMessage
+	_ = json.Unmarshal(fields[field], &value)
+	var items []json.RawMessage
+	_ = json.Unmarshal(value[pagedFields[field]], &items)
+	if items == nil {
+		return []json.RawMessage{}
+	}
+	return items
+}
+
+func (i *issue) histories() []json.RawMessage {
+	var changelog struct {
+		Histories []json.RawMessage `json:"histories"`
+	}
+	_ = json.Unmarshal(i.raw["changelog"], &changelog)
+	if changelog.Histories == nil {
+		return []json.RawMessage{}
+	}
+	return changelog.Histories
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers in the error format of Jira
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"errorMessages": []string{message},
+		"errors":        map[string]string{},
+	})
+}
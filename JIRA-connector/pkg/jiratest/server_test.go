@@ -0,0 +1,348 @@
+package jiratest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sssidkn/jira-connector/pkg/jiratest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchResult struct {
+	StartAt       int    `json:"startAt"`
+	MaxResults    int    `json:"maxResults"`
+	Total         int    `json:"total"`
+	NextPageToken string `json:"nextPageToken"`
+	IsLast        bool   `json:"isLast"`
+	Issues        []struct {
+		ID        string                     `json:"id"`
+		Key       string                     `json:"key"`
+		Fields    map[string]json.RawMessage `json:"fields"`
+		Changelog *struct {
+			Total     int               `json:"total"`
+			Histories []json.RawMessage `json:"histories"`
+		} `json:"changelog"`
+	} `json:"issues"`
+}
+
+func (r searchResult) keys() []string {
+	keys := make([]string, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		keys = append(keys, issue.Key)
+	}
+	return keys
+}
+
+func get(t *testing.T, baseURL, path string, params url.Values, result any) *http.Response {
+	t.Helper()
+	link := baseURL + "/rest/api/2" + path
+	if len(params) > 0 {
+		link += "?" + params.Encode()
+	}
+	resp, err := http.Get(link)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if result != nil && resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+	return resp
+}
+
+func TestServer_Projects(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+
+	var projects []struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	}
+	get(t, server.URL, "/project", nil, &projects)
+	require.Len(t, projects, 2)
+	assert.Equal(t, "TEST", projects[0].Key)
+
+	var project struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	resp := get(t, server.URL, "/project/DEMO", url.Values{"expand": {"lead"}}, &project)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "10001", project.ID)
+	assert.Equal(t, "Demo Project", project.Name)
+
+	resp = get(t, server.URL, "/project/NONE", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_Fields(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+
+	var fields []struct {
+		ID     string `json:"id"`
+		Custom bool   `json:"custom"`
+	}
+	get(t, server.URL, "/field", nil, &fields)
+	require.NotEmpty(t, fields)
+	assert.Equal(t, "summary", fields[0].ID)
+	assert.False(t, fields[0].Custom)
+
+	// Без fields.json список полей пустой
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/projects.json", []byte(`[]`), 0o644))
+	empty := jiratest.Start(t, os.DirFS(dir))
+	resp := get(t, empty.URL, "/field", nil, &fields)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, fields)
+}
+
+func TestServer_Versions(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+
+	var versions []struct {
+		Name        string `json:"name"`
+		Released    bool   `json:"released"`
+		ReleaseDate string `json:"releaseDate"`
+	}
+	get(t, server.URL, "/project/TEST/versions", nil, &versions)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.0", versions[0].Name)
+	assert.True(t, versions[0].Released)
+	assert.Equal(t, "2025-03-10", versions[0].ReleaseDate)
+
+	// У проекта без версий список пустой, а не null
+	resp := get(t, server.URL, "/project/DEMO/versions", nil, &versions)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, versions)
+
+	resp = get(t, server.URL, "/project/NONE/versions", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_Search(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+
+	t.Run("Pages", func(t *testing.T) {
+		var first, last searchResult
+		get(t, server.URL, "/search", url.Values{"jql": {"project=TEST"}, "maxResults": {"2"}}, &first)
+		assert.Equal(t, 5, first.Total)
+		assert.Equal(t, []string{"TEST-1", "TEST-2"}, first.keys())
+		assert.Nil(t, first.Issues[0].Changelog)
+
+		get(t, server.URL, "/search", url.Values{"jql": {"project=TEST"}, "maxResults": {"2"}, "startAt": {"4"}}, &last)
+		assert.Equal(t, []string{"TEST-10"}, last.keys())
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		var result searchResult
+		get(t, server.URL, "/search", url.Values{"jql": {"project=DEMO"}, "maxResults": {"0"}}, &result)
+		assert.Equal(t, 1, result.Total)
+		assert.Empty(t, result.Issues)
+	})
+
+	t.Run("UpdatedAfter", func(t *testing.T) {
+		var result searchResult
+		get(t, server.URL, "/search", url.Values{
+			"jql": {`(project=TEST) AND updated >= "2025/03/10 12:00" ORDER BY updated DESC`},
+		}, &result)
+		assert.Equal(t, []string{"TEST-10", "TEST-3", "TEST-2"}, result.keys())
+	})
+
+	t.Run("ChangelogAndFields", func(t *testing.T) {
+		var result searchResult
+		get(t, server.URL, "/search", url.Values{
+			"jql":    {"project=TEST"},
+			"expand": {"changelog"},
+			"fields": {"summary, status"},
+		}, &result)
+		require.NotNil(t, result.Issues[0].Changelog)
+		assert.Len(t, result.Issues[0].Changelog.Histories, 2)
+		assert.Len(t, result.Issues[0].Fields, 2)
+		assert.JSONEq(t, `"Login fails on Safari"`, string(result.Issues[0].Fields["summary"]))
+	})
+
+	t.Run("Token", func(t *testing.T) {
+		var keys []string
+		token := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 5)
+			params := url.Values{"jql": {"project=TEST ORDER BY key DESC"}, "maxResults": {"2"}, "fields": {"id"}}
+			if token != "" {
+				params.Set("nextPageToken", token)
+			}
+			var result searchResult
+			get(t, server.URL, "/search/jql", params, &result)
+			keys = append(keys, result.keys()...)
+			if result.IsLast {
+				assert.Empty(t, result.NextPageToken)
+				break
+			}
+			token = result.NextPageToken
+		}
+		assert.Equal(t, []string{"TEST-10", "TEST-4", "TEST-3", "TEST-2", "TEST-1"}, keys)
+
+		var result searchResult
+		get(t, server.URL, "/search/jql", url.Values{"jql": {"id in (10102,10201)"}}, &result)
+		assert.Equal(t, []string{"DEMO-1", "TEST-2"}, result.keys())
+	})
+
+	t.Run("UnsupportedJQL", func(t *testing.T) {
+		resp := get(t, server.URL, "/search", url.Values{"jql": {"assignee = bob"}}, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestServer_Changelog(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample(), jiratest.WithChangelogLimit(1))
+
+	// В результатах поиска история обрезается, как в Jira
+	var result searchResult
+	get(t, server.URL, "/search", url.Values{"jql": {"id in (10101)"}, "expand": {"changelog"}}, &result)
+	require.Len(t, result.Issues, 1)
+	require.NotNil(t, result.Issues[0].Changelog)
+	assert.Equal(t, 2, result.Issues[0].Changelog.Total)
+	assert.Len(t, result.Issues[0].Changelog.Histories, 1)
+
+	var page struct {
+		StartAt int  `json:"startAt"`
+		Total   int  `json:"total"`
+		IsLast  bool `json:"isLast"`
+		Values  []struct {
+			ID string `json:"id"`
+		} `json:"values"`
+	}
+	get(t, server.URL, "/issue/TEST-1/changelog", url.Values{"startAt": {"1"}, "maxResults": {"1"}}, &page)
+	assert.Equal(t, 2, page.Total)
+	assert.True(t, page.IsLast)
+	require.Len(t, page.Values, 1)
+	assert.Equal(t, "101011", page.Values[0].ID)
+
+	resp := get(t, server.URL, "/issue/NONE-1/changelog", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Задача отдельно возвращается со всей историей, как в Jira Server
+	var issue struct {
+		Key       string `json:"key"`
+		Changelog struct {
+			Total     int               `json:"total"`
+			Histories []json.RawMessage `json:"histories"`
+		} `json:"changelog"`
+	}
+	get(t, server.URL, "/issue/TEST-1", url.Values{"expand": {"changelog"}}, &issue)
+	assert.Equal(t, "TEST-1", issue.Key)
+	assert.Equal(t, 2, issue.Changelog.Total)
+	assert.Len(t, issue.Changelog.Histories, 2)
+
+	resp = get(t, server.URL, "/issue/NONE-1", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_Worklog(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample(), jiratest.WithWorklogLimit(1))
+
+	type worklogs struct {
+		StartAt  int `json:"startAt"`
+		Total    int `json:"total"`
+		Worklogs []struct {
+			ID string `json:"id"`
+		} `json:"worklogs"`
+	}
+
+	var result searchResult
+	get(t, server.URL, "/search", url.Values{"jql": {"id in (10101)"}, "fields": {"summary,worklog"}}, &result)
+	require.Len(t, result.Issues, 1)
+	var embedded worklogs
+	require.NoError(t, json.Unmarshal(result.Issues[0].Fields["worklog"], &embedded))
+	assert.Equal(t, 3, embedded.Total)
+	require.Len(t, embedded.Worklogs, 1)
+	assert.Equal(t, "10010", embedded.Worklogs[0].ID)
+
+	var page worklogs
+	get(t, server.URL, "/issue/TEST-1/worklog", url.Values{"startAt": {"1"}}, &page)
+	assert.Equal(t, 1, page.StartAt)
+	assert.Equal(t, 3, page.Total)
+	require.Len(t, page.Worklogs, 2)
+	assert.Equal(t, "10012", page.Worklogs[1].ID)
+}
+
+func TestServer_Comments(t *testing.T) {
+	type comments struct {
+		StartAt  int `json:"startAt"`
+		Total    int `json:"total"`
+		Comments []struct {
+			ID string `json:"id"`
+		} `json:"comments"`
+	}
+
+	t.Run("Embedded", func(t *testing.T) {
+		server := jiratest.Start(t, jiratest.Sample())
+		var result searchResult
+		get(t, server.URL, "/search", url.Values{"jql": {"id in (10101)"}, "fields": {"summary,comment"}}, &result)
+		require.Len(t, result.Issues, 1)
+		var embedded comments
+		require.NoError(t, json.Unmarshal(result.Issues[0].Fields["comment"], &embedded))
+		assert.Equal(t, 3, embedded.Total)
+		assert.Len(t, embedded.Comments, 3)
+	})
+
+	t.Run("Cut", func(t *testing.T) {
+		server := jiratest.Start(t, jiratest.Sample(), jiratest.WithCommentLimit(2))
+		var result searchResult
+		get(t, server.URL, "/search", url.Values{"jql": {"id in (10101)"}, "fields": {"summary,comment"}}, &result)
+		require.Len(t, result.Issues, 1)
+		var embedded comments
+		require.NoError(t, json.Unmarshal(result.Issues[0].Fields["comment"], &embedded))
+		assert.Equal(t, 3, embedded.Total)
+		require.Len(t, embedded.Comments, 2)
+
+		var page comments
+		get(t, server.URL, "/issue/TEST-1/comment", url.Values{"startAt": {"2"}, "maxResults": {"1"}}, &page)
+		assert.Equal(t, 2, page.StartAt)
+		assert.Equal(t, 3, page.Total)
+		require.Len(t, page.Comments, 1)
+		assert.Equal(t, "10022", page.Comments[0].ID)
+	})
+}
+
+func TestServer_Faults(t *testing.T) {
+	server := jiratest.Start(t, jiratest.Sample())
+
+	t.Run("FailNext", func(t *testing.T) {
+		server.FailNext(2, jiratest.Fault{Status: http.StatusTooManyRequests, RetryAfter: 3 * time.Second, Path: "/search"})
+
+		// Ошибка внедряется только в запросы к /search
+		resp := get(t, server.URL, "/project/TEST", nil, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		for i := 0; i < 2; i++ {
+			resp = get(t, server.URL, "/search", url.Values{"jql": {"project=TEST"}}, nil)
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Equal(t, "3", resp.Header.Get("Retry-After"))
+		}
+		resp = get(t, server.URL, "/search", url.Values{"jql": {"project=TEST"}}, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("FailEvery", func(t *testing.T) {
+		server.FailEvery(3, jiratest.Fault{Status: http.StatusServiceUnavailable, Path: "/project"})
+
+		var statuses []int
+		for i := 0; i < 6; i++ {
+			statuses = append(statuses, get(t, server.URL, "/project", nil, nil).StatusCode)
+		}
+		assert.Equal(t, []int{200, 200, 503, 200, 200, 503}, statuses)
+	})
+
+	assert.Equal(t, 3, server.Requests("/search"))
+}
+
+func TestNew_InvalidFixtures(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/projects.json", []byte(`{"key": "TEST"}`), 0o644))
+
+	_, err := jiratest.New(os.DirFS(dir))
+	assert.Error(t, err)
+}
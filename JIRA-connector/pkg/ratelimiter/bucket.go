@@ -0,0 +1,174 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of TokenBucket
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// BucketConfig is the request budget of one Jira instance
+type BucketConfig struct {
+	// Rate is the number of requests per second, 0 disables throttling
+	Rate float64 `yaml:"Rate" env:"RATE_LIMIT_RPS"`
+	// Burst is how many requests may be sent at once after an idle period, at least 1
+	Burst int `yaml:"Burst" env:"RATE_LIMIT_BURST"`
+	// MinRate is the lowest rate the bucket slows down to on 429s, Rate/10 by default
+	MinRate float64 `yaml:"MinRate" env:"RATE_LIMIT_MIN_RPS"`
+	// RecoverAfter is the number of successful requests in a row after which the rate is raised
+	RecoverAfter int `yaml:"RecoverAfter" env:"RATE_LIMIT_RECOVER_AFTER" env-default:"50"`
+}
+
+// TokenBucket throttles requests before they are sent. It slows down when the server
+// throttles (halving the rate) and speeds up again after sustained success
+// (adding a tenth of the configured rate).
+type TokenBucket struct {
+	mu           sync.Mutex
+	clock        Clock
+	maxRate      float64
+	minRate      float64
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	successes    int
+	recoverAfter int
+}
+
+type BucketOption func(*TokenBucket)
+
+// WithClock replaces the time source, used by tests
+func WithClock(clock Clock) BucketOption {
+	return func(b *TokenBucket) {
+		b.clock = clock
+	}
+}
+
+func NewTokenBucket(cfg BucketConfig, opts ...BucketOption) *TokenBucket {
+	b := &TokenBucket{
+		clock:        realClock{},
+		maxRate:      cfg.Rate,
+		minRate:      cfg.MinRate,
+		rate:         cfg.Rate,
+		burst:        float64(max(cfg.Burst, 1)),
+		recoverAfter: max(cfg.RecoverAfter, 1),
+	}
+	if b.minRate <= 0 || b.minRate > b.maxRate {
+		b.minRate = b.maxRate / 10
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.tokens = b.burst
+	b.last = b.clock.Now()
+	return b
+}
+
+// Wait blocks until the request may be sent
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait, ok := b.take()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.clock.After(wait):
+		}
+	}
+}
+
+// take takes a token or returns how long to wait for one
+func (b *TokenBucket) take() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxRate <= 0 {
+		return 0, true
+	}
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second)), false
+}
+
+func (b *TokenBucket) refill() {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.tokens+elapsed.Seconds()*b.rate, b.burst)
+	}
+	b.last = now
+}
+
+// Throttled halves the rate and drops the saved burst, it is called when the server answers 429
+func (b *TokenBucket) Throttled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxRate <= 0 {
+		return
+	}
+	b.refill()
+	b.rate = max(b.rate/2, b.minRate)
+	b.tokens = min(b.tokens, 0)
+	b.successes = 0
+}
+
+// Succeeded raises the rate back after RecoverAfter successful requests in a row
+func (b *TokenBucket) Succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxRate <= 0 || b.rate >= b.maxRate {
+		return
+	}
+	b.successes++
+	if b.successes < b.recoverAfter {
+		return
+	}
+	b.refill()
+	b.rate = min(b.rate+b.maxRate/10, b.maxRate)
+	b.successes = 0
+}
+
+// Rate returns the current number of requests per second
+func (b *TokenBucket) Rate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate
+}
+
+// Buckets shares one TokenBucket between all clients of the same Jira instance
+type Buckets struct {
+	mu      sync.Mutex
+	buckets map[string]*TokenBucket
+	opts    []BucketOption
+}
+
+func NewBuckets(opts ...BucketOption) *Buckets {
+	return &Buckets{buckets: make(map[string]*TokenBucket), opts: opts}
+}
+
+// Get returns the bucket of the base URL, creating it with cfg on first use. All clients of
+// the base URL share that bucket and cfg of the later calls is ignored, so sources of one
+// Jira instance must have the same config.
+func (bs *Buckets) Get(baseURL string, cfg BucketConfig) *TokenBucket {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	b, ok := bs.buckets[baseURL]
+	if !ok {
+		b = NewTokenBucket(cfg, bs.opts...)
+		bs.buckets[baseURL] = b
+	}
+	return b
+}
@@ -0,0 +1,287 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock moves only when Advance is called
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock and fires the timers which are due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// waitTimers waits until n goroutines are blocked on the clock
+func (c *fakeClock) waitTimers(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		count := len(c.timers)
+		c.mu.Unlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Expected %d waiting requests", n)
+}
+
+// tryWait reports whether Wait returns without moving the clock
+func tryWait(b *TokenBucket) bool {
+	_, ok := b.take()
+	return ok
+}
+
+func TestTokenBucket_Burst(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(BucketConfig{Rate: 10, Burst: 3}, WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		if !tryWait(b) {
+			t.Fatalf("Expected request %d of the burst to pass", i+1)
+		}
+	}
+	if tryWait(b) {
+		t.Fatal("Expected request over the burst to wait")
+	}
+
+	// A token is refilled every 100ms
+	clock.Advance(99 * time.Millisecond)
+	if tryWait(b) {
+		t.Error("Expected request to wait until the token is refilled")
+	}
+	clock.Advance(time.Millisecond)
+	if !tryWait(b) {
+		t.Error("Expected request to pass after the token is refilled")
+	}
+
+	// Idle time does not save more than the burst
+	clock.Advance(time.Minute)
+	for i := 0; i < 3; i++ {
+		if !tryWait(b) {
+			t.Fatalf("Expected request %d of the burst to pass", i+1)
+		}
+	}
+	if tryWait(b) {
+		t.Error("Expected the bucket to hold at most the burst")
+	}
+}
+
+func TestTokenBucket_WaitBlocks(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(BucketConfig{Rate: 2, Burst: 1}, WithClock(clock))
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- b.Wait(context.Background())
+	}()
+
+	clock.waitTimers(t, 1)
+	select {
+	case <-done:
+		t.Fatal("Expected Wait to block until the token is refilled")
+	default:
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if err := <-done; err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestTokenBucket_SharedByWorkers(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(BucketConfig{Rate: 10, Burst: 1}, WithClock(clock))
+	if !tryWait(b) {
+		t.Fatal("Expected the first request to pass")
+	}
+
+	const workers = 5
+	var passed sync.WaitGroup
+	passed.Add(workers)
+	var mu sync.Mutex
+	count := 0
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer passed.Done()
+			if err := b.Wait(context.Background()); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			mu.Lock()
+			count++
+			mu.Unlock()
+		}()
+	}
+
+	// Each tick of 100ms lets exactly one worker through
+	for i := 1; i <= workers; i++ {
+		clock.waitTimers(t, workers-i+1)
+		clock.Advance(100 * time.Millisecond)
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			mu.Lock()
+			c := count
+			mu.Unlock()
+			if c == i {
+				break
+			}
+			if c > i || time.Now().After(deadline) {
+				t.Fatalf("Expected %d requests after %d ticks, got %d", i, i, c)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	passed.Wait()
+}
+
+func TestTokenBucket_WaitCanceled(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(BucketConfig{Rate: 1, Burst: 1}, WithClock(clock))
+	b.Wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTokenBucket_Disabled(t *testing.T) {
+	b := NewTokenBucket(BucketConfig{}, WithClock(newFakeClock()))
+
+	for i := 0; i < 1000; i++ {
+		if !tryWait(b) {
+			t.Fatal("Expected disabled bucket never to block")
+		}
+	}
+	b.Throttled()
+	if b.Rate() != 0 {
+		t.Errorf("Expected rate 0, got %v", b.Rate())
+	}
+}
+
+func TestTokenBucket_Adapts(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(BucketConfig{Rate: 8, Burst: 4, MinRate: 1, RecoverAfter: 3}, WithClock(clock))
+
+	// 429 halves the rate down to MinRate and drops the burst
+	b.Throttled()
+	if b.Rate() != 4 {
+		t.Errorf("Expected rate 4, got %v", b.Rate())
+	}
+	if tryWait(b) {
+		t.Error("Expected the burst to be dropped after 429")
+	}
+	for i := 0; i < 5; i++ {
+		b.Throttled()
+	}
+	if b.Rate() != 1 {
+		t.Errorf("Expected rate to stop at MinRate 1, got %v", b.Rate())
+	}
+
+	// The rate goes up by a tenth of Rate after every RecoverAfter successes
+	b.Succeeded()
+	b.Succeeded()
+	if b.Rate() != 1 {
+		t.Errorf("Expected rate 1 before RecoverAfter successes, got %v", b.Rate())
+	}
+	b.Succeeded()
+	if b.Rate() != 1.8 {
+		t.Errorf("Expected rate 1.8, got %v", b.Rate())
+	}
+
+	// 429 in between restarts the count
+	b.Succeeded()
+	b.Succeeded()
+	b.Throttled()
+	b.Succeeded()
+	b.Succeeded()
+	if b.Rate() != 1 {
+		t.Errorf("Expected rate 1 after 429, got %v", b.Rate())
+	}
+
+	for i := 0; i < 100; i++ {
+		b.Succeeded()
+	}
+	if b.Rate() != 8 {
+		t.Errorf("Expected rate to stop at Rate 8, got %v", b.Rate())
+	}
+}
+
+func TestTokenBucket_DefaultMinRate(t *testing.T) {
+	b := NewTokenBucket(BucketConfig{Rate: 20}, WithClock(newFakeClock()))
+
+	for i := 0; i < 10; i++ {
+		b.Throttled()
+	}
+	if b.Rate() != 2 {
+		t.Errorf("Expected default MinRate 2, got %v", b.Rate())
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	buckets := NewBuckets(WithClock(newFakeClock()))
+	cfg := BucketConfig{Rate: 10, Burst: 1}
+
+	first := buckets.Get("https://jira.example.com", cfg)
+	if buckets.Get("https://jira.example.com", BucketConfig{Rate: 1}) != first {
+		t.Error("Expected clients of the same base URL to share the bucket")
+	}
+	if buckets.Get("https://issues.apache.org/jira", cfg) == first {
+		t.Error("Expected a separate bucket for another base URL")
+	}
+	if first.Rate() != 10 {
+		t.Errorf("Expected the bucket to keep its first config, got rate %v", first.Rate())
+	}
+}
@@ -64,6 +64,21 @@ func (rl *RateLimiter) Pause() {
 	rl.notifyPause = make(chan struct{})
 }
 
+// PauseFor pauses for the duration requested by the server. The backoff delay is not changed.
+// A shorter pause does not cut an earlier one.
+func (rl *RateLimiter) PauseFor(d time.Duration) {
+	rl.m.Lock()
+	defer rl.m.Unlock()
+
+	rl.paused = true
+	if until := time.Now().Add(d); until.After(rl.pauseUntil) {
+		rl.pauseUntil = until
+	}
+
+	close(rl.notifyPause)
+	rl.notifyPause = make(chan struct{})
+}
+
 func (rl *RateLimiter) Reset() {
 	rl.m.Lock()
 	defer rl.m.Unlock()
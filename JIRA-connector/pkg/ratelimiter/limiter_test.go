@@ -227,3 +227,38 @@ func TestConcurrentAccess(t *testing.T) {
 		t.Error("Rate limiter in inconsistent state after concurrent access")
 	}
 }
+
+func TestPauseFor(t *testing.T) {
+	rl := NewRateLimiter(100*time.Millisecond, 1*time.Second)
+	notify := rl.NotifyPause()
+
+	rl.PauseFor(500 * time.Millisecond)
+
+	paused, remaining := rl.ShouldPause()
+	if !paused {
+		t.Error("Expected paused true after PauseFor")
+	}
+	if remaining <= 400*time.Millisecond || remaining > 500*time.Millisecond {
+		t.Errorf("Expected remaining about 500ms, got %v", remaining)
+	}
+	if rl.CurrentDelay != rl.BaseDelay {
+		t.Errorf("Expected CurrentDelay %v to stay unchanged, got %v", rl.BaseDelay, rl.CurrentDelay)
+	}
+	select {
+	case <-notify:
+	default:
+		t.Error("Expected waiting requests to be notified")
+	}
+}
+
+func TestPauseFor_DoesNotShortenPause(t *testing.T) {
+	rl := NewRateLimiter(100*time.Millisecond, 1*time.Second)
+
+	rl.PauseFor(time.Second)
+	rl.PauseFor(10 * time.Millisecond)
+
+	_, remaining := rl.ShouldPause()
+	if remaining <= 900*time.Millisecond {
+		t.Errorf("Expected the longer pause to be kept, got %v", remaining)
+	}
+}
@@ -36,7 +36,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated project keys to compare",
+                        "description": "Comma-separated project keys to compare, source:KEY for a project of a named Jira source",
                         "name": "project",
                         "in": "query",
                         "required": true
@@ -80,7 +80,7 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project key identifier",
+                        "description": "Project key identifier, source:KEY for a project of a named Jira source",
                         "name": "project",
                         "in": "query",
                         "required": true
@@ -125,7 +125,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project key identifier",
+                        "description": "Project key identifier, source:KEY for a project of a named Jira source",
                         "name": "project",
                         "in": "query",
                         "required": true
@@ -176,7 +176,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project key identifier",
+                        "description": "Project key identifier, source:KEY for a project of a named Jira source",
                         "name": "project",
                         "in": "query",
                         "required": true
@@ -189,6 +189,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.IssueTaskTwo"
                         }
                     },
+                    "202": {
+                        "description": "Project sync is still in progress, retry when the job has finished",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncJob"
+                        }
+                    },
                     "400": {
                         "description": "Invalid task number or missing project key",
                         "schema": {
@@ -220,7 +226,7 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project key identifier",
+                        "description": "Project key identifier, source:KEY for a project of a named Jira source",
                         "name": "project",
                         "in": "query",
                         "required": true
@@ -253,6 +259,144 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/api/v1/sync": {
+            "post": {
+                "description": "Enqueues a sync of the project in the connector and returns without waiting for it. If the project is already being synced, the active job is returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Start a project sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key identifier, source:KEY for a project of a named Jira source",
+                        "name": "project",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Sync job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing project key",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sync/{id}": {
+            "get": {
+                "description": "Returns the state and progress of the sync job",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a project sync job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sync job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jira source of the job, the default source if not set",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sync job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sync/{id}/cancel": {
+            "post": {
+                "description": "Stops the sync job. Issues saved before stay in the database and the next sync resumes from them.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Cancel a project sync job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sync job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jira source of the job, the default source if not set",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sync job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -305,6 +449,44 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "dto.SyncJob": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issuesDone": {
+                    "type": "integer"
+                },
+                "issuesTotal": {
+                    "type": "integer"
+                },
+                "pagesDone": {
+                    "type": "integer"
+                },
+                "pagesTotal": {
+                    "type": "integer"
+                },
+                "projectKey": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
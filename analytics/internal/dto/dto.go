@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"strings"
+	"time"
+)
+
 // IssueTaskOne represents task one data
 type IssueTaskOne struct {
 	Count int    `json:"count"`
@@ -23,3 +28,31 @@ type ComparisonTaskTwo struct {
 	Key  string         `json:"key"`
 	Data []IssueTaskTwo `json:"data"`
 }
+
+// SyncJob represents a project sync running in the connector
+type SyncJob struct {
+	ID          int64      `json:"id"`
+	ProjectKey  string     `json:"projectKey"`
+	State       string     `json:"state"`
+	PagesDone   int64      `json:"pagesDone"`
+	PagesTotal  int64      `json:"pagesTotal"`
+	IssuesDone  int64      `json:"issuesDone"`
+	IssuesTotal int64      `json:"issuesTotal"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+}
+
+// DefaultSource is the Jira source of the connector used for project keys without a source
+const DefaultSource = "default"
+
+// ParseProjectRef splits a project reference "source:KEY" into the connector source and
+// the project key, a bare key refers to the default source
+func ParseProjectRef(ref string) (source string, key string) {
+	source, key, ok := strings.Cut(ref, ":")
+	if !ok {
+		return DefaultSource, ref
+	}
+	return source, key
+}
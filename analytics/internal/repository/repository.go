@@ -53,6 +53,7 @@ WITH issues_with_time AS (
         ) AS eff_timespent
     FROM issue
     WHERE projectid = $1
+      AND deletedat IS NULL
       AND status IN ('Closed', 'Resolved')
 ),
 time_categories AS (
@@ -170,7 +171,7 @@ func (r *repo) MakeTaskTwo(ctx context.Context, key string) (*[]dto.IssueTaskTwo
 		WHEN priority = 'Trivial' THEN 5
 		END AS priority_order
 		FROM issue 
-		   WHERE projectid = $1
+		   WHERE projectid = $1 AND deletedat IS NULL
 		)
 		SELECT 
 		  priority_category,
@@ -394,9 +395,12 @@ func (r *repo) CompareTaskTwo(ctx context.Context, keys *[]string) (*[]dto.Compa
 	return &comparisons, nil
 }
 
-func (r *repo) checkExistenceOfProject(key string) (int, error) {
+// checkExistenceOfProject returns the id of the project referenced as "source:KEY" or "KEY"
+func (r *repo) checkExistenceOfProject(ref string) (int, error) {
 	var id int
-	err := r.db.QueryRow(context.Background(), `SELECT id FROM projects WHERE key = $1`, key).Scan(&id)
+	source, key := dto.ParseProjectRef(ref)
+	err := r.db.QueryRow(context.Background(), `SELECT id FROM projects WHERE source = $1 AND key = $2`,
+		source, key).Scan(&id)
 	return id, err
 }
 
@@ -6,6 +6,9 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sssidkn/analytics/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GetGraph godoc
@@ -13,7 +16,7 @@ import (
 // @Description Retrieves graph data for the specified task number and project key
 // @Produce json
 // @Param taskNumber path int true "Task number to retrieve graph for"
-// @Param project query string true "Project key identifier"
+// @Param project query string true "Project key identifier, source:KEY for a project of a named Jira source"
 // @Success 200 {object} dto.IssueTaskOne "Данные для задачи типа 1"
 // @Success 200 {object} dto.IssueTaskTwo "Данные для задачи типа 2"
 // @Failure 400 {string} string "Invalid task number or missing project key"
@@ -48,9 +51,10 @@ func (s *Server) getGraph(c *gin.Context) {
 // @Description Creates and returns analytical graph data for the specified task
 // @Produce json
 // @Param taskNumber path int true "Task number to generate graph for"
-// @Param project query string true "Project key identifier"
+// @Param project query string true "Project key identifier, source:KEY for a project of a named Jira source"
 // @Success 200 {object} dto.IssueTaskOne "Данные для задачи типа 1"
 // @Success 200 {object} dto.IssueTaskTwo "Данные для задачи типа 2"
+// @Success 202 {object} dto.SyncJob "Project sync is still in progress, retry when the job has finished"
 // @Failure 400 {string} string "Invalid task number or missing project key"
 // @Failure 404 {string} string "Task or project not found"
 // @Failure 500 {string} string "Internal server error
@@ -70,6 +74,11 @@ func (s *Server) makeGraph(c *gin.Context) {
 	ctx := c.Request.Context()
 	issues, err := s.service.MakeTask(ctx, task, key)
 	if err != nil {
+		var inProgress *service.SyncInProgressError
+		if errors.As(err, &inProgress) {
+			c.JSON(http.StatusAccepted, inProgress.Job)
+			return
+		}
 		if errors.Is(err, errNotExist) {
 			c.String(http.StatusNotFound, err.Error())
 			return
@@ -84,7 +93,7 @@ func (s *Server) makeGraph(c *gin.Context) {
 // @Summary Delete all graph data for a project
 // @Description Removes all analytical graph data associated with the specified project
 // @Produce json
-// @Param project query string true "Project key identifier"
+// @Param project query string true "Project key identifier, source:KEY for a project of a named Jira source"
 // @Success 200 {boolean} bool "True if deletion was successful"
 // @Failure 400 {string} string "Missing project key"
 // @Failure 500 {string} string "Internal server error"
@@ -108,7 +117,7 @@ func (s *Server) deleteGraph(c *gin.Context) {
 // @Summary Check if project has been analyzed
 // @Description Verifies whether analytical data exists for the specified project
 // @Produce json
-// @Param project query string true "Project key identifier"
+// @Param project query string true "Project key identifier, source:KEY for a project of a named Jira source"
 // @Success 200 {boolean} bool "True if project has been analyzed"
 // @Failure 400 {string} string "Missing project key"
 // @Failure 404 {string} string "Project not found"
@@ -138,7 +147,7 @@ func (s *Server) isAnalyzed(c *gin.Context) {
 // @Description Retrieves comparison data for the specified task across projects
 // @Produce json
 // @Param taskNumber path int true "Task number to compare"
-// @Param project query string true "Comma-separated project keys to compare"
+// @Param project query string true "Comma-separated project keys to compare, source:KEY for a project of a named Jira source"
 // @Success 200 {object} dto.ComparisonTaskOne "Данные для задачи типа 1"
 // @Success 200 {object} dto.ComparisonTaskTwo "Данные для задачи типа 2"
 // @Failure 400 {string} string "Invalid task number or missing project keys"
@@ -169,4 +178,91 @@ func (s *Server) compare(c *gin.Context) {
 	c.JSON(http.StatusOK, comparisons)
 }
 
+// StartSync godoc
+// @Summary Start a project sync
+// @Description Enqueues a sync of the project in the connector and returns without waiting for it. If the project is already being synced, the active job is returned.
+// @Produce json
+// @Param project query string true "Project key identifier, source:KEY for a project of a named Jira source"
+// @Success 202 {object} dto.SyncJob "Sync job"
+// @Failure 400 {string} string "Missing project key"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/v1/sync [post]
+func (s *Server) startSync(c *gin.Context) {
+	key := c.Query("project")
+	if key == "" {
+		c.String(http.StatusBadRequest, "no key")
+		return
+	}
+
+	job, err := s.service.StartSync(c.Request.Context(), key)
+	if err != nil {
+		c.String(syncJobErrorStatus(err), err.Error())
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetSyncJob godoc
+// @Summary Get a project sync job
+// @Description Returns the state and progress of the sync job
+// @Produce json
+// @Param id path int true "Sync job id"
+// @Param source query string false "Jira source of the job, the default source if not set"
+// @Success 200 {object} dto.SyncJob "Sync job"
+// @Failure 400 {string} string "Invalid job id"
+// @Failure 404 {string} string "Job not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/v1/sync/{id} [get]
+func (s *Server) getSyncJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Params.ByName("id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := s.service.GetSyncJob(c.Request.Context(), c.Query("source"), id)
+	if err != nil {
+		c.String(syncJobErrorStatus(err), err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelSyncJob godoc
+// @Summary Cancel a project sync job
+// @Description Stops the sync job. Issues saved before stay in the database and the next sync resumes from them.
+// @Produce json
+// @Param id path int true "Sync job id"
+// @Param source query string false "Jira source of the job, the default source if not set"
+// @Success 200 {object} dto.SyncJob "Sync job"
+// @Failure 400 {string} string "Invalid job id"
+// @Failure 404 {string} string "Job not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/v1/sync/{id}/cancel [post]
+func (s *Server) cancelSyncJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Params.ByName("id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := s.service.CancelSyncJob(c.Request.Context(), c.Query("source"), id)
+	if err != nil {
+		c.String(syncJobErrorStatus(err), err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+func syncJobErrorStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 var errNotExist = errors.New("does not exist")
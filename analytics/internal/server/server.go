@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sssidkn/analytics/internal/dto"
 	"github.com/sssidkn/analytics/pkg/logger"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -20,6 +21,9 @@ type Service interface {
 	DeleteTasks(ctx context.Context, key string) (bool, error)
 	IsAnalyzed(ctx context.Context, key string) (bool, error)
 	Compare(ctx context.Context, task int, keys string) (interface{}, error)
+	StartSync(ctx context.Context, key string) (*dto.SyncJob, error)
+	GetSyncJob(ctx context.Context, source string, id int64) (*dto.SyncJob, error)
+	CancelSyncJob(ctx context.Context, source string, id int64) (*dto.SyncJob, error)
 }
 type Server struct {
 	engine     *gin.Engine
@@ -80,6 +84,9 @@ func (s *Server) registerRouters() {
 		api.DELETE("/graph/delete", s.deleteGraph)
 		api.GET("/isAnalyzed", s.isAnalyzed)
 		api.GET("/compare/:taskNumber", s.compare)
+		api.POST("/sync", s.startSync)
+		api.GET("/sync/:id", s.getSyncJob)
+		api.POST("/sync/:id/cancel", s.cancelSyncJob)
 		//TODO group/ services
 	}
 }
@@ -43,14 +43,9 @@ func New(repo Repository, log logger.Logger, client connectorApi.JiraConnectorCl
 type TaskHandler func(ctx context.Context, param string) (interface{}, error)
 
 func (s *service) MakeTask(ctx context.Context, task int, key string) (interface{}, error) {
-	response, err := s.client.UpdateProject(ctx, &connectorApi.UpdateProjectRequest{ProjectKey: key})
-	if err != nil {
-		s.log.Error(fmt.Errorf("failed to update project %s: %w", key, err))
+	if err := s.syncProject(ctx, key); err != nil {
 		return nil, err
 	}
-	if response.Success {
-		s.log.Info(fmt.Sprintf("updated project %s", key))
-	}
 	if hand, exists := s.handlers[task]; exists {
 		return hand(ctx, key)
 	}
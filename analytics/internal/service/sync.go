@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sssidkn/analytics/internal/dto"
+	"github.com/sssidkn/analytics/pkg/api/connectorApi"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// syncWait is how long MakeTask waits for the project sync, it must be shorter than the make request timeout
+	syncWait         = 20 * time.Second
+	syncPollInterval = time.Second
+)
+
+// SyncInProgressError is returned by MakeTask when the project sync takes longer than syncWait
+type SyncInProgressError struct {
+	Job *dto.SyncJob
+}
+
+func (e *SyncInProgressError) Error() string {
+	return fmt.Sprintf("sync job %d of project %s is in progress", e.Job.ID, e.Job.ProjectKey)
+}
+
+var ErrSyncFailed = errors.New("project sync failed")
+
+// StartSync enqueues a sync of the project in the connector and returns immediately
+func (s *service) StartSync(ctx context.Context, key string) (*dto.SyncJob, error) {
+	source, projectKey := dto.ParseProjectRef(key)
+	job, err := s.client.StartSync(ctx, &connectorApi.StartSyncRequest{Source: source, ProjectKey: projectKey})
+	if err != nil {
+		s.log.Error(fmt.Errorf("failed to start sync of project %s: %w", key, err))
+		return nil, err
+	}
+	s.log.Info(fmt.Sprintf("sync job %d of project %s is %s", job.GetId(), key, syncJobState(job)))
+	return syncJobToDTO(job), nil
+}
+
+func (s *service) GetSyncJob(ctx context.Context, source string, id int64) (*dto.SyncJob, error) {
+	job, err := s.client.GetSyncJob(ctx, &connectorApi.GetSyncJobRequest{Source: source, Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return syncJobToDTO(job), nil
+}
+
+func (s *service) CancelSyncJob(ctx context.Context, source string, id int64) (*dto.SyncJob, error) {
+	job, err := s.client.CancelSyncJob(ctx, &connectorApi.CancelSyncJobRequest{Source: source, Id: id})
+	if err != nil {
+		s.log.Error(fmt.Errorf("failed to cancel sync job %d: %w", id, err))
+		return nil, err
+	}
+	return syncJobToDTO(job), nil
+}
+
+// syncProject starts a sync of the project and waits up to syncWait for it to finish
+func (s *service) syncProject(ctx context.Context, key string) error {
+	source, projectKey := dto.ParseProjectRef(key)
+	job, err := s.client.StartSync(ctx, &connectorApi.StartSyncRequest{Source: source, ProjectKey: projectKey})
+	if err != nil {
+		s.log.Error(fmt.Errorf("failed to start sync of project %s: %w", key, err))
+		return err
+	}
+
+	deadline := time.NewTimer(syncWait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(syncPollInterval)
+	defer ticker.Stop()
+
+	for {
+		switch job.GetState() {
+		case connectorApi.SyncJobState_SYNC_JOB_STATE_SUCCEEDED:
+			s.log.Info(fmt.Sprintf("updated project %s", key))
+			return nil
+		case connectorApi.SyncJobState_SYNC_JOB_STATE_FAILED, connectorApi.SyncJobState_SYNC_JOB_STATE_CANCELED:
+			return fmt.Errorf("%w: %s %s", ErrSyncFailed, syncJobState(job), job.GetError())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return &SyncInProgressError{Job: syncJobToDTO(job)}
+		case <-ticker.C:
+		}
+
+		job, err = s.client.GetSyncJob(ctx, &connectorApi.GetSyncJobRequest{Source: source, Id: job.GetId()})
+		if err != nil {
+			s.log.Error(fmt.Errorf("failed to get sync job of project %s: %w", key, err))
+			return err
+		}
+	}
+}
+
+func syncJobState(job *connectorApi.SyncJob) string {
+	return strings.ToLower(strings.TrimPrefix(job.GetState().String(), "SYNC_JOB_STATE_"))
+}
+
+func syncJobToDTO(job *connectorApi.SyncJob) *dto.SyncJob {
+	return &dto.SyncJob{
+		ID:          job.GetId(),
+		ProjectKey:  job.GetProjectKey(),
+		State:       syncJobState(job),
+		PagesDone:   job.GetPagesDone(),
+		PagesTotal:  job.GetPagesTotal(),
+		IssuesDone:  job.GetIssuesDone(),
+		IssuesTotal: job.GetIssuesTotal(),
+		Error:       job.GetError(),
+		CreatedAt:   timeOrNil(job.GetCreatedAt()),
+		StartedAt:   timeOrNil(job.GetStartedAt()),
+		FinishedAt:  timeOrNil(job.GetFinishedAt()),
+	}
+}
+
+func timeOrNil(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
-// 	protoc        v5.29.3
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
 // source: connector.proto
 
 package connectorApi
@@ -10,6 +10,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,9 +24,232 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SyncJobState int32
+
+const (
+	SyncJobState_SYNC_JOB_STATE_UNSPECIFIED SyncJobState = 0
+	SyncJobState_SYNC_JOB_STATE_QUEUED      SyncJobState = 1
+	SyncJobState_SYNC_JOB_STATE_RUNNING     SyncJobState = 2
+	SyncJobState_SYNC_JOB_STATE_SUCCEEDED   SyncJobState = 3
+	SyncJobState_SYNC_JOB_STATE_FAILED      SyncJobState = 4
+	SyncJobState_SYNC_JOB_STATE_CANCELED    SyncJobState = 5
+)
+
+// Enum value maps for SyncJobState.
+var (
+	SyncJobState_name = map[int32]string{
+		0: "SYNC_JOB_STATE_UNSPECIFIED",
+		1: "SYNC_JOB_STATE_QUEUED",
+		2: "SYNC_JOB_STATE_RUNNING",
+		3: "SYNC_JOB_STATE_SUCCEEDED",
+		4: "SYNC_JOB_STATE_FAILED",
+		5: "SYNC_JOB_STATE_CANCELED",
+	}
+	SyncJobState_value = map[string]int32{
+		"SYNC_JOB_STATE_UNSPECIFIED": 0,
+		"SYNC_JOB_STATE_QUEUED":      1,
+		"SYNC_JOB_STATE_RUNNING":     2,
+		"SYNC_JOB_STATE_SUCCEEDED":   3,
+		"SYNC_JOB_STATE_FAILED":      4,
+		"SYNC_JOB_STATE_CANCELED":    5,
+	}
+)
+
+func (x SyncJobState) Enum() *SyncJobState {
+	p := new(SyncJobState)
+	*p = x
+	return p
+}
+
+func (x SyncJobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SyncJobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_connector_proto_enumTypes[0].Descriptor()
+}
+
+func (SyncJobState) Type() protoreflect.EnumType {
+	return &file_connector_proto_enumTypes[0]
+}
+
+func (x SyncJobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SyncJobState.Descriptor instead.
+func (SyncJobState) EnumDescriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{0}
+}
+
+type ScheduleOutcome int32
+
+const (
+	ScheduleOutcome_SCHEDULE_OUTCOME_UNSPECIFIED ScheduleOutcome = 0
+	ScheduleOutcome_SCHEDULE_OUTCOME_SUCCEEDED   ScheduleOutcome = 1
+	ScheduleOutcome_SCHEDULE_OUTCOME_FAILED      ScheduleOutcome = 2
+	ScheduleOutcome_SCHEDULE_OUTCOME_CANCELED    ScheduleOutcome = 3
+	// the project sync was already running
+	ScheduleOutcome_SCHEDULE_OUTCOME_SKIPPED ScheduleOutcome = 4
+)
+
+// Enum value maps for ScheduleOutcome.
+var (
+	ScheduleOutcome_name = map[int32]string{
+		0: "SCHEDULE_OUTCOME_UNSPECIFIED",
+		1: "SCHEDULE_OUTCOME_SUCCEEDED",
+		2: "SCHEDULE_OUTCOME_FAILED",
+		3: "SCHEDULE_OUTCOME_CANCELED",
+		4: "SCHEDULE_OUTCOME_SKIPPED",
+	}
+	ScheduleOutcome_value = map[string]int32{
+		"SCHEDULE_OUTCOME_UNSPECIFIED": 0,
+		"SCHEDULE_OUTCOME_SUCCEEDED":   1,
+		"SCHEDULE_OUTCOME_FAILED":      2,
+		"SCHEDULE_OUTCOME_CANCELED":    3,
+		"SCHEDULE_OUTCOME_SKIPPED":     4,
+	}
+)
+
+func (x ScheduleOutcome) Enum() *ScheduleOutcome {
+	p := new(ScheduleOutcome)
+	*p = x
+	return p
+}
+
+func (x ScheduleOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_connector_proto_enumTypes[1].Descriptor()
+}
+
+func (ScheduleOutcome) Type() protoreflect.EnumType {
+	return &file_connector_proto_enumTypes[1]
+}
+
+func (x ScheduleOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleOutcome.Descriptor instead.
+func (ScheduleOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{1}
+}
+
+type BreakerState int32
+
+const (
+	BreakerState_BREAKER_STATE_UNSPECIFIED BreakerState = 0
+	BreakerState_BREAKER_STATE_CLOSED      BreakerState = 1
+	// requests to Jira fail fast until retry_at
+	BreakerState_BREAKER_STATE_OPEN BreakerState = 2
+	// a probe request is let through
+	BreakerState_BREAKER_STATE_HALF_OPEN BreakerState = 3
+)
+
+// Enum value maps for BreakerState.
+var (
+	BreakerState_name = map[int32]string{
+		0: "BREAKER_STATE_UNSPECIFIED",
+		1: "BREAKER_STATE_CLOSED",
+		2: "BREAKER_STATE_OPEN",
+		3: "BREAKER_STATE_HALF_OPEN",
+	}
+	BreakerState_value = map[string]int32{
+		"BREAKER_STATE_UNSPECIFIED": 0,
+		"BREAKER_STATE_CLOSED":      1,
+		"BREAKER_STATE_OPEN":        2,
+		"BREAKER_STATE_HALF_OPEN":   3,
+	}
+)
+
+func (x BreakerState) Enum() *BreakerState {
+	p := new(BreakerState)
+	*p = x
+	return p
+}
+
+func (x BreakerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BreakerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_connector_proto_enumTypes[2].Descriptor()
+}
+
+func (BreakerState) Type() protoreflect.EnumType {
+	return &file_connector_proto_enumTypes[2]
+}
+
+func (x BreakerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BreakerState.Descriptor instead.
+func (BreakerState) EnumDescriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{2}
+}
+
+type ImportFormat int32
+
+const (
+	// detected by the first character of the export
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED ImportFormat = 0
+	// issue navigator export, /search results with changelog
+	ImportFormat_IMPORT_FORMAT_JSON ImportFormat = 1
+	// entities.xml of an XML backup
+	ImportFormat_IMPORT_FORMAT_XML ImportFormat = 2
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_JSON",
+		2: "IMPORT_FORMAT_XML",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED": 0,
+		"IMPORT_FORMAT_JSON":        1,
+		"IMPORT_FORMAT_XML":         2,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_connector_proto_enumTypes[3].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_connector_proto_enumTypes[3]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{3}
+}
+
 type UpdateProjectRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectKey    string                 `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProjectKey string                 `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	// id of the Jira source in the connector config, the default source if not set.
+	// Other requests select the source the same way.
+	Source        string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +291,13 @@ func (x *UpdateProjectRequest) GetProjectKey() string {
 	return ""
 }
 
+func (x *UpdateProjectRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type UpdateProjectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Project       *JiraProject           `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
@@ -123,6 +355,7 @@ type GetProjectsRequest struct {
 	Page          int64                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
 	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -178,6 +411,13 @@ func (x *GetProjectsRequest) GetSearch() string {
 	return ""
 }
 
+func (x *GetProjectsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type GetProjectsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Projects      []*JiraProject         `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
@@ -358,119 +598,2217 @@ func (x *JiraProject) GetId() string {
 	return ""
 }
 
-var File_connector_proto protoreflect.FileDescriptor
+type StartSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectKey    string                 `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var file_connector_proto_rawDesc = string([]byte{
-	0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x03, 0x61, 0x70, 0x69, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x37, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
-	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x22, 0x5d, 0x0a,
-	0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x69,
-	0x72, 0x61, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x56, 0x0a, 0x12,
-	0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x22, 0x6f, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x4a, 0x69, 0x72, 0x61, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52,
-	0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x09, 0x70, 0x61, 0x67,
-	0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x61, 0x67,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x73, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x67, 0x65, 0x22, 0x55, 0x0a, 0x0b, 0x4a, 0x69,
-	0x72, 0x61, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x32, 0xe9, 0x01, 0x0a, 0x0d, 0x4a, 0x69, 0x72, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x12, 0x72, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74,
-	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x1c, 0x12, 0x1a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x42, 0x16, 0x5a,
-	0x14, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x41, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+func (x *StartSyncRequest) Reset() {
+	*x = StartSyncRequest{}
+	mi := &file_connector_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_connector_proto_rawDescOnce sync.Once
-	file_connector_proto_rawDescData []byte
-)
+func (x *StartSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_connector_proto_rawDescGZIP() []byte {
-	file_connector_proto_rawDescOnce.Do(func() {
-		file_connector_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_connector_proto_rawDesc), len(file_connector_proto_rawDesc)))
-	})
-	return file_connector_proto_rawDescData
+func (*StartSyncRequest) ProtoMessage() {}
+
+func (x *StartSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_connector_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_connector_proto_goTypes = []any{
-	(*UpdateProjectRequest)(nil),  // 0: api.UpdateProjectRequest
-	(*UpdateProjectResponse)(nil), // 1: api.UpdateProjectResponse
-	(*GetProjectsRequest)(nil),    // 2: api.GetProjectsRequest
-	(*GetProjectsResponse)(nil),   // 3: api.GetProjectsResponse
-	(*PageInfo)(nil),              // 4: api.PageInfo
-	(*JiraProject)(nil),           // 5: api.JiraProject
+// Deprecated: Use StartSyncRequest.ProtoReflect.Descriptor instead.
+func (*StartSyncRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{6}
 }
-var file_connector_proto_depIdxs = []int32{
-	5, // 0: api.UpdateProjectResponse.project:type_name -> api.JiraProject
-	5, // 1: api.GetProjectsResponse.projects:type_name -> api.JiraProject
-	4, // 2: api.GetProjectsResponse.page_info:type_name -> api.PageInfo
-	0, // 3: api.JiraConnector.UpdateProject:input_type -> api.UpdateProjectRequest
-	2, // 4: api.JiraConnector.GetProjects:input_type -> api.GetProjectsRequest
-	1, // 5: api.JiraConnector.UpdateProject:output_type -> api.UpdateProjectResponse
-	3, // 6: api.JiraConnector.GetProjects:output_type -> api.GetProjectsResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+
+func (x *StartSyncRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
 }
 
-func init() { file_connector_proto_init() }
-func file_connector_proto_init() {
-	if File_connector_proto != nil {
-		return
+func (x *StartSyncRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type GetSyncJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncJobRequest) Reset() {
+	*x = GetSyncJobRequest{}
+	mi := &file_connector_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncJobRequest) ProtoMessage() {}
+
+func (x *GetSyncJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncJobRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncJobRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetSyncJobRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetSyncJobRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type CancelSyncJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSyncJobRequest) Reset() {
+	*x = CancelSyncJobRequest{}
+	mi := &file_connector_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSyncJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSyncJobRequest) ProtoMessage() {}
+
+func (x *CancelSyncJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSyncJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelSyncJobRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelSyncJobRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CancelSyncJobRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type SyncJob struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectKey string                 `protobuf:"bytes,2,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	State      SyncJobState           `protobuf:"varint,3,opt,name=state,proto3,enum=api.SyncJobState" json:"state,omitempty"`
+	PagesDone  int64                  `protobuf:"varint,4,opt,name=pages_done,json=pagesDone,proto3" json:"pages_done,omitempty"`
+	// 0 while the total is unknown
+	PagesTotal    int64                  `protobuf:"varint,5,opt,name=pages_total,json=pagesTotal,proto3" json:"pages_total,omitempty"`
+	IssuesDone    int64                  `protobuf:"varint,6,opt,name=issues_done,json=issuesDone,proto3" json:"issues_done,omitempty"`
+	IssuesTotal   int64                  `protobuf:"varint,7,opt,name=issues_total,json=issuesTotal,proto3" json:"issues_total,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncJob) Reset() {
+	*x = SyncJob{}
+	mi := &file_connector_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncJob) ProtoMessage() {}
+
+func (x *SyncJob) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncJob.ProtoReflect.Descriptor instead.
+func (*SyncJob) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SyncJob) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SyncJob) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *SyncJob) GetState() SyncJobState {
+	if x != nil {
+		return x.State
+	}
+	return SyncJobState_SYNC_JOB_STATE_UNSPECIFIED
+}
+
+func (x *SyncJob) GetPagesDone() int64 {
+	if x != nil {
+		return x.PagesDone
+	}
+	return 0
+}
+
+func (x *SyncJob) GetPagesTotal() int64 {
+	if x != nil {
+		return x.PagesTotal
+	}
+	return 0
+}
+
+func (x *SyncJob) GetIssuesDone() int64 {
+	if x != nil {
+		return x.IssuesDone
+	}
+	return 0
+}
+
+func (x *SyncJob) GetIssuesTotal() int64 {
+	if x != nil {
+		return x.IssuesTotal
+	}
+	return 0
+}
+
+func (x *SyncJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SyncJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SyncJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *SyncJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *SyncJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type WatchSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectKey    string                 `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSyncRequest) Reset() {
+	*x = WatchSyncRequest{}
+	mi := &file_connector_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSyncRequest) ProtoMessage() {}
+
+func (x *WatchSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSyncRequest.ProtoReflect.Descriptor instead.
+func (*WatchSyncRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchSyncRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *WatchSyncRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type SyncEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	JobId      int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	ProjectKey string                 `protobuf:"bytes,2,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	Time       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*SyncEvent_TotalDiscovered
+	//	*SyncEvent_PageFetched
+	//	*SyncEvent_RateLimitPaused
+	//	*SyncEvent_BatchCommitted
+	//	*SyncEvent_Completed
+	//	*SyncEvent_Failed
+	Event         isSyncEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncEvent) Reset() {
+	*x = SyncEvent{}
+	mi := &file_connector_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncEvent) ProtoMessage() {}
+
+func (x *SyncEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncEvent.ProtoReflect.Descriptor instead.
+func (*SyncEvent) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SyncEvent) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *SyncEvent) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *SyncEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetEvent() isSyncEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetTotalDiscovered() *TotalDiscovered {
+	if x != nil {
+		if x, ok := x.Event.(*SyncEvent_TotalDiscovered); ok {
+			return x.TotalDiscovered
+		}
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetPageFetched() *PageFetched {
+	if x != nil {
+		if x, ok := x.Event.(*SyncEvent_PageFetched); ok {
+			return x.PageFetched
+		}
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetRateLimitPaused() *RateLimitPaused {
+	if x != nil {
+		if x, ok := x.Event.(*SyncEvent_RateLimitPaused); ok {
+			return x.RateLimitPaused
+		}
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetBatchCommitted() *BatchCommitted {
+	if x != nil {
+		if x, ok := x.Event.(*SyncEvent_BatchCommitted); ok {
+			return x.BatchCommitted
+		}
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetCompleted() *SyncCompleted {
+	if x != nil {
+		if x, ok := x.Event.(*SyncEvent_Completed); ok {
+			return x.Completed
+		}
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetFailed() *SyncFailed {
+	if x != nil {
+		if x, ok := x.Event.(*SyncEvent_Failed); ok {
+			return x.Failed
+		}
+	}
+	return nil
+}
+
+type isSyncEvent_Event interface {
+	isSyncEvent_Event()
+}
+
+type SyncEvent_TotalDiscovered struct {
+	TotalDiscovered *TotalDiscovered `protobuf:"bytes,4,opt,name=total_discovered,json=totalDiscovered,proto3,oneof"`
+}
+
+type SyncEvent_PageFetched struct {
+	PageFetched *PageFetched `protobuf:"bytes,5,opt,name=page_fetched,json=pageFetched,proto3,oneof"`
+}
+
+type SyncEvent_RateLimitPaused struct {
+	RateLimitPaused *RateLimitPaused `protobuf:"bytes,6,opt,name=rate_limit_paused,json=rateLimitPaused,proto3,oneof"`
+}
+
+type SyncEvent_BatchCommitted struct {
+	BatchCommitted *BatchCommitted `protobuf:"bytes,7,opt,name=batch_committed,json=batchCommitted,proto3,oneof"`
+}
+
+type SyncEvent_Completed struct {
+	Completed *SyncCompleted `protobuf:"bytes,8,opt,name=completed,proto3,oneof"`
+}
+
+type SyncEvent_Failed struct {
+	Failed *SyncFailed `protobuf:"bytes,9,opt,name=failed,proto3,oneof"`
+}
+
+func (*SyncEvent_TotalDiscovered) isSyncEvent_Event() {}
+
+func (*SyncEvent_PageFetched) isSyncEvent_Event() {}
+
+func (*SyncEvent_RateLimitPaused) isSyncEvent_Event() {}
+
+func (*SyncEvent_BatchCommitted) isSyncEvent_Event() {}
+
+func (*SyncEvent_Completed) isSyncEvent_Event() {}
+
+func (*SyncEvent_Failed) isSyncEvent_Event() {}
+
+// TotalDiscovered is sent once Jira reports the size of the query
+type TotalDiscovered struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issues        int64                  `protobuf:"varint,1,opt,name=issues,proto3" json:"issues,omitempty"`
+	Pages         int64                  `protobuf:"varint,2,opt,name=pages,proto3" json:"pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TotalDiscovered) Reset() {
+	*x = TotalDiscovered{}
+	mi := &file_connector_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotalDiscovered) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotalDiscovered) ProtoMessage() {}
+
+func (x *TotalDiscovered) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotalDiscovered.ProtoReflect.Descriptor instead.
+func (*TotalDiscovered) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TotalDiscovered) GetIssues() int64 {
+	if x != nil {
+		return x.Issues
+	}
+	return 0
+}
+
+func (x *TotalDiscovered) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+type PageFetched struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int64                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Issues        int64                  `protobuf:"varint,2,opt,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageFetched) Reset() {
+	*x = PageFetched{}
+	mi := &file_connector_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageFetched) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageFetched) ProtoMessage() {}
+
+func (x *PageFetched) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageFetched.ProtoReflect.Descriptor instead.
+func (*PageFetched) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PageFetched) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageFetched) GetIssues() int64 {
+	if x != nil {
+		return x.Issues
+	}
+	return 0
+}
+
+type RateLimitPaused struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetryAfter    *durationpb.Duration   `protobuf:"bytes,1,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitPaused) Reset() {
+	*x = RateLimitPaused{}
+	mi := &file_connector_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitPaused) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitPaused) ProtoMessage() {}
+
+func (x *RateLimitPaused) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitPaused.ProtoReflect.Descriptor instead.
+func (*RateLimitPaused) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RateLimitPaused) GetRetryAfter() *durationpb.Duration {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return nil
+}
+
+type BatchCommitted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int64                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Issues        int64                  `protobuf:"varint,2,opt,name=issues,proto3" json:"issues,omitempty"`
+	IssuesDone    int64                  `protobuf:"varint,3,opt,name=issues_done,json=issuesDone,proto3" json:"issues_done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCommitted) Reset() {
+	*x = BatchCommitted{}
+	mi := &file_connector_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCommitted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCommitted) ProtoMessage() {}
+
+func (x *BatchCommitted) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCommitted.ProtoReflect.Descriptor instead.
+func (*BatchCommitted) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchCommitted) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *BatchCommitted) GetIssues() int64 {
+	if x != nil {
+		return x.Issues
+	}
+	return 0
+}
+
+func (x *BatchCommitted) GetIssuesDone() int64 {
+	if x != nil {
+		return x.IssuesDone
+	}
+	return 0
+}
+
+type SyncCompleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issues        int64                  `protobuf:"varint,1,opt,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncCompleted) Reset() {
+	*x = SyncCompleted{}
+	mi := &file_connector_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncCompleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncCompleted) ProtoMessage() {}
+
+func (x *SyncCompleted) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncCompleted.ProtoReflect.Descriptor instead.
+func (*SyncCompleted) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SyncCompleted) GetIssues() int64 {
+	if x != nil {
+		return x.Issues
+	}
+	return 0
+}
+
+type SyncFailed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Canceled      bool                   `protobuf:"varint,2,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncFailed) Reset() {
+	*x = SyncFailed{}
+	mi := &file_connector_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncFailed) ProtoMessage() {}
+
+func (x *SyncFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncFailed.ProtoReflect.Descriptor instead.
+func (*SyncFailed) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SyncFailed) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SyncFailed) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+type GetSyncScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncScheduleRequest) Reset() {
+	*x = GetSyncScheduleRequest{}
+	mi := &file_connector_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncScheduleRequest) ProtoMessage() {}
+
+func (x *GetSyncScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSyncScheduleRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type SyncSchedule struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// standard 5-field cron expression, e.g. "0 */6 * * *"
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// every run starts after a random delay up to jitter
+	Jitter *durationpb.Duration `protobuf:"bytes,3,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	// how many projects are synced at once
+	MaxConcurrent int64 `protobuf:"varint,4,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	// not set if the schedule is disabled
+	NextRunAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncSchedule) Reset() {
+	*x = SyncSchedule{}
+	mi := &file_connector_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncSchedule) ProtoMessage() {}
+
+func (x *SyncSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncSchedule.ProtoReflect.Descriptor instead.
+func (*SyncSchedule) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SyncSchedule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SyncSchedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *SyncSchedule) GetJitter() *durationpb.Duration {
+	if x != nil {
+		return x.Jitter
+	}
+	return nil
+}
+
+func (x *SyncSchedule) GetMaxConcurrent() int64 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *SyncSchedule) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *SyncSchedule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UpdateSyncScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Cron          string                 `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	Jitter        *durationpb.Duration   `protobuf:"bytes,3,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	MaxConcurrent int64                  `protobuf:"varint,4,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSyncScheduleRequest) Reset() {
+	*x = UpdateSyncScheduleRequest{}
+	mi := &file_connector_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSyncScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSyncScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateSyncScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSyncScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSyncScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateSyncScheduleRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpdateSyncScheduleRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *UpdateSyncScheduleRequest) GetJitter() *durationpb.Duration {
+	if x != nil {
+		return x.Jitter
+	}
+	return nil
+}
+
+func (x *UpdateSyncScheduleRequest) GetMaxConcurrent() int64 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *UpdateSyncScheduleRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type ListScheduleRunsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 20 if not set
+	Limit         int64  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Source        string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduleRunsRequest) Reset() {
+	*x = ListScheduleRunsRequest{}
+	mi := &file_connector_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduleRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduleRunsRequest) ProtoMessage() {}
+
+func (x *ListScheduleRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduleRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduleRunsRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListScheduleRunsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListScheduleRunsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type ListScheduleRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*ScheduleRun         `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduleRunsResponse) Reset() {
+	*x = ListScheduleRunsResponse{}
+	mi := &file_connector_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduleRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduleRunsResponse) ProtoMessage() {}
+
+func (x *ListScheduleRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduleRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduleRunsResponse) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListScheduleRunsResponse) GetRuns() []*ScheduleRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type ScheduleRun struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// not set while the run is in progress
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Succeeded     int64                  `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int64                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped       int64                  `protobuf:"varint,6,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Projects      []*ScheduledSync       `protobuf:"bytes,8,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleRun) Reset() {
+	*x = ScheduleRun{}
+	mi := &file_connector_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRun) ProtoMessage() {}
+
+func (x *ScheduleRun) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRun.ProtoReflect.Descriptor instead.
+func (*ScheduleRun) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScheduleRun) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduleRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ScheduleRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *ScheduleRun) GetSucceeded() int64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *ScheduleRun) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ScheduleRun) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ScheduleRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetProjects() []*ScheduledSync {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type ScheduledSync struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProjectKey string                 `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	// the active job if the project was skipped, 0 if the job was not created
+	JobId         int64           `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Outcome       ScheduleOutcome `protobuf:"varint,3,opt,name=outcome,proto3,enum=api.ScheduleOutcome" json:"outcome,omitempty"`
+	Error         string          `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledSync) Reset() {
+	*x = ScheduledSync{}
+	mi := &file_connector_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledSync) ProtoMessage() {}
+
+func (x *ScheduledSync) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledSync.ProtoReflect.Descriptor instead.
+func (*ScheduledSync) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ScheduledSync) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *ScheduledSync) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *ScheduledSync) GetOutcome() ScheduleOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ScheduleOutcome_SCHEDULE_OUTCOME_UNSPECIFIED
+}
+
+func (x *ScheduledSync) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_connector_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HealthRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type HealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// false while the circuit is open
+	Serving       bool        `protobuf:"varint,1,opt,name=serving,proto3" json:"serving,omitempty"`
+	Jira          *JiraHealth `protobuf:"bytes,2,opt,name=jira,proto3" json:"jira,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_connector_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *HealthResponse) GetServing() bool {
+	if x != nil {
+		return x.Serving
+	}
+	return false
+}
+
+func (x *HealthResponse) GetJira() *JiraHealth {
+	if x != nil {
+		return x.Jira
+	}
+	return nil
+}
+
+type JiraHealth struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	BaseUrl string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	State   BreakerState           `protobuf:"varint,2,opt,name=state,proto3,enum=api.BreakerState" json:"state,omitempty"`
+	// counted over the recent requests window
+	Requests      int64                  `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	Failures      int64                  `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`
+	OpenedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	RetryAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=retry_at,json=retryAt,proto3" json:"retry_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JiraHealth) Reset() {
+	*x = JiraHealth{}
+	mi := &file_connector_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JiraHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraHealth) ProtoMessage() {}
+
+func (x *JiraHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraHealth.ProtoReflect.Descriptor instead.
+func (*JiraHealth) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *JiraHealth) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *JiraHealth) GetState() BreakerState {
+	if x != nil {
+		return x.State
+	}
+	return BreakerState_BREAKER_STATE_UNSPECIFIED
+}
+
+func (x *JiraHealth) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *JiraHealth) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *JiraHealth) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *JiraHealth) GetRetryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetryAt
+	}
+	return nil
+}
+
+type ImportProjectsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Data   []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Format ImportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=api.ImportFormat" json:"format,omitempty"`
+	// IANA timezone of the XML backup dates, UTC if not set
+	Timezone      string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Source        string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProjectsRequest) Reset() {
+	*x = ImportProjectsRequest{}
+	mi := &file_connector_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProjectsRequest) ProtoMessage() {}
+
+func (x *ImportProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportProjectsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportProjectsRequest) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportProjectsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ImportProjectsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type ImportedProject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *JiraProject           `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Issues        int64                  `protobuf:"varint,2,opt,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedProject) Reset() {
+	*x = ImportedProject{}
+	mi := &file_connector_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedProject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedProject) ProtoMessage() {}
+
+func (x *ImportedProject) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedProject.ProtoReflect.Descriptor instead.
+func (*ImportedProject) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportedProject) GetProject() *JiraProject {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *ImportedProject) GetIssues() int64 {
+	if x != nil {
+		return x.Issues
+	}
+	return 0
+}
+
+type ImportProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*ImportedProject     `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProjectsResponse) Reset() {
+	*x = ImportProjectsResponse{}
+	mi := &file_connector_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProjectsResponse) ProtoMessage() {}
+
+func (x *ImportProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportProjectsResponse) GetProjects() []*ImportedProject {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type ListSprintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectKey    string                 `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSprintsRequest) Reset() {
+	*x = ListSprintsRequest{}
+	mi := &file_connector_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSprintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSprintsRequest) ProtoMessage() {}
+
+func (x *ListSprintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSprintsRequest.ProtoReflect.Descriptor instead.
+func (*ListSprintsRequest) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListSprintsRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *ListSprintsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type Sprint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the board the sprint was created on
+	BoardId int64  `protobuf:"varint,2,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	Name    string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// future, active or closed
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Goal  string `protobuf:"bytes,5,opt,name=goal,proto3" json:"goal,omitempty"`
+	// not set for future sprints
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// not set until the sprint is closed
+	CompleteDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=complete_date,json=completeDate,proto3" json:"complete_date,omitempty"`
+	// the issues of the project in the sprint
+	IssueKeys     []string `protobuf:"bytes,9,rep,name=issue_keys,json=issueKeys,proto3" json:"issue_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sprint) Reset() {
+	*x = Sprint{}
+	mi := &file_connector_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sprint) ProtoMessage() {}
+
+func (x *Sprint) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sprint.ProtoReflect.Descriptor instead.
+func (*Sprint) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Sprint) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Sprint) GetBoardId() int64 {
+	if x != nil {
+		return x.BoardId
+	}
+	return 0
+}
+
+func (x *Sprint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Sprint) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Sprint) GetGoal() string {
+	if x != nil {
+		return x.Goal
+	}
+	return ""
+}
+
+func (x *Sprint) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Sprint) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Sprint) GetCompleteDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompleteDate
+	}
+	return nil
+}
+
+func (x *Sprint) GetIssueKeys() []string {
+	if x != nil {
+		return x.IssueKeys
+	}
+	return nil
+}
+
+type ListSprintsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprints       []*Sprint              `protobuf:"bytes,1,rep,name=sprints,proto3" json:"sprints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSprintsResponse) Reset() {
+	*x = ListSprintsResponse{}
+	mi := &file_connector_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSprintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSprintsResponse) ProtoMessage() {}
+
+func (x *ListSprintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_connector_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSprintsResponse.ProtoReflect.Descriptor instead.
+func (*ListSprintsResponse) Descriptor() ([]byte, []int) {
+	return file_connector_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSprintsResponse) GetSprints() []*Sprint {
+	if x != nil {
+		return x.Sprints
+	}
+	return nil
+}
+
+var File_connector_proto protoreflect.FileDescriptor
+
+const file_connector_proto_rawDesc = "" +
+	"\n" +
+	"\x0fconnector.proto\x12\x03api\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"O\n" +
+	"\x14UpdateProjectRequest\x12\x1f\n" +
+	"\vproject_key\x18\x01 \x01(\tR\n" +
+	"projectKey\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\"]\n" +
+	"\x15UpdateProjectResponse\x12*\n" +
+	"\aproject\x18\x01 \x01(\v2\x10.api.JiraProjectR\aproject\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"n\n" +
+	"\x12GetProjectsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\"o\n" +
+	"\x13GetProjectsResponse\x12,\n" +
+	"\bprojects\x18\x01 \x03(\v2\x10.api.JiraProjectR\bprojects\x12*\n" +
+	"\tpage_info\x18\x02 \x01(\v2\r.api.PageInfoR\bpageInfo\"s\n" +
+	"\bPageInfo\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x01 \x01(\x03R\tpageCount\x12%\n" +
+	"\x0eprojects_count\x18\x02 \x01(\x03R\rprojectsCount\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x03R\vcurrentPage\"U\n" +
+	"\vJiraProject\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\"K\n" +
+	"\x10StartSyncRequest\x12\x1f\n" +
+	"\vproject_key\x18\x01 \x01(\tR\n" +
+	"projectKey\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\";\n" +
+	"\x11GetSyncJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\">\n" +
+	"\x14CancelSyncJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\"\xeb\x03\n" +
+	"\aSyncJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vproject_key\x18\x02 \x01(\tR\n" +
+	"projectKey\x12'\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x11.api.SyncJobStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"pages_done\x18\x04 \x01(\x03R\tpagesDone\x12\x1f\n" +
+	"\vpages_total\x18\x05 \x01(\x03R\n" +
+	"pagesTotal\x12\x1f\n" +
+	"\vissues_done\x18\x06 \x01(\x03R\n" +
+	"issuesDone\x12!\n" +
+	"\fissues_total\x18\a \x01(\x03R\vissuesTotal\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"K\n" +
+	"\x10WatchSyncRequest\x12\x1f\n" +
+	"\vproject_key\x18\x01 \x01(\tR\n" +
+	"projectKey\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\"\xd9\x03\n" +
+	"\tSyncEvent\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12\x1f\n" +
+	"\vproject_key\x18\x02 \x01(\tR\n" +
+	"projectKey\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12A\n" +
+	"\x10total_discovered\x18\x04 \x01(\v2\x14.api.TotalDiscoveredH\x00R\x0ftotalDiscovered\x125\n" +
+	"\fpage_fetched\x18\x05 \x01(\v2\x10.api.PageFetchedH\x00R\vpageFetched\x12B\n" +
+	"\x11rate_limit_paused\x18\x06 \x01(\v2\x14.api.RateLimitPausedH\x00R\x0frateLimitPaused\x12>\n" +
+	"\x0fbatch_committed\x18\a \x01(\v2\x13.api.BatchCommittedH\x00R\x0ebatchCommitted\x122\n" +
+	"\tcompleted\x18\b \x01(\v2\x12.api.SyncCompletedH\x00R\tcompleted\x12)\n" +
+	"\x06failed\x18\t \x01(\v2\x0f.api.SyncFailedH\x00R\x06failedB\a\n" +
+	"\x05event\"?\n" +
+	"\x0fTotalDiscovered\x12\x16\n" +
+	"\x06issues\x18\x01 \x01(\x03R\x06issues\x12\x14\n" +
+	"\x05pages\x18\x02 \x01(\x03R\x05pages\"9\n" +
+	"\vPageFetched\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x03R\x04page\x12\x16\n" +
+	"\x06issues\x18\x02 \x01(\x03R\x06issues\"M\n" +
+	"\x0fRateLimitPaused\x12:\n" +
+	"\vretry_after\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"retryAfter\"]\n" +
+	"\x0eBatchCommitted\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x03R\x04page\x12\x16\n" +
+	"\x06issues\x18\x02 \x01(\x03R\x06issues\x12\x1f\n" +
+	"\vissues_done\x18\x03 \x01(\x03R\n" +
+	"issuesDone\"'\n" +
+	"\rSyncCompleted\x12\x16\n" +
+	"\x06issues\x18\x01 \x01(\x03R\x06issues\">\n" +
+	"\n" +
+	"SyncFailed\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x1a\n" +
+	"\bcanceled\x18\x02 \x01(\bR\bcanceled\"0\n" +
+	"\x16GetSyncScheduleRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\"\x8d\x02\n" +
+	"\fSyncSchedule\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x121\n" +
+	"\x06jitter\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06jitter\x12%\n" +
+	"\x0emax_concurrent\x18\x04 \x01(\x03R\rmaxConcurrent\x12:\n" +
+	"\vnext_run_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbb\x01\n" +
+	"\x19UpdateSyncScheduleRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x121\n" +
+	"\x06jitter\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06jitter\x12%\n" +
+	"\x0emax_concurrent\x18\x04 \x01(\x03R\rmaxConcurrent\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\"G\n" +
+	"\x17ListScheduleRunsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\"@\n" +
+	"\x18ListScheduleRunsResponse\x12$\n" +
+	"\x04runs\x18\x01 \x03(\v2\x10.api.ScheduleRunR\x04runs\"\xab\x02\n" +
+	"\vScheduleRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x1c\n" +
+	"\tsucceeded\x18\x04 \x01(\x03R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x03R\x06failed\x12\x18\n" +
+	"\askipped\x18\x06 \x01(\x03R\askipped\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12.\n" +
+	"\bprojects\x18\b \x03(\v2\x12.api.ScheduledSyncR\bprojects\"\x8d\x01\n" +
+	"\rScheduledSync\x12\x1f\n" +
+	"\vproject_key\x18\x01 \x01(\tR\n" +
+	"projectKey\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\x03R\x05jobId\x12.\n" +
+	"\aoutcome\x18\x03 \x01(\x0e2\x14.api.ScheduleOutcomeR\aoutcome\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"'\n" +
+	"\rHealthRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\"O\n" +
+	"\x0eHealthResponse\x12\x18\n" +
+	"\aserving\x18\x01 \x01(\bR\aserving\x12#\n" +
+	"\x04jira\x18\x02 \x01(\v2\x0f.api.JiraHealthR\x04jira\"\xf8\x01\n" +
+	"\n" +
+	"JiraHealth\x12\x19\n" +
+	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12'\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x11.api.BreakerStateR\x05state\x12\x1a\n" +
+	"\brequests\x18\x03 \x01(\x03R\brequests\x12\x1a\n" +
+	"\bfailures\x18\x04 \x01(\x03R\bfailures\x127\n" +
+	"\topened_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\x125\n" +
+	"\bretry_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aretryAt\"\x8a\x01\n" +
+	"\x15ImportProjectsRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12)\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x11.api.ImportFormatR\x06format\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\"U\n" +
+	"\x0fImportedProject\x12*\n" +
+	"\aproject\x18\x01 \x01(\v2\x10.api.JiraProjectR\aproject\x12\x16\n" +
+	"\x06issues\x18\x02 \x01(\x03R\x06issues\"J\n" +
+	"\x16ImportProjectsResponse\x120\n" +
+	"\bprojects\x18\x01 \x03(\v2\x14.api.ImportedProjectR\bprojects\"M\n" +
+	"\x12ListSprintsRequest\x12\x1f\n" +
+	"\vproject_key\x18\x01 \x01(\tR\n" +
+	"projectKey\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\"\xc3\x02\n" +
+	"\x06Sprint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bboard_id\x18\x02 \x01(\x03R\aboardId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x12\n" +
+	"\x04goal\x18\x05 \x01(\tR\x04goal\x129\n" +
+	"\n" +
+	"start_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12?\n" +
+	"\rcomplete_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fcompleteDate\x12\x1d\n" +
+	"\n" +
+	"issue_keys\x18\t \x03(\tR\tissueKeys\"<\n" +
+	"\x13ListSprintsResponse\x12%\n" +
+	"\asprints\x18\x01 \x03(\v2\v.api.SprintR\asprints*\xbb\x01\n" +
+	"\fSyncJobState\x12\x1e\n" +
+	"\x1aSYNC_JOB_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15SYNC_JOB_STATE_QUEUED\x10\x01\x12\x1a\n" +
+	"\x16SYNC_JOB_STATE_RUNNING\x10\x02\x12\x1c\n" +
+	"\x18SYNC_JOB_STATE_SUCCEEDED\x10\x03\x12\x19\n" +
+	"\x15SYNC_JOB_STATE_FAILED\x10\x04\x12\x1b\n" +
+	"\x17SYNC_JOB_STATE_CANCELED\x10\x05*\xad\x01\n" +
+	"\x0fScheduleOutcome\x12 \n" +
+	"\x1cSCHEDULE_OUTCOME_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSCHEDULE_OUTCOME_SUCCEEDED\x10\x01\x12\x1b\n" +
+	"\x17SCHEDULE_OUTCOME_FAILED\x10\x02\x12\x1d\n" +
+	"\x19SCHEDULE_OUTCOME_CANCELED\x10\x03\x12\x1c\n" +
+	"\x18SCHEDULE_OUTCOME_SKIPPED\x10\x04*|\n" +
+	"\fBreakerState\x12\x1d\n" +
+	"\x19BREAKER_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14BREAKER_STATE_CLOSED\x10\x01\x12\x16\n" +
+	"\x12BREAKER_STATE_OPEN\x10\x02\x12\x1b\n" +
+	"\x17BREAKER_STATE_HALF_OPEN\x10\x03*\\\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12IMPORT_FORMAT_JSON\x10\x01\x12\x15\n" +
+	"\x11IMPORT_FORMAT_XML\x10\x022\xfc\t\n" +
+	"\rJiraConnector\x12r\n" +
+	"\rUpdateProject\x12\x19.api.UpdateProjectRequest\x1a\x1a.api.UpdateProjectResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/connector/updateProject\x12d\n" +
+	"\vGetProjects\x12\x17.api.GetProjectsRequest\x1a\x18.api.GetProjectsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/connector/projects\x12W\n" +
+	"\tStartSync\x12\x15.api.StartSyncRequest\x1a\f.api.SyncJob\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/connector/syncJobs\x12[\n" +
+	"\n" +
+	"GetSyncJob\x12\x16.api.GetSyncJobRequest\x1a\f.api.SyncJob\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/connector/syncJobs/{id}\x12k\n" +
+	"\rCancelSyncJob\x12\x19.api.CancelSyncJobRequest\x1a\f.api.SyncJob\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/api/v1/connector/syncJobs/{id}/cancel\x12\\\n" +
+	"\tWatchSync\x12\x15.api.WatchSyncRequest\x1a\x0e.api.SyncEvent\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/connector/watchSync0\x01\x12e\n" +
+	"\x0fGetSyncSchedule\x12\x1b.api.GetSyncScheduleRequest\x1a\x11.api.SyncSchedule\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/connector/schedule\x12n\n" +
+	"\x12UpdateSyncSchedule\x12\x1e.api.UpdateSyncScheduleRequest\x1a\x11.api.SyncSchedule\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/api/v1/connector/schedule\x12x\n" +
+	"\x10ListScheduleRuns\x12\x1c.api.ListScheduleRunsRequest\x1a\x1d.api.ListScheduleRunsResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/connector/schedule/runs\x12n\n" +
+	"\x0eImportProjects\x12\x1a.api.ImportProjectsRequest\x1a\x1b.api.ImportProjectsResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/connector/import\x12z\n" +
+	"\vListSprints\x12\x17.api.ListSprintsRequest\x1a\x18.api.ListSprintsResponse\"8\x82\xd3\xe4\x93\x022\x120/api/v1/connector/projects/{project_key}/sprints\x12S\n" +
+	"\x06Health\x12\x12.api.HealthRequest\x1a\x13.api.HealthResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/connector/healthB\x16Z\x14pkg/api/connectorApib\x06proto3"
+
+var (
+	file_connector_proto_rawDescOnce sync.Once
+	file_connector_proto_rawDescData []byte
+)
+
+func file_connector_proto_rawDescGZIP() []byte {
+	file_connector_proto_rawDescOnce.Do(func() {
+		file_connector_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_connector_proto_rawDesc), len(file_connector_proto_rawDesc)))
+	})
+	return file_connector_proto_rawDescData
+}
+
+var file_connector_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_connector_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_connector_proto_goTypes = []any{
+	(SyncJobState)(0),                 // 0: api.SyncJobState
+	(ScheduleOutcome)(0),              // 1: api.ScheduleOutcome
+	(BreakerState)(0),                 // 2: api.BreakerState
+	(ImportFormat)(0),                 // 3: api.ImportFormat
+	(*UpdateProjectRequest)(nil),      // 4: api.UpdateProjectRequest
+	(*UpdateProjectResponse)(nil),     // 5: api.UpdateProjectResponse
+	(*GetProjectsRequest)(nil),        // 6: api.GetProjectsRequest
+	(*GetProjectsResponse)(nil),       // 7: api.GetProjectsResponse
+	(*PageInfo)(nil),                  // 8: api.PageInfo
+	(*JiraProject)(nil),               // 9: api.JiraProject
+	(*StartSyncRequest)(nil),          // 10: api.StartSyncRequest
+	(*GetSyncJobRequest)(nil),         // 11: api.GetSyncJobRequest
+	(*CancelSyncJobRequest)(nil),      // 12: api.CancelSyncJobRequest
+	(*SyncJob)(nil),                   // 13: api.SyncJob
+	(*WatchSyncRequest)(nil),          // 14: api.WatchSyncRequest
+	(*SyncEvent)(nil),                 // 15: api.SyncEvent
+	(*TotalDiscovered)(nil),           // 16: api.TotalDiscovered
+	(*PageFetched)(nil),               // 17: api.PageFetched
+	(*RateLimitPaused)(nil),           // 18: api.RateLimitPaused
+	(*BatchCommitted)(nil),            // 19: api.BatchCommitted
+	(*SyncCompleted)(nil),             // 20: api.SyncCompleted
+	(*SyncFailed)(nil),                // 21: api.SyncFailed
+	(*GetSyncScheduleRequest)(nil),    // 22: api.GetSyncScheduleRequest
+	(*SyncSchedule)(nil),              // 23: api.SyncSchedule
+	(*UpdateSyncScheduleRequest)(nil), // 24: api.UpdateSyncScheduleRequest
+	(*ListScheduleRunsRequest)(nil),   // 25: api.ListScheduleRunsRequest
+	(*ListScheduleRunsResponse)(nil),  // 26: api.ListScheduleRunsResponse
+	(*ScheduleRun)(nil),               // 27: api.ScheduleRun
+	(*ScheduledSync)(nil),             // 28: api.ScheduledSync
+	(*HealthRequest)(nil),             // 29: api.HealthRequest
+	(*HealthResponse)(nil),            // 30: api.HealthResponse
+	(*JiraHealth)(nil),                // 31: api.JiraHealth
+	(*ImportProjectsRequest)(nil),     // 32: api.ImportProjectsRequest
+	(*ImportedProject)(nil),           // 33: api.ImportedProject
+	(*ImportProjectsResponse)(nil),    // 34: api.ImportProjectsResponse
+	(*ListSprintsRequest)(nil),        // 35: api.ListSprintsRequest
+	(*Sprint)(nil),                    // 36: api.Sprint
+	(*ListSprintsResponse)(nil),       // 37: api.ListSprintsResponse
+	(*timestamppb.Timestamp)(nil),     // 38: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),       // 39: google.protobuf.Duration
+}
+var file_connector_proto_depIdxs = []int32{
+	9,  // 0: api.UpdateProjectResponse.project:type_name -> api.JiraProject
+	9,  // 1: api.GetProjectsResponse.projects:type_name -> api.JiraProject
+	8,  // 2: api.GetProjectsResponse.page_info:type_name -> api.PageInfo
+	0,  // 3: api.SyncJob.state:type_name -> api.SyncJobState
+	38, // 4: api.SyncJob.created_at:type_name -> google.protobuf.Timestamp
+	38, // 5: api.SyncJob.started_at:type_name -> google.protobuf.Timestamp
+	38, // 6: api.SyncJob.finished_at:type_name -> google.protobuf.Timestamp
+	38, // 7: api.SyncJob.updated_at:type_name -> google.protobuf.Timestamp
+	38, // 8: api.SyncEvent.time:type_name -> google.protobuf.Timestamp
+	16, // 9: api.SyncEvent.total_discovered:type_name -> api.TotalDiscovered
+	17, // 10: api.SyncEvent.page_fetched:type_name -> api.PageFetched
+	18, // 11: api.SyncEvent.rate_limit_paused:type_name -> api.RateLimitPaused
+	19, // 12: api.SyncEvent.batch_committed:type_name -> api.BatchCommitted
+	20, // 13: api.SyncEvent.completed:type_name -> api.SyncCompleted
+	21, // 14: api.SyncEvent.failed:type_name -> api.SyncFailed
+	39, // 15: api.RateLimitPaused.retry_after:type_name -> google.protobuf.Duration
+	39, // 16: api.SyncSchedule.jitter:type_name -> google.protobuf.Duration
+	38, // 17: api.SyncSchedule.next_run_at:type_name -> google.protobuf.Timestamp
+	38, // 18: api.SyncSchedule.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 19: api.UpdateSyncScheduleRequest.jitter:type_name -> google.protobuf.Duration
+	27, // 20: api.ListScheduleRunsResponse.runs:type_name -> api.ScheduleRun
+	38, // 21: api.ScheduleRun.started_at:type_name -> google.protobuf.Timestamp
+	38, // 22: api.ScheduleRun.finished_at:type_name -> google.protobuf.Timestamp
+	28, // 23: api.ScheduleRun.projects:type_name -> api.ScheduledSync
+	1,  // 24: api.ScheduledSync.outcome:type_name -> api.ScheduleOutcome
+	31, // 25: api.HealthResponse.jira:type_name -> api.JiraHealth
+	2,  // 26: api.JiraHealth.state:type_name -> api.BreakerState
+	38, // 27: api.JiraHealth.opened_at:type_name -> google.protobuf.Timestamp
+	38, // 28: api.JiraHealth.retry_at:type_name -> google.protobuf.Timestamp
+	3,  // 29: api.ImportProjectsRequest.format:type_name -> api.ImportFormat
+	9,  // 30: api.ImportedProject.project:type_name -> api.JiraProject
+	33, // 31: api.ImportProjectsResponse.projects:type_name -> api.ImportedProject
+	38, // 32: api.Sprint.start_date:type_name -> google.protobuf.Timestamp
+	38, // 33: api.Sprint.end_date:type_name -> google.protobuf.Timestamp
+	38, // 34: api.Sprint.complete_date:type_name -> google.protobuf.Timestamp
+	36, // 35: api.ListSprintsResponse.sprints:type_name -> api.Sprint
+	4,  // 36: api.JiraConnector.UpdateProject:input_type -> api.UpdateProjectRequest
+	6,  // 37: api.JiraConnector.GetProjects:input_type -> api.GetProjectsRequest
+	10, // 38: api.JiraConnector.StartSync:input_type -> api.StartSyncRequest
+	11, // 39: api.JiraConnector.GetSyncJob:input_type -> api.GetSyncJobRequest
+	12, // 40: api.JiraConnector.CancelSyncJob:input_type -> api.CancelSyncJobRequest
+	14, // 41: api.JiraConnector.WatchSync:input_type -> api.WatchSyncRequest
+	22, // 42: api.JiraConnector.GetSyncSchedule:input_type -> api.GetSyncScheduleRequest
+	24, // 43: api.JiraConnector.UpdateSyncSchedule:input_type -> api.UpdateSyncScheduleRequest
+	25, // 44: api.JiraConnector.ListScheduleRuns:input_type -> api.ListScheduleRunsRequest
+	32, // 45: api.JiraConnector.ImportProjects:input_type -> api.ImportProjectsRequest
+	35, // 46: api.JiraConnector.ListSprints:input_type -> api.ListSprintsRequest
+	29, // 47: api.JiraConnector.Health:input_type -> api.HealthRequest
+	5,  // 48: api.JiraConnector.UpdateProject:output_type -> api.UpdateProjectResponse
+	7,  // 49: api.JiraConnector.GetProjects:output_type -> api.GetProjectsResponse
+	13, // 50: api.JiraConnector.StartSync:output_type -> api.SyncJob
+	13, // 51: api.JiraConnector.GetSyncJob:output_type -> api.SyncJob
+	13, // 52: api.JiraConnector.CancelSyncJob:output_type -> api.SyncJob
+	15, // 53: api.JiraConnector.WatchSync:output_type -> api.SyncEvent
+	23, // 54: api.JiraConnector.GetSyncSchedule:output_type -> api.SyncSchedule
+	23, // 55: api.JiraConnector.UpdateSyncSchedule:output_type -> api.SyncSchedule
+	26, // 56: api.JiraConnector.ListScheduleRuns:output_type -> api.ListScheduleRunsResponse
+	34, // 57: api.JiraConnector.ImportProjects:output_type -> api.ImportProjectsResponse
+	37, // 58: api.JiraConnector.ListSprints:output_type -> api.ListSprintsResponse
+	30, // 59: api.JiraConnector.Health:output_type -> api.HealthResponse
+	48, // [48:60] is the sub-list for method output_type
+	36, // [36:48] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_connector_proto_init() }
+func file_connector_proto_init() {
+	if File_connector_proto != nil {
+		return
+	}
+	file_connector_proto_msgTypes[11].OneofWrappers = []any{
+		(*SyncEvent_TotalDiscovered)(nil),
+		(*SyncEvent_PageFetched)(nil),
+		(*SyncEvent_RateLimitPaused)(nil),
+		(*SyncEvent_BatchCommitted)(nil),
+		(*SyncEvent_Completed)(nil),
+		(*SyncEvent_Failed)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_connector_proto_rawDesc), len(file_connector_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      4,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_connector_proto_goTypes,
 		DependencyIndexes: file_connector_proto_depIdxs,
+		EnumInfos:         file_connector_proto_enumTypes,
 		MessageInfos:      file_connector_proto_msgTypes,
 	}.Build()
 	File_connector_proto = out.File
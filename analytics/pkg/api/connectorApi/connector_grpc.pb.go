@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.29.3
+// - protoc             v3.21.12
 // source: connector.proto
 
 package connectorApi
@@ -19,8 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	JiraConnector_UpdateProject_FullMethodName = "/api.JiraConnector/UpdateProject"
-	JiraConnector_GetProjects_FullMethodName   = "/api.JiraConnector/GetProjects"
+	JiraConnector_UpdateProject_FullMethodName      = "/api.JiraConnector/UpdateProject"
+	JiraConnector_GetProjects_FullMethodName        = "/api.JiraConnector/GetProjects"
+	JiraConnector_StartSync_FullMethodName          = "/api.JiraConnector/StartSync"
+	JiraConnector_GetSyncJob_FullMethodName         = "/api.JiraConnector/GetSyncJob"
+	JiraConnector_CancelSyncJob_FullMethodName      = "/api.JiraConnector/CancelSyncJob"
+	JiraConnector_WatchSync_FullMethodName          = "/api.JiraConnector/WatchSync"
+	JiraConnector_GetSyncSchedule_FullMethodName    = "/api.JiraConnector/GetSyncSchedule"
+	JiraConnector_UpdateSyncSchedule_FullMethodName = "/api.JiraConnector/UpdateSyncSchedule"
+	JiraConnector_ListScheduleRuns_FullMethodName   = "/api.JiraConnector/ListScheduleRuns"
+	JiraConnector_ImportProjects_FullMethodName     = "/api.JiraConnector/ImportProjects"
+	JiraConnector_ListSprints_FullMethodName        = "/api.JiraConnector/ListSprints"
+	JiraConnector_Health_FullMethodName             = "/api.JiraConnector/Health"
 )
 
 // JiraConnectorClient is the client API for JiraConnector service.
@@ -29,6 +39,25 @@ const (
 type JiraConnectorClient interface {
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
 	GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*GetProjectsResponse, error)
+	// StartSync enqueues a project sync and returns immediately. If the project is already
+	// being synced, the active job is returned.
+	StartSync(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (*SyncJob, error)
+	GetSyncJob(ctx context.Context, in *GetSyncJobRequest, opts ...grpc.CallOption) (*SyncJob, error)
+	CancelSyncJob(ctx context.Context, in *CancelSyncJobRequest, opts ...grpc.CallOption) (*SyncJob, error)
+	// WatchSync starts a project sync, or joins the active one, and streams its progress
+	// until it completes or fails
+	WatchSync(ctx context.Context, in *WatchSyncRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncEvent], error)
+	GetSyncSchedule(ctx context.Context, in *GetSyncScheduleRequest, opts ...grpc.CallOption) (*SyncSchedule, error)
+	// UpdateSyncSchedule replaces the schedule of the periodic sync of tracked projects
+	UpdateSyncSchedule(ctx context.Context, in *UpdateSyncScheduleRequest, opts ...grpc.CallOption) (*SyncSchedule, error)
+	// ListScheduleRuns returns the latest scheduled runs, newest first
+	ListScheduleRuns(ctx context.Context, in *ListScheduleRunsRequest, opts ...grpc.CallOption) (*ListScheduleRunsResponse, error)
+	// ImportProjects saves the projects of an offline export of an air-gapped Jira instance
+	ImportProjects(ctx context.Context, in *ImportProjectsRequest, opts ...grpc.CallOption) (*ImportProjectsResponse, error)
+	// ListSprints returns the sprints of a synced project ordered by start date, future sprints last
+	ListSprints(ctx context.Context, in *ListSprintsRequest, opts ...grpc.CallOption) (*ListSprintsResponse, error)
+	// Health reports whether requests to Jira are let through by the circuit breaker
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 }
 
 type jiraConnectorClient struct {
@@ -59,12 +88,140 @@ func (c *jiraConnectorClient) GetProjects(ctx context.Context, in *GetProjectsRe
 	return out, nil
 }
 
+func (c *jiraConnectorClient) StartSync(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (*SyncJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncJob)
+	err := c.cc.Invoke(ctx, JiraConnector_StartSync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) GetSyncJob(ctx context.Context, in *GetSyncJobRequest, opts ...grpc.CallOption) (*SyncJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncJob)
+	err := c.cc.Invoke(ctx, JiraConnector_GetSyncJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) CancelSyncJob(ctx context.Context, in *CancelSyncJobRequest, opts ...grpc.CallOption) (*SyncJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncJob)
+	err := c.cc.Invoke(ctx, JiraConnector_CancelSyncJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) WatchSync(ctx context.Context, in *WatchSyncRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JiraConnector_ServiceDesc.Streams[0], JiraConnector_WatchSync_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSyncRequest, SyncEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JiraConnector_WatchSyncClient = grpc.ServerStreamingClient[SyncEvent]
+
+func (c *jiraConnectorClient) GetSyncSchedule(ctx context.Context, in *GetSyncScheduleRequest, opts ...grpc.CallOption) (*SyncSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncSchedule)
+	err := c.cc.Invoke(ctx, JiraConnector_GetSyncSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) UpdateSyncSchedule(ctx context.Context, in *UpdateSyncScheduleRequest, opts ...grpc.CallOption) (*SyncSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncSchedule)
+	err := c.cc.Invoke(ctx, JiraConnector_UpdateSyncSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) ListScheduleRuns(ctx context.Context, in *ListScheduleRunsRequest, opts ...grpc.CallOption) (*ListScheduleRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduleRunsResponse)
+	err := c.cc.Invoke(ctx, JiraConnector_ListScheduleRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) ImportProjects(ctx context.Context, in *ImportProjectsRequest, opts ...grpc.CallOption) (*ImportProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportProjectsResponse)
+	err := c.cc.Invoke(ctx, JiraConnector_ImportProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) ListSprints(ctx context.Context, in *ListSprintsRequest, opts ...grpc.CallOption) (*ListSprintsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSprintsResponse)
+	err := c.cc.Invoke(ctx, JiraConnector_ListSprints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jiraConnectorClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, JiraConnector_Health_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JiraConnectorServer is the server API for JiraConnector service.
 // All implementations must embed UnimplementedJiraConnectorServer
 // for forward compatibility.
 type JiraConnectorServer interface {
 	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
 	GetProjects(context.Context, *GetProjectsRequest) (*GetProjectsResponse, error)
+	// StartSync enqueues a project sync and returns immediately. If the project is already
+	// being synced, the active job is returned.
+	StartSync(context.Context, *StartSyncRequest) (*SyncJob, error)
+	GetSyncJob(context.Context, *GetSyncJobRequest) (*SyncJob, error)
+	CancelSyncJob(context.Context, *CancelSyncJobRequest) (*SyncJob, error)
+	// WatchSync starts a project sync, or joins the active one, and streams its progress
+	// until it completes or fails
+	WatchSync(*WatchSyncRequest, grpc.ServerStreamingServer[SyncEvent]) error
+	GetSyncSchedule(context.Context, *GetSyncScheduleRequest) (*SyncSchedule, error)
+	// UpdateSyncSchedule replaces the schedule of the periodic sync of tracked projects
+	UpdateSyncSchedule(context.Context, *UpdateSyncScheduleRequest) (*SyncSchedule, error)
+	// ListScheduleRuns returns the latest scheduled runs, newest first
+	ListScheduleRuns(context.Context, *ListScheduleRunsRequest) (*ListScheduleRunsResponse, error)
+	// ImportProjects saves the projects of an offline export of an air-gapped Jira instance
+	ImportProjects(context.Context, *ImportProjectsRequest) (*ImportProjectsResponse, error)
+	// ListSprints returns the sprints of a synced project ordered by start date, future sprints last
+	ListSprints(context.Context, *ListSprintsRequest) (*ListSprintsResponse, error)
+	// Health reports whether requests to Jira are let through by the circuit breaker
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 	mustEmbedUnimplementedJiraConnectorServer()
 }
 
@@ -81,6 +238,36 @@ func (UnimplementedJiraConnectorServer) UpdateProject(context.Context, *UpdatePr
 func (UnimplementedJiraConnectorServer) GetProjects(context.Context, *GetProjectsRequest) (*GetProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProjects not implemented")
 }
+func (UnimplementedJiraConnectorServer) StartSync(context.Context, *StartSyncRequest) (*SyncJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSync not implemented")
+}
+func (UnimplementedJiraConnectorServer) GetSyncJob(context.Context, *GetSyncJobRequest) (*SyncJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncJob not implemented")
+}
+func (UnimplementedJiraConnectorServer) CancelSyncJob(context.Context, *CancelSyncJobRequest) (*SyncJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSyncJob not implemented")
+}
+func (UnimplementedJiraConnectorServer) WatchSync(*WatchSyncRequest, grpc.ServerStreamingServer[SyncEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSync not implemented")
+}
+func (UnimplementedJiraConnectorServer) GetSyncSchedule(context.Context, *GetSyncScheduleRequest) (*SyncSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncSchedule not implemented")
+}
+func (UnimplementedJiraConnectorServer) UpdateSyncSchedule(context.Context, *UpdateSyncScheduleRequest) (*SyncSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSyncSchedule not implemented")
+}
+func (UnimplementedJiraConnectorServer) ListScheduleRuns(context.Context, *ListScheduleRunsRequest) (*ListScheduleRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduleRuns not implemented")
+}
+func (UnimplementedJiraConnectorServer) ImportProjects(context.Context, *ImportProjectsRequest) (*ImportProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProjects not implemented")
+}
+func (UnimplementedJiraConnectorServer) ListSprints(context.Context, *ListSprintsRequest) (*ListSprintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSprints not implemented")
+}
+func (UnimplementedJiraConnectorServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
 func (UnimplementedJiraConnectorServer) mustEmbedUnimplementedJiraConnectorServer() {}
 func (UnimplementedJiraConnectorServer) testEmbeddedByValue()                       {}
 
@@ -138,6 +325,179 @@ func _JiraConnector_GetProjects_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JiraConnector_StartSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).StartSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_StartSync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).StartSync(ctx, req.(*StartSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_GetSyncJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).GetSyncJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_GetSyncJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).GetSyncJob(ctx, req.(*GetSyncJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_CancelSyncJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSyncJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).CancelSyncJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_CancelSyncJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).CancelSyncJob(ctx, req.(*CancelSyncJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_WatchSync_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JiraConnectorServer).WatchSync(m, &grpc.GenericServerStream[WatchSyncRequest, SyncEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JiraConnector_WatchSyncServer = grpc.ServerStreamingServer[SyncEvent]
+
+func _JiraConnector_GetSyncSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).GetSyncSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_GetSyncSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).GetSyncSchedule(ctx, req.(*GetSyncScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_UpdateSyncSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSyncScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).UpdateSyncSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_UpdateSyncSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).UpdateSyncSchedule(ctx, req.(*UpdateSyncScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_ListScheduleRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduleRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).ListScheduleRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_ListScheduleRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).ListScheduleRuns(ctx, req.(*ListScheduleRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_ImportProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).ImportProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_ImportProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).ImportProjects(ctx, req.(*ImportProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_ListSprints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSprintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).ListSprints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_ListSprints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).ListSprints(ctx, req.(*ListSprintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JiraConnector_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JiraConnectorServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JiraConnector_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JiraConnectorServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // JiraConnector_ServiceDesc is the grpc.ServiceDesc for JiraConnector service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +513,49 @@ var JiraConnector_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProjects",
 			Handler:    _JiraConnector_GetProjects_Handler,
 		},
+		{
+			MethodName: "StartSync",
+			Handler:    _JiraConnector_StartSync_Handler,
+		},
+		{
+			MethodName: "GetSyncJob",
+			Handler:    _JiraConnector_GetSyncJob_Handler,
+		},
+		{
+			MethodName: "CancelSyncJob",
+			Handler:    _JiraConnector_CancelSyncJob_Handler,
+		},
+		{
+			MethodName: "GetSyncSchedule",
+			Handler:    _JiraConnector_GetSyncSchedule_Handler,
+		},
+		{
+			MethodName: "UpdateSyncSchedule",
+			Handler:    _JiraConnector_UpdateSyncSchedule_Handler,
+		},
+		{
+			MethodName: "ListScheduleRuns",
+			Handler:    _JiraConnector_ListScheduleRuns_Handler,
+		},
+		{
+			MethodName: "ImportProjects",
+			Handler:    _JiraConnector_ImportProjects_Handler,
+		},
+		{
+			MethodName: "ListSprints",
+			Handler:    _JiraConnector_ListSprints_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _JiraConnector_Health_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSync",
+			Handler:       _JiraConnector_WatchSync_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "connector.proto",
 }
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+DELETE FROM StatusChanges a
+    USING StatusChanges b
+WHERE a.ctid < b.ctid
+  AND a.issueId = b.issueId
+  AND a.changeTime IS NOT DISTINCT FROM b.changeTime
+  AND a.fromStatus IS NOT DISTINCT FROM b.fromStatus
+  AND a.toStatus IS NOT DISTINCT FROM b.toStatus;
+
+CREATE UNIQUE INDEX IF NOT EXISTS StatusChanges_unique
+    ON StatusChanges (issueId, changeTime, fromStatus, toStatus);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS StatusChanges_unique;
+-- +goose StatementEnd
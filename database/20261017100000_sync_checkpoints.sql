@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sync_checkpoints
+(
+    projectKey     TEXT PRIMARY KEY,
+    FOREIGN KEY (projectKey) REFERENCES Projects (key) ON DELETE CASCADE ON UPDATE CASCADE,
+    jql            TEXT NOT NULL,
+    startedAt      TIMESTAMP NOT NULL,
+    pagesCompleted INT  NOT NULL DEFAULT 0,
+    highWater      TIMESTAMP WITHOUT TIME ZONE,
+    updatedAt      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sync_checkpoints;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sync_jobs
+(
+    id          BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    projectKey  TEXT NOT NULL,
+    state       TEXT NOT NULL,
+    pagesDone   INT  NOT NULL DEFAULT 0,
+    pagesTotal  INT  NOT NULL DEFAULT 0,
+    issuesDone  INT  NOT NULL DEFAULT 0,
+    issuesTotal INT  NOT NULL DEFAULT 0,
+    error       TEXT NOT NULL DEFAULT '',
+    createdAt   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    startedAt   TIMESTAMP WITH TIME ZONE,
+    finishedAt  TIMESTAMP WITH TIME ZONE,
+    updatedAt   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- at most one active job per project
+CREATE UNIQUE INDEX IF NOT EXISTS sync_jobs_active
+    ON sync_jobs (projectKey) WHERE state IN ('queued', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sync_jobs;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- single row, the schedule from the config is used until it is saved
+CREATE TABLE IF NOT EXISTS sync_schedule
+(
+    id            INT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    enabled       BOOLEAN  NOT NULL,
+    cron          TEXT     NOT NULL,
+    jitterSeconds INT      NOT NULL,
+    maxConcurrent INT      NOT NULL,
+    updatedAt     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS schedule_runs
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    startedAt  TIMESTAMP WITH TIME ZONE NOT NULL,
+    finishedAt TIMESTAMP WITH TIME ZONE,
+    error      TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_syncs
+(
+    runId      BIGINT NOT NULL,
+    FOREIGN KEY (runId) REFERENCES schedule_runs (id) ON DELETE CASCADE,
+    projectKey TEXT   NOT NULL,
+    jobId      BIGINT,
+    outcome    TEXT   NOT NULL,
+    error      TEXT   NOT NULL DEFAULT '',
+    PRIMARY KEY (runId, projectKey)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scheduled_syncs;
+DROP TABLE IF EXISTS schedule_runs;
+DROP TABLE IF EXISTS sync_schedule;
+-- +goose StatementEnd
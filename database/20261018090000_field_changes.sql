@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every changelog item, StatusChanges keeps only the status ones
+CREATE TABLE IF NOT EXISTS FieldChanges
+(
+    issueId    INT  NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    authorId   INT  NOT NULL,
+    FOREIGN KEY (authorId) REFERENCES Author (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    changeTime TIMESTAMP WITHOUT TIME ZONE,
+    field      TEXT NOT NULL,
+    fromId     TEXT NOT NULL DEFAULT '',
+    fromString TEXT NOT NULL DEFAULT '',
+    toId       TEXT NOT NULL DEFAULT '',
+    toString   TEXT NOT NULL DEFAULT ''
+);
+
+-- the strings of description changes are too long for a btree index, so they are hashed
+CREATE UNIQUE INDEX IF NOT EXISTS FieldChanges_unique
+    ON FieldChanges (issueId, changeTime, field, fromId, toId, md5(fromString), md5(toString));
+
+CREATE INDEX IF NOT EXISTS FieldChanges_field ON FieldChanges (field, changeTime);
+CREATE INDEX IF NOT EXISTS FieldChanges_author ON FieldChanges (authorId);
+
+INSERT INTO FieldChanges (issueId, authorId, changeTime, field, fromString, toString)
+SELECT issueId, authorId, changeTime, 'status', COALESCE(fromStatus, ''), COALESCE(toStatus, '')
+FROM StatusChanges
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS FieldChanges;
+-- +goose StatementEnd
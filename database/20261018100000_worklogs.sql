@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS Worklog
+(
+    id               serial PRIMARY KEY,
+    jiraId           TEXT UNIQUE NOT NULL,
+    issueId          INT NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    authorId         INT NOT NULL,
+    FOREIGN KEY (authorId) REFERENCES Author (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    started          TIMESTAMP WITHOUT TIME ZONE,
+    timeSpentSeconds INT NOT NULL,
+    comment          TEXT
+);
+
+CREATE INDEX IF NOT EXISTS Worklog_issue ON Worklog (issueId);
+CREATE INDEX IF NOT EXISTS Worklog_author ON Worklog (authorId, started);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS Worklog;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS Comment
+(
+    id          serial PRIMARY KEY,
+    jiraId      TEXT UNIQUE NOT NULL,
+    issueId     INT NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    authorId    INT NOT NULL,
+    FOREIGN KEY (authorId) REFERENCES Author (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    createdTime TIMESTAMP WITHOUT TIME ZONE,
+    updatedTime TIMESTAMP WITHOUT TIME ZONE,
+    body        TEXT
+);
+
+CREATE INDEX IF NOT EXISTS Comment_issue ON Comment (issueId, createdTime);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS Comment;
+-- +goose StatementEnd
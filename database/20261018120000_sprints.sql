@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ids of boards and sprints are the Jira ones
+CREATE TABLE IF NOT EXISTS Board
+(
+    id   INT PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL
+);
+
+-- boards whose filter includes the project
+CREATE TABLE IF NOT EXISTS ProjectBoard
+(
+    projectId INT NOT NULL,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    boardId   INT NOT NULL,
+    FOREIGN KEY (boardId) REFERENCES Board (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (projectId, boardId)
+);
+
+CREATE TABLE IF NOT EXISTS Sprint
+(
+    id           INT PRIMARY KEY,
+    -- the board the sprint was created on, it may belong to a project that is not synced
+    boardId      INT  NOT NULL,
+    name         TEXT NOT NULL,
+    state        TEXT NOT NULL,
+    goal         TEXT NOT NULL DEFAULT '',
+    startDate    TIMESTAMP WITHOUT TIME ZONE,
+    endDate      TIMESTAMP WITHOUT TIME ZONE,
+    completeDate TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS Sprint_board ON Sprint (boardId);
+
+CREATE TABLE IF NOT EXISTS SprintIssue
+(
+    sprintId INT NOT NULL,
+    FOREIGN KEY (sprintId) REFERENCES Sprint (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    issueId  INT NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (sprintId, issueId)
+);
+
+CREATE INDEX IF NOT EXISTS SprintIssue_issue ON SprintIssue (issueId);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS SprintIssue;
+DROP TABLE IF EXISTS Sprint;
+DROP TABLE IF EXISTS ProjectBoard;
+DROP TABLE IF EXISTS Board;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- keys rather than ids: the parent, the epic and linked issues may be saved later or belong to another project
+ALTER TABLE Issue ADD COLUMN IF NOT EXISTS parentKey TEXT;
+ALTER TABLE Issue ADD COLUMN IF NOT EXISTS epicKey TEXT;
+
+CREATE INDEX IF NOT EXISTS Issue_parent ON Issue (parentKey);
+CREATE INDEX IF NOT EXISTS Issue_epic ON Issue (epicKey);
+
+-- a link is listed on both issues, sourceKey is the outward side of the link type, e.g. the blocking issue
+CREATE TABLE IF NOT EXISTS IssueLink
+(
+    jiraId    TEXT PRIMARY KEY,
+    type      TEXT NOT NULL,
+    inward    TEXT NOT NULL,
+    outward   TEXT NOT NULL,
+    sourceKey TEXT NOT NULL,
+    targetKey TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS IssueLink_source ON IssueLink (sourceKey);
+CREATE INDEX IF NOT EXISTS IssueLink_target ON IssueLink (targetKey);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS IssueLink;
+ALTER TABLE Issue DROP COLUMN IF EXISTS epicKey;
+ALTER TABLE Issue DROP COLUMN IF EXISTS parentKey;
+-- +goose StatementEnd
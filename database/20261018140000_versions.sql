@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ids of components and versions are the Jira ones
+CREATE TABLE IF NOT EXISTS Component
+(
+    id        TEXT PRIMARY KEY,
+    projectId INT  NOT NULL,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    name      TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Version
+(
+    id          TEXT PRIMARY KEY,
+    projectId   INT     NOT NULL,
+    FOREIGN KEY (projectId) REFERENCES Projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    name        TEXT    NOT NULL,
+    description TEXT    NOT NULL DEFAULT '',
+    archived    BOOLEAN NOT NULL DEFAULT FALSE,
+    released    BOOLEAN NOT NULL DEFAULT FALSE,
+    startDate   DATE,
+    releaseDate DATE
+);
+
+CREATE INDEX IF NOT EXISTS Component_project ON Component (projectId);
+CREATE INDEX IF NOT EXISTS Version_project ON Version (projectId, releaseDate);
+
+CREATE TABLE IF NOT EXISTS IssueComponent
+(
+    issueId     INT  NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    componentId TEXT NOT NULL,
+    FOREIGN KEY (componentId) REFERENCES Component (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (issueId, componentId)
+);
+
+CREATE TABLE IF NOT EXISTS IssueLabel
+(
+    issueId INT  NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    label   TEXT NOT NULL,
+    PRIMARY KEY (issueId, label)
+);
+
+-- kind is fix for fixVersions and affected for versions
+CREATE TABLE IF NOT EXISTS IssueVersion
+(
+    issueId   INT  NOT NULL,
+    FOREIGN KEY (issueId) REFERENCES Issue (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    versionId TEXT NOT NULL,
+    FOREIGN KEY (versionId) REFERENCES Version (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    kind      TEXT NOT NULL CHECK (kind IN ('fix', 'affected')),
+    PRIMARY KEY (issueId, versionId, kind)
+);
+
+CREATE INDEX IF NOT EXISTS IssueComponent_component ON IssueComponent (componentId);
+CREATE INDEX IF NOT EXISTS IssueLabel_label ON IssueLabel (label);
+CREATE INDEX IF NOT EXISTS IssueVersion_version ON IssueVersion (versionId, kind);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS IssueVersion;
+DROP TABLE IF EXISTS IssueLabel;
+DROP TABLE IF EXISTS IssueComponent;
+DROP TABLE IF EXISTS Version;
+DROP TABLE IF EXISTS Component;
+-- +goose StatementEnd
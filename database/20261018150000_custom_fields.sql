@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- values of the custom fields mapped in the connector config by their mapped names, e.g. {"storyPoints": 3}
+ALTER TABLE Issue ADD COLUMN IF NOT EXISTS custom_fields JSONB;
+
+CREATE INDEX IF NOT EXISTS Issue_custom_fields ON Issue USING GIN (custom_fields);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE Issue DROP COLUMN IF EXISTS custom_fields;
+-- +goose StatementEnd
@@ -0,0 +1,137 @@
+-- +goose Up
+-- +goose StatementBegin
+-- several Jira instances are synced into one database, so everything keyed by a Jira key or id
+-- is keyed by the source it came from too. Existing rows belong to the default source.
+ALTER TABLE sync_checkpoints DROP CONSTRAINT IF EXISTS sync_checkpoints_projectkey_fkey;
+ALTER TABLE IssueComponent DROP CONSTRAINT IF EXISTS issuecomponent_componentid_fkey;
+ALTER TABLE IssueVersion DROP CONSTRAINT IF EXISTS issueversion_versionid_fkey;
+ALTER TABLE ProjectBoard DROP CONSTRAINT IF EXISTS projectboard_boardid_fkey;
+ALTER TABLE SprintIssue DROP CONSTRAINT IF EXISTS sprintissue_sprintid_fkey;
+
+-- project ids of different instances collide too: the Jira one moves to jiraId
+-- and new projects get generated ids
+ALTER TABLE Projects ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Projects ADD COLUMN IF NOT EXISTS jiraId TEXT;
+UPDATE Projects SET jiraId = id::text WHERE jiraId IS NULL;
+SELECT setval(pg_get_serial_sequence('projects', 'id'), (SELECT COALESCE(max(id), 0) + 1 FROM Projects), false);
+ALTER TABLE Projects DROP CONSTRAINT IF EXISTS projects_key_key;
+ALTER TABLE Projects ADD CONSTRAINT projects_source_key UNIQUE (source, key);
+
+ALTER TABLE Issue ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Issue DROP CONSTRAINT IF EXISTS issue_key_key;
+ALTER TABLE Issue ADD CONSTRAINT issue_source_key UNIQUE (source, key);
+
+-- worklog and comment ids are unique within the issue they belong to
+ALTER TABLE Worklog DROP CONSTRAINT IF EXISTS worklog_jiraid_key;
+ALTER TABLE Worklog ADD CONSTRAINT worklog_issue_jiraid UNIQUE (issueId, jiraId);
+ALTER TABLE Comment DROP CONSTRAINT IF EXISTS comment_jiraid_key;
+ALTER TABLE Comment ADD CONSTRAINT comment_issue_jiraid UNIQUE (issueId, jiraId);
+
+ALTER TABLE IssueLink ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE IssueLink DROP CONSTRAINT IF EXISTS issuelink_pkey;
+ALTER TABLE IssueLink ADD PRIMARY KEY (source, jiraId);
+
+ALTER TABLE Component ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Component DROP CONSTRAINT IF EXISTS component_pkey;
+ALTER TABLE Component ADD PRIMARY KEY (source, id);
+ALTER TABLE IssueComponent ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE IssueComponent ADD FOREIGN KEY (source, componentId) REFERENCES Component (source, id)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE Version ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Version DROP CONSTRAINT IF EXISTS version_pkey;
+ALTER TABLE Version ADD PRIMARY KEY (source, id);
+ALTER TABLE IssueVersion ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE IssueVersion ADD FOREIGN KEY (source, versionId) REFERENCES Version (source, id)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE Board ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Board DROP CONSTRAINT IF EXISTS board_pkey;
+ALTER TABLE Board ADD PRIMARY KEY (source, id);
+ALTER TABLE ProjectBoard ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE ProjectBoard ADD FOREIGN KEY (source, boardId) REFERENCES Board (source, id)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE Sprint ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Sprint DROP CONSTRAINT IF EXISTS sprint_pkey;
+ALTER TABLE Sprint ADD PRIMARY KEY (source, id);
+DROP INDEX IF EXISTS Sprint_board;
+CREATE INDEX IF NOT EXISTS Sprint_board ON Sprint (source, boardId);
+ALTER TABLE SprintIssue ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE SprintIssue ADD FOREIGN KEY (source, sprintId) REFERENCES Sprint (source, id)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE sync_checkpoints ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE sync_checkpoints DROP CONSTRAINT IF EXISTS sync_checkpoints_pkey;
+ALTER TABLE sync_checkpoints ADD PRIMARY KEY (source, projectKey);
+ALTER TABLE sync_checkpoints ADD FOREIGN KEY (source, projectKey) REFERENCES Projects (source, key)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE sync_jobs ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+DROP INDEX IF EXISTS sync_jobs_active;
+CREATE UNIQUE INDEX IF NOT EXISTS sync_jobs_active
+    ON sync_jobs (source, projectKey) WHERE state IN ('queued', 'running');
+
+-- every source has its own schedule and runs
+ALTER TABLE sync_schedule DROP COLUMN IF EXISTS id;
+ALTER TABLE sync_schedule ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default' PRIMARY KEY;
+ALTER TABLE schedule_runs ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS schedule_runs_source ON schedule_runs (source, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- fails if projects of different sources share a key
+DROP INDEX IF EXISTS schedule_runs_source;
+ALTER TABLE schedule_runs DROP COLUMN IF EXISTS source;
+ALTER TABLE sync_schedule DROP COLUMN IF EXISTS source;
+ALTER TABLE sync_schedule ADD COLUMN IF NOT EXISTS id INT PRIMARY KEY DEFAULT 1 CHECK (id = 1);
+
+DROP INDEX IF EXISTS sync_jobs_active;
+ALTER TABLE sync_jobs DROP COLUMN IF EXISTS source;
+CREATE UNIQUE INDEX IF NOT EXISTS sync_jobs_active
+    ON sync_jobs (projectKey) WHERE state IN ('queued', 'running');
+
+ALTER TABLE sync_checkpoints DROP COLUMN IF EXISTS source;
+ALTER TABLE sync_checkpoints ADD PRIMARY KEY (projectKey);
+
+ALTER TABLE SprintIssue DROP COLUMN IF EXISTS source;
+DROP INDEX IF EXISTS Sprint_board;
+ALTER TABLE Sprint DROP COLUMN IF EXISTS source;
+ALTER TABLE Sprint ADD PRIMARY KEY (id);
+CREATE INDEX IF NOT EXISTS Sprint_board ON Sprint (boardId);
+ALTER TABLE SprintIssue ADD FOREIGN KEY (sprintId) REFERENCES Sprint (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE ProjectBoard DROP COLUMN IF EXISTS source;
+ALTER TABLE Board DROP COLUMN IF EXISTS source;
+ALTER TABLE Board ADD PRIMARY KEY (id);
+ALTER TABLE ProjectBoard ADD FOREIGN KEY (boardId) REFERENCES Board (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE IssueVersion DROP COLUMN IF EXISTS source;
+ALTER TABLE Version DROP COLUMN IF EXISTS source;
+ALTER TABLE Version ADD PRIMARY KEY (id);
+ALTER TABLE IssueVersion ADD FOREIGN KEY (versionId) REFERENCES Version (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE IssueComponent DROP COLUMN IF EXISTS source;
+ALTER TABLE Component DROP COLUMN IF EXISTS source;
+ALTER TABLE Component ADD PRIMARY KEY (id);
+ALTER TABLE IssueComponent ADD FOREIGN KEY (componentId) REFERENCES Component (id)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE IssueLink DROP COLUMN IF EXISTS source;
+ALTER TABLE IssueLink ADD PRIMARY KEY (jiraId);
+
+ALTER TABLE Comment DROP CONSTRAINT IF EXISTS comment_issue_jiraid;
+ALTER TABLE Comment ADD CONSTRAINT comment_jiraid_key UNIQUE (jiraId);
+ALTER TABLE Worklog DROP CONSTRAINT IF EXISTS worklog_issue_jiraid;
+ALTER TABLE Worklog ADD CONSTRAINT worklog_jiraid_key UNIQUE (jiraId);
+
+ALTER TABLE Issue DROP COLUMN IF EXISTS source;
+ALTER TABLE Issue ADD CONSTRAINT issue_key_key UNIQUE (key);
+
+ALTER TABLE Projects DROP COLUMN IF EXISTS source;
+ALTER TABLE Projects DROP COLUMN IF EXISTS jiraId;
+ALTER TABLE Projects ADD CONSTRAINT projects_key_key UNIQUE (key);
+ALTER TABLE sync_checkpoints ADD FOREIGN KEY (projectKey) REFERENCES Projects (key)
+    ON DELETE CASCADE ON UPDATE CASCADE;
+-- +goose StatementEnd
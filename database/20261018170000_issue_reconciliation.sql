@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the Jira issue id survives moving the issue to another project, which changes its key.
+-- Rows saved before are matched by key on the next reconciliation of their project.
+ALTER TABLE Issue ADD COLUMN IF NOT EXISTS jiraId TEXT;
+-- set when the issue is no longer found in its project in Jira
+ALTER TABLE Issue ADD COLUMN IF NOT EXISTS deletedAt TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS Issue_source_jiraId ON Issue (source, jiraId);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS Issue_source_jiraId;
+ALTER TABLE Issue DROP COLUMN IF EXISTS deletedAt;
+ALTER TABLE Issue DROP COLUMN IF EXISTS jiraId;
+-- +goose StatementEnd
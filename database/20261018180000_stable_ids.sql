@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the accountId (Jira Cloud) or key (Jira Server) of the user identifies an author from now on.
+-- Existing rows have none until their user is seen again and are matched by name once.
+ALTER TABLE Author ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE Author ADD COLUMN IF NOT EXISTS jiraId TEXT;
+
+-- authors were shared by all sources, an author belongs to the source of the issues it is
+-- referenced from. Authors of several sources are copied, one row per source.
+CREATE TEMP TABLE author_source AS
+SELECT DISTINCT r.authorId AS id, i.source
+FROM (SELECT id AS issueId, authorId FROM Issue
+      UNION ALL SELECT id, assigneeId FROM Issue
+      UNION ALL SELECT issueId, authorId FROM StatusChanges
+      UNION ALL SELECT issueId, authorId FROM FieldChanges
+      UNION ALL SELECT issueId, authorId FROM Worklog
+      UNION ALL SELECT issueId, authorId FROM Comment) r
+JOIN Issue i ON i.id = r.issueId;
+
+UPDATE Author a SET source = s.source
+FROM (SELECT id, MIN(source) AS source FROM author_source GROUP BY id) s
+WHERE a.id = s.id;
+
+CREATE TEMP TABLE author_copy AS
+SELECT s.id, s.source, nextval(pg_get_serial_sequence('author', 'id'))::INT AS copyId
+FROM author_source s JOIN Author a ON a.id = s.id
+WHERE s.source <> a.source;
+INSERT INTO Author (id, source, name)
+SELECT c.copyId, c.source, a.name FROM author_copy c JOIN Author a ON a.id = c.id;
+
+UPDATE Issue i SET authorId = c.copyId FROM author_copy c
+WHERE i.authorId = c.id AND i.source = c.source;
+UPDATE Issue i SET assigneeId = c.copyId FROM author_copy c
+WHERE i.assigneeId = c.id AND i.source = c.source;
+UPDATE StatusChanges s SET authorId = c.copyId FROM author_copy c, Issue i
+WHERE s.authorId = c.id AND i.id = s.issueId AND i.source = c.source;
+UPDATE FieldChanges f SET authorId = c.copyId FROM author_copy c, Issue i
+WHERE f.authorId = c.id AND i.id = f.issueId AND i.source = c.source;
+UPDATE Worklog w SET authorId = c.copyId FROM author_copy c, Issue i
+WHERE w.authorId = c.id AND i.id = w.issueId AND i.source = c.source;
+UPDATE Comment cm SET authorId = c.copyId FROM author_copy c, Issue i
+WHERE cm.authorId = c.id AND i.id = cm.issueId AND i.source = c.source;
+DROP TABLE author_copy;
+DROP TABLE author_source;
+
+-- authors were inserted by display name without a unique constraint, so every sync added
+-- the same people again. Duplicates of a source are merged into the oldest row before rows
+-- are deleted, as deleting an author cascades to everything it wrote.
+CREATE TEMP TABLE author_merge AS
+SELECT id, MIN(id) OVER (PARTITION BY source, name) AS keepId FROM Author;
+DELETE FROM author_merge WHERE id = keepId;
+
+UPDATE Issue i SET authorId = m.keepId FROM author_merge m WHERE i.authorId = m.id;
+UPDATE Issue i SET assigneeId = m.keepId FROM author_merge m WHERE i.assigneeId = m.id;
+UPDATE StatusChanges s SET authorId = m.keepId FROM author_merge m WHERE s.authorId = m.id;
+UPDATE FieldChanges f SET authorId = m.keepId FROM author_merge m WHERE f.authorId = m.id;
+UPDATE Worklog w SET authorId = m.keepId FROM author_merge m WHERE w.authorId = m.id;
+UPDATE Comment c SET authorId = m.keepId FROM author_merge m WHERE c.authorId = m.id;
+DELETE FROM Author a USING author_merge m WHERE a.id = m.id;
+DROP TABLE author_merge;
+
+CREATE UNIQUE INDEX IF NOT EXISTS Author_source_jiraId ON Author (source, jiraId);
+-- users without an id, e.g. from old exports, are still told apart by name only
+CREATE UNIQUE INDEX IF NOT EXISTS Author_source_name ON Author (source, name) WHERE jiraId IS NULL;
+
+-- a project keeps its Jira id when its key changes
+CREATE UNIQUE INDEX IF NOT EXISTS Projects_source_jiraId ON Projects (source, jiraId);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- merged authors are not split again and copies of an author in several sources are kept
+DROP INDEX IF EXISTS Projects_source_jiraId;
+DROP INDEX IF EXISTS Author_source_name;
+DROP INDEX IF EXISTS Author_source_jiraId;
+ALTER TABLE Author DROP COLUMN IF EXISTS jiraId;
+ALTER TABLE Author DROP COLUMN IF EXISTS source;
+-- +goose StatementEnd
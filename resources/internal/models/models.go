@@ -4,14 +4,18 @@ import "time"
 
 // Project main info about project
 type Project struct {
-	Id   int    `json:"id"`
-	Key  string `json:"key"`
-	Name string `json:"name"`
+	Id     int    `json:"id"`
+	Source string `json:"source"`
+	JiraId string `json:"jiraId"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
 }
 
 // ProjectInfo full info about project
 type ProjectInfo struct {
 	Id                  int     `json:"id"`
+	Source              string  `json:"source"`
+	JiraId              string  `json:"jiraId"`
 	Key                 string  `json:"key"`
 	Name                string  `json:"name"`
 	AllIssuesCount      int     `json:"allIssuesCount"`
@@ -35,6 +39,7 @@ type Issue struct {
 // IssueInfo full info about issue
 type IssueInfo struct {
 	Id                int       `json:"id"`
+	JiraId            string    `json:"jiraId"`
 	ProjectId         int       `json:"projectId"`
 	AuthorId          int       `json:"authorId"`
 	AuthorName        string    `json:"authorName"`
@@ -50,6 +55,22 @@ type IssueInfo struct {
 	UpdatedTime       time.Time `json:"updated_time"`
 	TimeSpent         int32     `json:"timespent"`
 	ChangeStatusCount int       `json:"change_status_count"`
+	Components        []string  `json:"components"`
+	Labels            []string  `json:"labels"`
+	FixVersions       []string  `json:"fixVersions"`
+	AffectedVersions  []string  `json:"affectedVersions"`
+	// CustomFields values of the custom fields mapped in the connector config
+	CustomFields map[string]any `json:"customFields"`
+	// DeletedAt is set if the issue is no longer found in its project in Jira
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// IssueFilter narrows issue lists, an issue matches a list if it has any of its values
+type IssueFilter struct {
+	Components       []string
+	Labels           []string
+	FixVersions      []string
+	AffectedVersions []string
 }
 
 // History status change info about issue
@@ -61,6 +82,79 @@ type History struct {
 	ToStatus   string    `json:"toStatus"`
 }
 
+// FieldChange change of any issue field from the changelog
+type FieldChange struct {
+	IssueId    int       `json:"issueId"`
+	AuthorId   int       `json:"authorId"`
+	ChangeTime time.Time `json:"changeTime"`
+	Field      string    `json:"field"`
+	FromId     string    `json:"fromId"`
+	FromString string    `json:"fromString"`
+	ToId       string    `json:"toId"`
+	ToString   string    `json:"toString"`
+}
+
+// Worklog time logged on the issue
+type Worklog struct {
+	Id               int       `json:"id"`
+	IssueId          int       `json:"issueId"`
+	AuthorId         int       `json:"authorId"`
+	Started          time.Time `json:"started"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds"`
+	Comment          string    `json:"comment"`
+}
+
+// Comment comment on the issue
+type Comment struct {
+	Id       int       `json:"id"`
+	IssueId  int       `json:"issueId"`
+	AuthorId int       `json:"authorId"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+	Body     string    `json:"body"`
+}
+
+// Release version of the project with the progress of its issues
+type Release struct {
+	Id                  string     `json:"id"`
+	ProjectId           int        `json:"projectId"`
+	Name                string     `json:"name"`
+	Description         string     `json:"description"`
+	Archived            bool       `json:"archived"`
+	Released            bool       `json:"released"`
+	StartDate           *time.Time `json:"startDate"`
+	ReleaseDate         *time.Time `json:"releaseDate"`
+	IssuesCount         int        `json:"issuesCount"`
+	ClosedIssuesCount   int        `json:"closedIssuesCount"`
+	AffectedIssuesCount int        `json:"affectedIssuesCount"`
+}
+
+// IssueGraph issues linked to the root issue up to the requested depth
+type IssueGraph struct {
+	Root  string      `json:"root"`
+	Depth int         `json:"depth"`
+	Nodes []IssueNode `json:"nodes"`
+	Edges []IssueEdge `json:"edges"`
+}
+
+// IssueNode issue of the graph, Id is 0 if the issue is not loaded
+type IssueNode struct {
+	Id      int    `json:"id"`
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Depth   int    `json:"depth"`
+}
+
+// IssueEdge link between issues read as From Relation To, e.g. TEST-1 blocks TEST-2
+type IssueEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Type     string `json:"type"`
+	Relation string `json:"relation"`
+}
+
 type Link struct {
 	URL string `json:"href"`
 }
@@ -71,6 +165,8 @@ type ReferencesLinks struct {
 	LinkIssues    []Link `json:"issues"`
 	LinkProjects  []Link `json:"projects"`
 	LinkHistories []Link `json:"histories"`
+	LinkWorklogs  []Link `json:"worklogs"`
+	LinkComments  []Link `json:"comments"`
 }
 
 // Response links and data
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sssidkn/resources/internal/models"
 )
@@ -13,9 +14,16 @@ type Repository interface {
 	GetProject(ctx context.Context, id int) (*models.ProjectInfo, error)
 	DeleteProject(ctx context.Context, id int) error
 	GetIssue(ctx context.Context, id int) (*models.IssueInfo, error)
-	GetIssuesByProject(ctx context.Context, projectId int, limit int, offset int) (*[]models.Issue, int, error)
+	GetIssuesByProject(ctx context.Context, projectId int, filter models.IssueFilter, limit int, offset int) (*[]models.Issue, int, error)
 	GetHistoryByIssue(ctx context.Context, issueId int) (*[]models.History, error)
 	GetHistoryByAuthor(ctx context.Context, authorId int) (*[]models.History, error)
+	GetFieldChangesByIssue(ctx context.Context, issueId int, fields []string) (*[]models.FieldChange, error)
+	GetFieldChangesByProject(ctx context.Context, projectId int, fields []string, limit int, offset int) (*[]models.FieldChange, int, error)
+	GetWorklogsByIssue(ctx context.Context, issueId int) (*[]models.Worklog, error)
+	GetWorklogsByAuthor(ctx context.Context, authorId int) (*[]models.Worklog, error)
+	GetCommentsByIssue(ctx context.Context, issueId int) (*[]models.Comment, error)
+	GetIssueGraph(ctx context.Context, issueId int, depth int) (*models.IssueGraph, error)
+	GetReleasesByProject(ctx context.Context, projectId int) (*[]models.Release, error)
 }
 
 type repo struct {
@@ -28,7 +36,7 @@ func New(db *pgxpool.Pool) *repo {
 
 func (r *repo) GetProjects(ctx context.Context, limit int, offset int) (*[]models.Project, int, error) {
 	var projects []models.Project
-	query := `SELECT id, key, title from projects LIMIT $1 OFFSET $2`
+	query := `SELECT id, source, COALESCE(jiraid, ''), key, title from projects LIMIT $1 OFFSET $2`
 	rows, err := r.db.Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, ErrSelect(err)
@@ -37,7 +45,7 @@ func (r *repo) GetProjects(ctx context.Context, limit int, offset int) (*[]model
 
 	for rows.Next() {
 		var p models.Project
-		err = rows.Scan(&p.Id, &p.Key, &p.Name)
+		err = rows.Scan(&p.Id, &p.Source, &p.JiraId, &p.Key, &p.Name)
 		if err != nil {
 			return nil, 0, ErrScan(err)
 		}
@@ -65,7 +73,7 @@ func (r *repo) GetProject(ctx context.Context, id int) (*models.ProjectInfo, err
 	var project models.ProjectInfo
 
 	query := `SELECT 
-		p.id, p.key, p.title,
+		p.id, p.source, COALESCE(p.jiraid, ''), p.key, p.title,
 		COUNT(i.id) AS all_issues_count,
 		COUNT(i.id) FILTER (WHERE i.status = 'Opened') AS opened_issues_count,
 		COUNT(i.id) FILTER (WHERE i.status = 'Closed') AS closed_issues_count,
@@ -77,14 +85,14 @@ func (r *repo) GetProject(ctx context.Context, id int) (*models.ProjectInfo, err
 		FROM 
 			projects p
 		LEFT JOIN 
-			issue i ON i.projectid = p.id
+			issue i ON i.projectid = p.id AND i.deletedat IS NULL
 		WHERE 
 			p.id = $1
 		GROUP BY 
-			p.id, p.key, p.title`
+			p.id, p.source, p.key, p.title`
 
 	var avgTime sql.NullFloat64
-	err = r.db.QueryRow(ctx, query, id).Scan(&project.Id, &project.Key, &project.Name, &project.AllIssuesCount,
+	err = r.db.QueryRow(ctx, query, id).Scan(&project.Id, &project.Source, &project.JiraId, &project.Key, &project.Name, &project.AllIssuesCount,
 		&project.OpenedIssuesCount, &project.ClosedIssuesCount, &project.ResolvedIssuesCount, &project.ReopenedIssuesCount,
 		&project.ProgressIssuesCount, &avgTime, &project.AverageIssuesCount)
 	if err != nil {
@@ -137,12 +145,52 @@ func (r *repo) DeleteProject(ctx context.Context, id int) error {
 		}
 	}
 
+	query = `DELETE FROM fieldchanges WHERE issueid = ANY($1)`
+	_, err = tx.Exec(ctx, query, issuesIds)
+	if err != nil {
+		return ErrDelete(err)
+	}
+
+	query = `DELETE FROM worklog WHERE issueid = ANY($1)`
+	_, err = tx.Exec(ctx, query, issuesIds)
+	if err != nil {
+		return ErrDelete(err)
+	}
+
+	query = `DELETE FROM comment WHERE issueid = ANY($1)`
+	_, err = tx.Exec(ctx, query, issuesIds)
+	if err != nil {
+		return ErrDelete(err)
+	}
+
+	for _, table := range []string{"issuecomponent", "issuelabel", "issueversion"} {
+		_, err = tx.Exec(ctx, `DELETE FROM `+table+` WHERE issueid = ANY($1)`, issuesIds)
+		if err != nil {
+			return ErrDelete(err)
+		}
+	}
+
+	query = `DELETE FROM issuelink l USING projects p WHERE p.id = $1 AND l.source = p.source
+		AND (l.sourcekey IN (SELECT key FROM issue WHERE projectid = $1)
+			OR l.targetkey IN (SELECT key FROM issue WHERE projectid = $1))`
+	_, err = tx.Exec(ctx, query, id)
+	if err != nil {
+		return ErrDelete(err)
+	}
+
 	query = `DELETE FROM issue WHERE projectid = $1`
 	_, err = tx.Exec(ctx, query, id)
 	if err != nil {
 		return ErrDelete(err)
 	}
 
+	for _, table := range []string{"component", "version"} {
+		_, err = tx.Exec(ctx, `DELETE FROM `+table+` WHERE projectid = $1`, id)
+		if err != nil {
+			return ErrDelete(err)
+		}
+	}
+
 	query = `DELETE FROM projects WHERE id = $1`
 	_, err = tx.Exec(ctx, query, id)
 	if err != nil {
@@ -167,9 +215,11 @@ func (r *repo) GetIssue(ctx context.Context, id int) (*models.IssueInfo, error)
 
 	var issue models.IssueInfo
 	var timeSpent sql.NullInt32
-	query := `SELECT * from issue WHERE id = $1`
-	err = r.db.QueryRow(ctx, query, id).Scan(&issue.Id, &issue.ProjectId, &issue.AuthorId, &issue.AssigneeId, &issue.Key, &issue.Summary,
-		&issue.Description, &issue.Type, &issue.Priority, &issue.Status, &issue.CreatedTime, &issue.ClosedTime, &issue.UpdatedTime, &timeSpent)
+	query := `SELECT id, COALESCE(jiraid, ''), projectid, authorid, assigneeid, key, summary, description, type, priority, status,
+		createdtime, closedtime, updatedtime, timespent, custom_fields, deletedat from issue WHERE id = $1`
+	err = r.db.QueryRow(ctx, query, id).Scan(&issue.Id, &issue.JiraId, &issue.ProjectId, &issue.AuthorId, &issue.AssigneeId, &issue.Key, &issue.Summary,
+		&issue.Description, &issue.Type, &issue.Priority, &issue.Status, &issue.CreatedTime, &issue.ClosedTime, &issue.UpdatedTime, &timeSpent,
+		&issue.CustomFields, &issue.DeletedAt)
 	if err != nil {
 		return nil, ErrScan(err)
 	}
@@ -177,8 +227,8 @@ func (r *repo) GetIssue(ctx context.Context, id int) (*models.IssueInfo, error)
 		issue.TimeSpent = timeSpent.Int32
 	}
 
-	query = `SELECT name FROM author WHERE id = $1`
-	err = r.db.QueryRow(ctx, query, id).Scan(&issue.AuthorName)
+	query = `SELECT COALESCE(name, '') FROM author WHERE id = $1`
+	err = r.db.QueryRow(ctx, query, issue.AuthorId).Scan(&issue.AuthorName)
 	if err != nil {
 		return nil, ErrScan(err)
 	}
@@ -188,10 +238,24 @@ func (r *repo) GetIssue(ctx context.Context, id int) (*models.IssueInfo, error)
 	if err != nil {
 		return nil, ErrScan(err)
 	}
+
+	query = `SELECT
+		ARRAY(SELECT c.name FROM issuecomponent ic JOIN component c ON c.source = ic.source AND c.id = ic.componentid
+			WHERE ic.issueid = $1 ORDER BY c.name),
+		ARRAY(SELECT label FROM issuelabel WHERE issueid = $1 ORDER BY label),
+		ARRAY(SELECT v.name FROM issueversion iv JOIN version v ON v.source = iv.source AND v.id = iv.versionid
+			WHERE iv.issueid = $1 AND iv.kind = 'fix' ORDER BY v.releasedate NULLS LAST, v.name),
+		ARRAY(SELECT v.name FROM issueversion iv JOIN version v ON v.source = iv.source AND v.id = iv.versionid
+			WHERE iv.issueid = $1 AND iv.kind = 'affected' ORDER BY v.releasedate NULLS LAST, v.name)`
+	err = r.db.QueryRow(ctx, query, id).Scan(&issue.Components, &issue.Labels, &issue.FixVersions,
+		&issue.AffectedVersions)
+	if err != nil {
+		return nil, ErrScan(err)
+	}
 	return &issue, nil
 }
 
-func (r *repo) GetIssuesByProject(ctx context.Context, projectId int, limit int, offset int) (*[]models.Issue, int, error) {
+func (r *repo) GetIssuesByProject(ctx context.Context, projectId int, filter models.IssueFilter, limit int, offset int) (*[]models.Issue, int, error) {
 	exist, err := r.checkExistenceOfProject(projectId)
 	if err != nil {
 		return nil, 0, ErrExistence(err)
@@ -200,9 +264,11 @@ func (r *repo) GetIssuesByProject(ctx context.Context, projectId int, limit int,
 		return nil, 0, ErrNotExist
 	}
 
+	args := []any{projectId, filter.Components, filter.Labels, filter.FixVersions, filter.AffectedVersions}
 	var issues []models.Issue
-	query := `SELECT id, projectid, authorid, type from issue WHERE projectid = $1 LIMIT $2 OFFSET $3`
-	rows, err := r.db.Query(ctx, query, projectId, limit, offset)
+	query := `SELECT id, projectid, authorid, type from issue i WHERE ` + issueFilterCondition +
+		` ORDER BY id LIMIT $6 OFFSET $7`
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, ErrSelect(err)
 	}
@@ -218,8 +284,8 @@ func (r *repo) GetIssuesByProject(ctx context.Context, projectId int, limit int,
 	}
 
 	var total int
-	query = `SELECT COUNT(*) FROM issue WHERE projectid = $1`
-	err = r.db.QueryRow(ctx, query, projectId).Scan(&total)
+	query = `SELECT COUNT(*) FROM issue i WHERE ` + issueFilterCondition
+	err = r.db.QueryRow(ctx, query, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, ErrScan(err)
 	}
@@ -227,6 +293,62 @@ func (r *repo) GetIssuesByProject(ctx context.Context, projectId int, limit int,
 	return &issues, total, nil
 }
 
+// issueFilterCondition selects the issues i of the project $1 matching the component,
+// label, fix and affected version names $2-$5. An empty list matches every issue.
+// Issues deleted in Jira are skipped.
+const issueFilterCondition = `i.projectid = $1 AND i.deletedat IS NULL
+	AND (COALESCE(cardinality($2::text[]), 0) = 0 OR EXISTS (SELECT 1 FROM issuecomponent ic
+		JOIN component c ON c.source = ic.source AND c.id = ic.componentid WHERE ic.issueid = i.id AND c.name = ANY($2)))
+	AND (COALESCE(cardinality($3::text[]), 0) = 0 OR EXISTS (SELECT 1 FROM issuelabel il
+		WHERE il.issueid = i.id AND il.label = ANY($3)))
+	AND (COALESCE(cardinality($4::text[]), 0) = 0 OR EXISTS (SELECT 1 FROM issueversion iv
+		JOIN version v ON v.source = iv.source AND v.id = iv.versionid WHERE iv.issueid = i.id AND iv.kind = 'fix' AND v.name = ANY($4)))
+	AND (COALESCE(cardinality($5::text[]), 0) = 0 OR EXISTS (SELECT 1 FROM issueversion iv
+		JOIN version v ON v.source = iv.source AND v.id = iv.versionid WHERE iv.issueid = i.id AND iv.kind = 'affected' AND v.name = ANY($5)))`
+
+// GetReleasesByProject returns the versions of the project by release date, unscheduled ones last
+func (r *repo) GetReleasesByProject(ctx context.Context, projectId int) (*[]models.Release, error) {
+	exist, err := r.checkExistenceOfProject(projectId)
+	if err != nil {
+		return nil, ErrExistence(err)
+	}
+	if !exist {
+		return nil, ErrNotExist
+	}
+
+	query := `SELECT v.id, v.projectid, v.name, v.description, v.archived, v.released, v.startdate, v.releasedate,
+		COUNT(i.id) FILTER (WHERE iv.kind = 'fix'),
+		COUNT(i.id) FILTER (WHERE iv.kind = 'fix' AND i.status IN ('Closed', 'Resolved')),
+		COUNT(i.id) FILTER (WHERE iv.kind = 'affected')
+		FROM version v
+		LEFT JOIN issueversion iv ON iv.source = v.source AND iv.versionid = v.id
+		LEFT JOIN issue i ON i.id = iv.issueid AND i.deletedat IS NULL
+		WHERE v.projectid = $1
+		GROUP BY v.source, v.id
+		ORDER BY v.releasedate NULLS LAST, v.name`
+	rows, err := r.db.Query(ctx, query, projectId)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	releases := make([]models.Release, 0)
+	for rows.Next() {
+		var release models.Release
+		err = rows.Scan(&release.Id, &release.ProjectId, &release.Name, &release.Description, &release.Archived,
+			&release.Released, &release.StartDate, &release.ReleaseDate, &release.IssuesCount,
+			&release.ClosedIssuesCount, &release.AffectedIssuesCount)
+		if err != nil {
+			return nil, ErrScan(err)
+		}
+		releases = append(releases, release)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, ErrSelect(err)
+	}
+	return &releases, nil
+}
+
 func (r *repo) GetHistoryByIssue(ctx context.Context, issueId int) (*[]models.History, error) {
 	exist, err := r.checkExistenceOfIssue(issueId)
 	if err != nil {
@@ -283,6 +405,299 @@ func (r *repo) GetHistoryByAuthor(ctx context.Context, authorId int) (*[]models.
 	return &histories, nil
 }
 
+// GetFieldChangesByIssue returns the changes of the fields, of all fields if fields is empty
+func (r *repo) GetFieldChangesByIssue(ctx context.Context, issueId int, fields []string) (*[]models.FieldChange, error) {
+	exist, err := r.checkExistenceOfIssue(issueId)
+	if err != nil {
+		return nil, ErrExistence(err)
+	}
+	if !exist {
+		return nil, ErrNotExist
+	}
+
+	query := `SELECT issueid, authorid, changetime, field, fromid, fromstring, toid, tostring
+		FROM fieldchanges
+		WHERE issueid = $1 AND (COALESCE(cardinality($2::text[]), 0) = 0 OR field = ANY($2))
+		ORDER BY changetime`
+	rows, err := r.db.Query(ctx, query, issueId, fields)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	changes, err := scanFieldChanges(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &changes, nil
+}
+
+// GetFieldChangesByProject returns the changes of the fields in all issues of the project
+func (r *repo) GetFieldChangesByProject(ctx context.Context, projectId int, fields []string, limit int,
+	offset int) (*[]models.FieldChange, int, error) {
+	exist, err := r.checkExistenceOfProject(projectId)
+	if err != nil {
+		return nil, 0, ErrExistence(err)
+	}
+	if !exist {
+		return nil, 0, ErrNotExist
+	}
+
+	query := `SELECT fc.issueid, fc.authorid, fc.changetime, fc.field, fc.fromid, fc.fromstring, fc.toid, fc.tostring
+		FROM fieldchanges fc
+		JOIN issue i ON i.id = fc.issueid
+		WHERE i.projectid = $1 AND i.deletedat IS NULL
+			AND (COALESCE(cardinality($2::text[]), 0) = 0 OR fc.field = ANY($2))
+		ORDER BY fc.changetime, fc.issueid
+		LIMIT $3 OFFSET $4`
+	rows, err := r.db.Query(ctx, query, projectId, fields, limit, offset)
+	if err != nil {
+		return nil, 0, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	changes, err := scanFieldChanges(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	query = `SELECT COUNT(*)
+		FROM fieldchanges fc
+		JOIN issue i ON i.id = fc.issueid
+		WHERE i.projectid = $1 AND i.deletedat IS NULL
+			AND (COALESCE(cardinality($2::text[]), 0) = 0 OR fc.field = ANY($2))`
+	err = r.db.QueryRow(ctx, query, projectId, fields).Scan(&total)
+	if err != nil {
+		return nil, 0, ErrScan(err)
+	}
+	return &changes, total, nil
+}
+
+func (r *repo) GetWorklogsByIssue(ctx context.Context, issueId int) (*[]models.Worklog, error) {
+	exist, err := r.checkExistenceOfIssue(issueId)
+	if err != nil {
+		return nil, ErrExistence(err)
+	}
+	if !exist {
+		return nil, ErrNotExist
+	}
+
+	query := `SELECT id, issueid, authorid, started, timespentseconds, COALESCE(comment, '')
+		FROM worklog WHERE issueid = $1 ORDER BY started`
+	rows, err := r.db.Query(ctx, query, issueId)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	worklogs, err := scanWorklogs(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &worklogs, nil
+}
+
+func (r *repo) GetWorklogsByAuthor(ctx context.Context, authorId int) (*[]models.Worklog, error) {
+	exist, err := r.checkExistenceOfAuthor(authorId)
+	if err != nil {
+		return nil, ErrExistence(err)
+	}
+	if !exist {
+		return nil, ErrNotExist
+	}
+
+	query := `SELECT id, issueid, authorid, started, timespentseconds, COALESCE(comment, '')
+		FROM worklog WHERE authorid = $1 ORDER BY started`
+	rows, err := r.db.Query(ctx, query, authorId)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	worklogs, err := scanWorklogs(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &worklogs, nil
+}
+
+func (r *repo) GetCommentsByIssue(ctx context.Context, issueId int) (*[]models.Comment, error) {
+	exist, err := r.checkExistenceOfIssue(issueId)
+	if err != nil {
+		return nil, ErrExistence(err)
+	}
+	if !exist {
+		return nil, ErrNotExist
+	}
+
+	query := `SELECT id, issueid, authorid, createdtime, updatedtime, COALESCE(body, '')
+		FROM comment WHERE issueid = $1 ORDER BY createdtime`
+	rows, err := r.db.Query(ctx, query, issueId)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	var comments []models.Comment
+	for rows.Next() {
+		var comment models.Comment
+		err = rows.Scan(&comment.Id, &comment.IssueId, &comment.AuthorId, &comment.Created,
+			&comment.Updated, &comment.Body)
+		if err != nil {
+			return nil, ErrScan(err)
+		}
+		comments = append(comments, comment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, ErrSelect(err)
+	}
+	return &comments, nil
+}
+
+// GetIssueGraph walks issue links, sub-tasks and epics in both directions from the issue
+func (r *repo) GetIssueGraph(ctx context.Context, issueId int, depth int) (*models.IssueGraph, error) {
+	exist, err := r.checkExistenceOfIssue(issueId)
+	if err != nil {
+		return nil, ErrExistence(err)
+	}
+	if !exist {
+		return nil, ErrNotExist
+	}
+
+	graph := models.IssueGraph{Depth: depth, Nodes: []models.IssueNode{}, Edges: []models.IssueEdge{}}
+	var source string
+	err = r.db.QueryRow(ctx, `SELECT key, source FROM issue WHERE id = $1`, issueId).Scan(&graph.Root, &source)
+	if err != nil {
+		return nil, ErrScan(err)
+	}
+
+	levels := map[string]int{graph.Root: 0}
+	keys := []string{graph.Root}
+	seen := make(map[models.IssueEdge]bool)
+	frontier := []string{graph.Root}
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		edges, err := r.getIssueEdges(ctx, source, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = frontier[:0:0]
+		for _, edge := range edges {
+			if seen[edge] {
+				continue
+			}
+			seen[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+			for _, key := range []string{edge.From, edge.To} {
+				if _, ok := levels[key]; !ok {
+					levels[key] = level
+					keys = append(keys, key)
+					frontier = append(frontier, key)
+				}
+			}
+		}
+	}
+
+	query := `SELECT id, key, COALESCE(summary, ''), COALESCE(type, ''), COALESCE(status, '')
+		FROM issue WHERE source = $1 AND key = ANY($2)`
+	rows, err := r.db.Query(ctx, query, source, keys)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	nodes := make(map[string]models.IssueNode, len(keys))
+	for rows.Next() {
+		var node models.IssueNode
+		err = rows.Scan(&node.Id, &node.Key, &node.Summary, &node.Type, &node.Status)
+		if err != nil {
+			return nil, ErrScan(err)
+		}
+		nodes[node.Key] = node
+	}
+	if err = rows.Err(); err != nil {
+		return nil, ErrSelect(err)
+	}
+	for _, key := range keys {
+		node, ok := nodes[key]
+		if !ok {
+			node.Key = key
+		}
+		node.Depth = levels[key]
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	return &graph, nil
+}
+
+// getIssueEdges selects the links, parent and epic relations touching any of the issues of the source
+func (r *repo) getIssueEdges(ctx context.Context, source string, keys []string) ([]models.IssueEdge, error) {
+	// linked issues of untracked projects are not saved, only the deleted ones are left out
+	query := `SELECT sourcekey, targetkey, type, outward FROM issuelink l
+		WHERE source = $1 AND (sourcekey = ANY($2) OR targetkey = ANY($2))
+			AND NOT EXISTS (SELECT 1 FROM issue d WHERE d.source = l.source
+				AND d.key IN (l.sourcekey, l.targetkey) AND d.deletedat IS NOT NULL)
+		UNION ALL
+		SELECT parentkey, key, 'Sub-task', 'is parent of' FROM issue
+		WHERE source = $1 AND deletedat IS NULL AND parentkey IS NOT NULL AND (key = ANY($2) OR parentkey = ANY($2))
+		UNION ALL
+		SELECT epickey, key, 'Epic', 'is epic of' FROM issue
+		WHERE source = $1 AND deletedat IS NULL AND epickey IS NOT NULL AND (key = ANY($2) OR epickey = ANY($2))
+		ORDER BY 1, 2, 3`
+	rows, err := r.db.Query(ctx, query, source, keys)
+	if err != nil {
+		return nil, ErrSelect(err)
+	}
+	defer rows.Close()
+
+	var edges []models.IssueEdge
+	for rows.Next() {
+		var edge models.IssueEdge
+		err = rows.Scan(&edge.From, &edge.To, &edge.Type, &edge.Relation)
+		if err != nil {
+			return nil, ErrScan(err)
+		}
+		edges = append(edges, edge)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, ErrSelect(err)
+	}
+	return edges, nil
+}
+
+func scanWorklogs(rows pgx.Rows) ([]models.Worklog, error) {
+	var worklogs []models.Worklog
+	for rows.Next() {
+		var worklog models.Worklog
+		err := rows.Scan(&worklog.Id, &worklog.IssueId, &worklog.AuthorId, &worklog.Started,
+			&worklog.TimeSpentSeconds, &worklog.Comment)
+		if err != nil {
+			return nil, ErrScan(err)
+		}
+		worklogs = append(worklogs, worklog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrSelect(err)
+	}
+	return worklogs, nil
+}
+
+func scanFieldChanges(rows pgx.Rows) ([]models.FieldChange, error) {
+	var changes []models.FieldChange
+	for rows.Next() {
+		var change models.FieldChange
+		err := rows.Scan(&change.IssueId, &change.AuthorId, &change.ChangeTime, &change.Field,
+			&change.FromId, &change.FromString, &change.ToId, &change.ToString)
+		if err != nil {
+			return nil, ErrScan(err)
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrSelect(err)
+	}
+	return changes, nil
+}
+
 func (r *repo) checkExistenceOfProject(id int) (bool, error) {
 	var exist bool
 	err := r.db.QueryRow(context.Background(), `SELECT EXISTS(SELECT 1 FROM projects WHERE id = $1 )`, id).Scan(&exist)
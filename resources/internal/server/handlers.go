@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sssidkn/resources/internal/models"
 	"github.com/sssidkn/resources/internal/repository"
 )
 
@@ -145,6 +147,10 @@ func (s *Server) getIssue(c *gin.Context) {
 // @Tags Issues
 // @Produce json
 // @Param projectId path int true "ID проекта"
+// @Param component query []string false "Компоненты" collectionFormat(multi)
+// @Param label query []string false "Метки" collectionFormat(multi)
+// @Param fixVersion query []string false "Версии исправления" collectionFormat(multi)
+// @Param affectedVersion query []string false "Затронутые версии" collectionFormat(multi)
 // @Param limit query int false "Лимит записей (по умолчанию 20)"
 // @Param offset query int false "Смещение (по умолчанию 0)"
 // @Success 200 {object} models.PaginatedResponse
@@ -168,7 +174,13 @@ func (s *Server) getIssuesByProject(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	ctx = context.WithValue(ctx, "url", getFullURL(c))
-	response, err := s.service.GetIssuesByProject(ctx, projectId, limit, offset)
+	filter := models.IssueFilter{
+		Components:       queryValues(c, "component"),
+		Labels:           queryValues(c, "label"),
+		FixVersions:      queryValues(c, "fixVersion"),
+		AffectedVersions: queryValues(c, "affectedVersion"),
+	}
+	response, err := s.service.GetIssuesByProject(ctx, projectId, filter, limit, offset)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotExist) {
 			c.String(http.StatusNotFound, err.Error())
@@ -241,6 +253,274 @@ func (s *Server) getHistoryByAuthor(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// getFieldChangesByIssue godoc
+// @Summary Получить изменения полей задачи
+// @Description Возвращает изменения всех полей задачи (исполнитель, приоритет, резолюция, спринт и т.д.)
+// @Tags History
+// @Produce json
+// @Param issueId path int true "ID задачи"
+// @Param field query []string false "Поля, например assignee,priority (по умолчанию все)" collectionFormat(multi)
+// @Success 200 {object} models.Response
+// @Failure 400 {string} string "Неверный ID задачи"
+// @Failure 404 {string} string "Задача не найдена"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/histories/fields/by-issue/{issueId} [get]
+func (s *Server) getFieldChangesByIssue(c *gin.Context) {
+	issueId, err := strconv.Atoi(c.Params.ByName("issueId"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetFieldChangesByIssue(ctx, issueId, queryFields(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getFieldChangesByProject godoc
+// @Summary Получить изменения полей задач проекта
+// @Description Возвращает изменения полей всех задач проекта по времени с пагинацией
+// @Tags History
+// @Produce json
+// @Param projectId path int true "ID проекта"
+// @Param field query []string false "Поля, например assignee,priority (по умолчанию все)" collectionFormat(multi)
+// @Param limit query int false "Лимит записей (по умолчанию 20)"
+// @Param offset query int false "Смещение (по умолчанию 0)"
+// @Success 200 {object} models.PaginatedResponse
+// @Failure 400 {string} string "Неверные параметры запроса"
+// @Failure 404 {string} string "Проект не найден"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/histories/fields/by-project/{projectId} [get]
+func (s *Server) getFieldChangesByProject(c *gin.Context) {
+	projectId, err := strconv.Atoi(c.Params.ByName("projectId"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.Query("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetFieldChangesByProject(ctx, projectId, queryFields(c), limit, offset)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getWorklogsByIssue godoc
+// @Summary Получить списанное время по задаче
+// @Description Возвращает записи о работе по указанной задаче
+// @Tags Worklogs
+// @Produce json
+// @Param issueId path int true "ID задачи"
+// @Success 200 {object} models.Response
+// @Failure 400 {string} string "Неверный ID задачи"
+// @Failure 404 {string} string "Задача не найдена"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/worklogs/by-issue/{issueId} [get]
+func (s *Server) getWorklogsByIssue(c *gin.Context) {
+	issueId, err := strconv.Atoi(c.Params.ByName("issueId"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetWorklogsByIssue(ctx, issueId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getWorklogsByAuthor godoc
+// @Summary Получить списанное время автора
+// @Description Возвращает записи о работе указанного автора
+// @Tags Worklogs
+// @Produce json
+// @Param authorId path int true "ID автора"
+// @Success 200 {object} models.Response
+// @Failure 400 {string} string "Неверный ID автора"
+// @Failure 404 {string} string "Автор не найден"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/worklogs/by-author/{authorId} [get]
+func (s *Server) getWorklogsByAuthor(c *gin.Context) {
+	authorId, err := strconv.Atoi(c.Params.ByName("authorId"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetWorklogsByAuthor(ctx, authorId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getCommentsByIssue godoc
+// @Summary Получить комментарии задачи
+// @Description Возвращает комментарии к указанной задаче по времени создания
+// @Tags Comments
+// @Produce json
+// @Param issueId path int true "ID задачи"
+// @Success 200 {object} models.Response
+// @Failure 400 {string} string "Неверный ID задачи"
+// @Failure 404 {string} string "Задача не найдена"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/comments/by-issue/{issueId} [get]
+func (s *Server) getCommentsByIssue(c *gin.Context) {
+	issueId, err := strconv.Atoi(c.Params.ByName("issueId"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetCommentsByIssue(ctx, issueId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getReleasesByProject godoc
+// @Summary Получить релизы проекта
+// @Description Возвращает версии проекта с датами выпуска и числом задач, упорядоченные по дате выпуска
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID проекта"
+// @Success 200 {object} models.Response
+// @Failure 400 {string} string "Неверный ID проекта"
+// @Failure 404 {string} string "Проект не найден"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/projects/{id}/releases [get]
+func (s *Server) getReleasesByProject(c *gin.Context) {
+	projectId, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetReleasesByProject(ctx, projectId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+const (
+	defaultGraphDepth = 3
+	maxGraphDepth     = 10
+)
+
+// getIssueGraph godoc
+// @Summary Получить граф связей задачи
+// @Description Возвращает связанные задачи, подзадачи и эпики вокруг задачи до указанной глубины
+// @Tags Issues
+// @Produce json
+// @Param id path int true "ID задачи"
+// @Param depth query int false "Глубина обхода от 1 до 10 (по умолчанию 3)"
+// @Success 200 {object} models.Response
+// @Failure 400 {string} string "Неверные параметры запроса"
+// @Failure 404 {string} string "Задача не найдена"
+// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Router /api/v1/issues/{id}/graph [get]
+func (s *Server) getIssueGraph(c *gin.Context) {
+	issueId, err := strconv.Atoi(c.Params.ByName("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	depth := defaultGraphDepth
+	if value, ok := c.GetQuery("depth"); ok {
+		depth, err = strconv.Atoi(value)
+		if err != nil || depth < 1 || depth > maxGraphDepth {
+			c.String(http.StatusBadRequest, fmt.Sprintf("depth must be from 1 to %d", maxGraphDepth))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, "url", getFullURL(c))
+	response, err := s.service.GetIssueGraph(ctx, issueId, depth)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotExist) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// queryFields reads both ?field=assignee&field=priority and ?field=assignee,priority
+func queryFields(c *gin.Context) []string {
+	return queryValues(c, "field")
+}
+
+// queryValues reads a list parameter given either repeatedly or comma separated
+func queryValues(c *gin.Context, name string) []string {
+	values := make([]string, 0)
+	for _, param := range c.QueryArray(name) {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
 func getFullURL(c *gin.Context) string {
 	scheme := "http"
 	if c.Request.Header.Get("X-Forwarded-Proto") == "https" {
@@ -71,10 +71,17 @@ func (s *Server) registerRouters() {
 		api.GET("/projects", s.getProjects)
 		api.GET("projects/:id", s.getProject)
 		api.DELETE("/projects/:id", s.deleteProject)
+		api.GET("/projects/:id/releases", s.getReleasesByProject)
 		api.GET("/issues/:id", s.getIssue)
+		api.GET("/issues/:id/graph", s.getIssueGraph)
 		api.GET("/issues/by-project/:projectId", s.getIssuesByProject)
 		api.GET("/histories/by-issue/:issueId", s.getHistoryByIssue)
 		api.GET("/histories/by-author/:authorId", s.getHistoryByAuthor)
+		api.GET("/histories/fields/by-issue/:issueId", s.getFieldChangesByIssue)
+		api.GET("/histories/fields/by-project/:projectId", s.getFieldChangesByProject)
+		api.GET("/worklogs/by-issue/:issueId", s.getWorklogsByIssue)
+		api.GET("/worklogs/by-author/:authorId", s.getWorklogsByAuthor)
+		api.GET("/comments/by-issue/:issueId", s.getCommentsByIssue)
 	}
 }
 
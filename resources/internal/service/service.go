@@ -14,9 +14,16 @@ type Service interface {
 	GetProject(ctx context.Context, id int) (*models.Response, error)
 	DeleteProject(ctx context.Context, id int) error
 	GetIssue(ctx context.Context, id int) (*models.Response, error)
-	GetIssuesByProject(ctx context.Context, projectId int, limit int, offset int) (*models.PaginatedResponse, error)
+	GetIssuesByProject(ctx context.Context, projectId int, filter models.IssueFilter, limit int, offset int) (*models.PaginatedResponse, error)
 	GetHistoryByIssue(ctx context.Context, issueId int) (*models.Response, error)
 	GetHistoryByAuthor(ctx context.Context, authorId int) (*models.Response, error)
+	GetFieldChangesByIssue(ctx context.Context, issueId int, fields []string) (*models.Response, error)
+	GetFieldChangesByProject(ctx context.Context, projectId int, fields []string, limit int, offset int) (*models.PaginatedResponse, error)
+	GetWorklogsByIssue(ctx context.Context, issueId int) (*models.Response, error)
+	GetWorklogsByAuthor(ctx context.Context, authorId int) (*models.Response, error)
+	GetCommentsByIssue(ctx context.Context, issueId int) (*models.Response, error)
+	GetIssueGraph(ctx context.Context, issueId int, depth int) (*models.Response, error)
+	GetReleasesByProject(ctx context.Context, projectId int) (*models.Response, error)
 }
 
 type service struct {
@@ -30,7 +37,12 @@ func New(repo repository.Repository, log logger.Logger, port int) *service {
 		LinkIssues: []models.Link{{fmt.Sprintf("http://localhost:%d/api/v1/issues", port)},
 			{fmt.Sprintf("http://localhost:%d/api/v1/issues/by-project", port)}},
 		LinkHistories: []models.Link{{fmt.Sprintf("http://localhost:%d/api/v1/histories/by-issue", port)},
-			{fmt.Sprintf("http://localhost:%d/api/v1/histories/by-author", port)}}}}
+			{fmt.Sprintf("http://localhost:%d/api/v1/histories/by-author", port)},
+			{fmt.Sprintf("http://localhost:%d/api/v1/histories/fields/by-issue", port)},
+			{fmt.Sprintf("http://localhost:%d/api/v1/histories/fields/by-project", port)}},
+		LinkWorklogs: []models.Link{{fmt.Sprintf("http://localhost:%d/api/v1/worklogs/by-issue", port)},
+			{fmt.Sprintf("http://localhost:%d/api/v1/worklogs/by-author", port)}},
+		LinkComments: []models.Link{{fmt.Sprintf("http://localhost:%d/api/v1/comments/by-issue", port)}}}}
 }
 
 func (s *service) GetProjects(ctx context.Context, limit int, offset int) (*models.PaginatedResponse, error) {
@@ -92,8 +104,8 @@ func (s *service) GetIssue(ctx context.Context, id int) (*models.Response, error
 	return &response, nil
 }
 
-func (s *service) GetIssuesByProject(ctx context.Context, projectId int, limit int, offset int) (*models.PaginatedResponse, error) {
-	issues, total, err := s.repo.GetIssuesByProject(ctx, projectId, limit, offset)
+func (s *service) GetIssuesByProject(ctx context.Context, projectId int, filter models.IssueFilter, limit int, offset int) (*models.PaginatedResponse, error) {
+	issues, total, err := s.repo.GetIssuesByProject(ctx, projectId, filter, limit, offset)
 	if err != nil {
 		s.log.Error(fmt.Errorf("error getting issues : %w", err))
 		return nil, err
@@ -142,6 +154,120 @@ func (s *service) GetHistoryByAuthor(ctx context.Context, authorId int) (*models
 	return &response, nil
 }
 
+func (s *service) GetFieldChangesByIssue(ctx context.Context, issueId int, fields []string) (*models.Response, error) {
+	changes, err := s.repo.GetFieldChangesByIssue(ctx, issueId, fields)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting field changes : %w", err))
+		return nil, err
+	}
+
+	var response models.Response
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = changes
+	return &response, nil
+}
+
+func (s *service) GetFieldChangesByProject(ctx context.Context, projectId int, fields []string, limit int, offset int) (*models.PaginatedResponse, error) {
+	changes, total, err := s.repo.GetFieldChangesByProject(ctx, projectId, fields, limit, offset)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting field changes : %w", err))
+		return nil, err
+	}
+
+	pageInfo := getPageInfo(total, limit, offset)
+	var response models.PaginatedResponse
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = changes
+	response.PageInfo = pageInfo
+	return &response, nil
+}
+
+func (s *service) GetWorklogsByIssue(ctx context.Context, issueId int) (*models.Response, error) {
+	worklogs, err := s.repo.GetWorklogsByIssue(ctx, issueId)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting worklogs : %w", err))
+		return nil, err
+	}
+
+	var response models.Response
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = worklogs
+	return &response, nil
+}
+
+func (s *service) GetWorklogsByAuthor(ctx context.Context, authorId int) (*models.Response, error) {
+	worklogs, err := s.repo.GetWorklogsByAuthor(ctx, authorId)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting worklogs : %w", err))
+		return nil, err
+	}
+
+	var response models.Response
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = worklogs
+	return &response, nil
+}
+
+func (s *service) GetCommentsByIssue(ctx context.Context, issueId int) (*models.Response, error) {
+	comments, err := s.repo.GetCommentsByIssue(ctx, issueId)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting comments : %w", err))
+		return nil, err
+	}
+
+	var response models.Response
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = comments
+	return &response, nil
+}
+
+func (s *service) GetIssueGraph(ctx context.Context, issueId int, depth int) (*models.Response, error) {
+	graph, err := s.repo.GetIssueGraph(ctx, issueId, depth)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting issue graph : %w", err))
+		return nil, err
+	}
+
+	var response models.Response
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = graph
+	return &response, nil
+}
+
+func (s *service) GetReleasesByProject(ctx context.Context, projectId int) (*models.Response, error) {
+	releases, err := s.repo.GetReleasesByProject(ctx, projectId)
+	if err != nil {
+		s.log.Error(fmt.Errorf("error getting releases : %w", err))
+		return nil, err
+	}
+
+	var response models.Response
+	links, err := s.addLink(ctx)
+	if err == nil {
+		response.Links = links
+	}
+	response.Data = releases
+	return &response, nil
+}
+
 func (s *service) addLink(ctx context.Context) (models.ReferencesLinks, error) {
 	self, ok := ctx.Value("url").(string)
 	if ok {